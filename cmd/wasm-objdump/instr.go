@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+)
+
+// instr formats an instruction and its immediates.
+func (d *dumper) instr(instr *wasm.Instr) string {
+	var sb strings.Builder
+	sb.WriteString(instr.Opcode.String())
+	info := instr.Opcode.Info()
+	if info == nil {
+		return sb.String()
+	}
+	numIndices := 0
+	for _, imm := range info.Immediates {
+		switch imm {
+		case wasm.BlockTypeImmediate:
+			if vt, ok := instr.BlockType.ValType(); ok {
+				fmt.Fprintf(&sb, " %s", vt)
+			} else if index, ok := instr.BlockType.TypeIndex(); ok {
+				fmt.Fprintf(&sb, " type[%d]", index)
+			}
+		case wasm.LabelVecImmediate:
+			for _, label := range instr.Labels {
+				fmt.Fprintf(&sb, " %d", label)
+			}
+		case wasm.MemArgImmediate:
+			fmt.Fprintf(&sb, " offset=%d align=%d", instr.MemArg.Offset, uint64(1)<<instr.MemArg.Align)
+		case wasm.LaneImmediate:
+			fmt.Fprintf(&sb, " %d", instr.Lane)
+		case wasm.ShuffleImmediate:
+			for _, lane := range instr.V128 {
+				fmt.Fprintf(&sb, " %d", lane)
+			}
+		case wasm.V128Immediate:
+			sb.WriteString(" i32x4")
+			for i := 0; i < 16; i += 4 {
+				fmt.Fprintf(&sb, " 0x%08x", binary.LittleEndian.Uint32(instr.V128[i:]))
+			}
+		case wasm.I32Immediate:
+			fmt.Fprintf(&sb, " %d", int32(uint32(instr.Value)))
+		case wasm.I64Immediate:
+			fmt.Fprintf(&sb, " %d", int64(instr.Value))
+		case wasm.F32Immediate:
+			f := math.Float32frombits(uint32(instr.Value))
+			sb.WriteString(" " + strconv.FormatFloat(float64(f), 'g', -1, 32))
+		case wasm.F64Immediate:
+			f := math.Float64frombits(instr.Value)
+			sb.WriteString(" " + strconv.FormatFloat(f, 'g', -1, 64))
+		case wasm.HeapTypeImmediate:
+			fmt.Fprintf(&sb, " %s", instr.HeapType)
+		case wasm.ValTypeVecImmediate:
+			for _, vt := range instr.Types {
+				fmt.Fprintf(&sb, " %s", vt)
+			}
+		case wasm.ZeroByteImmediate:
+		default:
+			index := instr.Index
+			if numIndices > 0 {
+				index = instr.Index2
+			}
+			numIndices++
+			fmt.Fprintf(&sb, " %d", index)
+			if imm == wasm.FuncImmediate {
+				sb.WriteString(d.funcName(index))
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/internal/hexfmt"
+	"github.com/chronos-tachyon/wasmfile/leb128"
+	"github.com/chronos-tachyon/wasmfile/wasm"
+)
+
+var (
+	flagHeaders     = flag.Bool("h", false, "print the section headers, with their offsets and sizes")
+	flagDetails     = flag.Bool("x", false, "print the contents of each section")
+	flagDisassemble = flag.Bool("d", false, "disassemble function bodies")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 || !(*flagHeaders || *flagDetails || *flagDisassemble) {
+		usage()
+		os.Exit(2)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	status := 0
+	for _, name := range flag.Args() {
+		if err := dump(w, name); err != nil {
+			w.Flush()
+			fmt.Fprintf(os.Stderr, "wasm-objdump: %v\n", err)
+			status = 1
+		}
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "wasm-objdump: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(status)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: wasm-objdump [-h] [-x] [-d] file.wasm...")
+	flag.PrintDefaults()
+}
+
+func dump(w io.Writer, name string) error {
+	bin, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	module, err := wasm.Decode(bin)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	fmt.Fprintf(w, "\n%s:\tfile format wasm 0x%x\n", name, wasm.Version)
+	d := &dumper{w: w, bin: bin, module: module}
+	if *flagHeaders {
+		d.headers()
+	}
+	if *flagDetails {
+		d.details()
+	}
+	if *flagDisassemble {
+		if err := d.disassemble(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+type dumper struct {
+	w      io.Writer
+	bin    []byte
+	module *wasm.Module
+}
+
+func (d *dumper) printf(format string, args ...any) {
+	fmt.Fprintf(d.w, format, args...)
+}
+
+// title is the name of a section as objdump prints it.
+func title(id wasm.SectionID) string {
+	str := strings.ReplaceAll(id.String(), " ", "")
+	return strings.ToUpper(str[:1]) + str[1:]
+}
+
+// customName reads the name at the start of a custom section's payload.
+func customName(payload []byte) string {
+	rest, n, err := leb128.Uint32(payload)
+	if err != nil || uint64(n) > uint64(len(rest)) {
+		return ""
+	}
+	return string(rest[:n])
+}
+
+func (d *dumper) headers() {
+	d.printf("\nSections:\n\n")
+	for _, section := range d.module.Sections {
+		end := section.PayloadOffset + uint64(len(section.Payload))
+		d.printf("%9s start=0x%08x end=0x%08x (size=0x%08x)", title(section.ID), section.PayloadOffset, end, len(section.Payload))
+		_, n, err := leb128.Uint32(section.Payload)
+		switch {
+		case section.ID == wasm.CustomSection:
+			d.printf(" %q", customName(section.Payload))
+		case err != nil:
+		case section.ID == wasm.StartSection:
+			d.printf(" start: %d", n)
+		default:
+			d.printf(" count: %d", n)
+		}
+		d.printf("\n")
+	}
+}
+
+// funcName returns " <name>" for a function that has a name, or "".
+func (d *dumper) funcName(index uint32) string {
+	if names := d.module.Names; names != nil {
+		if name, ok := names.Funcs[index]; ok {
+			return " <" + name + ">"
+		}
+	}
+	return ""
+}
+
+func limits(l wasm.Limits) string {
+	str := fmt.Sprintf("initial=%d", l.Min)
+	if l.HasMax {
+		str += fmt.Sprintf(" max=%d", l.Max)
+	}
+	if l.Shared {
+		str += " shared"
+	}
+	return str
+}
+
+func globalType(gt wasm.GlobalType) string {
+	mutable := 0
+	if gt.Mutable {
+		mutable = 1
+	}
+	return fmt.Sprintf("%s mutable=%d", gt.Type, mutable)
+}
+
+func (d *dumper) details() {
+	module := d.module
+	d.printf("\nSection Details:\n")
+
+	if len(module.Types) > 0 {
+		d.printf("\nType[%d]:\n", len(module.Types))
+		for i, ft := range module.Types {
+			d.printf(" - type[%d] %s\n", i, ft)
+		}
+	}
+
+	if len(module.Imports) > 0 {
+		d.printf("\nImport[%d]:\n", len(module.Imports))
+		counts := make(map[wasm.ExternKind]uint32, 4)
+		for _, imp := range module.Imports {
+			index := counts[imp.Kind]
+			counts[imp.Kind]++
+			from := fmt.Sprintf("<- %s.%s", imp.Module, imp.Name)
+			switch imp.Kind {
+			case wasm.FuncExtern:
+				d.printf(" - func[%d] sig=%d%s %s\n", index, imp.Type, d.funcName(index), from)
+			case wasm.TableExtern:
+				d.printf(" - table[%d] type=%s %s %s\n", index, imp.Table.Elem, limits(imp.Table.Limits), from)
+			case wasm.MemoryExtern:
+				d.printf(" - memory[%d] pages: %s %s\n", index, limits(imp.Memory.Limits), from)
+			case wasm.GlobalExtern:
+				d.printf(" - global[%d] %s %s\n", index, globalType(imp.Global), from)
+			}
+		}
+	}
+
+	if len(module.Funcs) > 0 {
+		d.printf("\nFunction[%d]:\n", len(module.Funcs))
+		base := module.NumImported(wasm.FuncExtern)
+		for i, fn := range module.Funcs {
+			index := base + uint32(i)
+			d.printf(" - func[%d] sig=%d%s\n", index, fn.Type, d.funcName(index))
+		}
+	}
+
+	if len(module.Tables) > 0 {
+		d.printf("\nTable[%d]:\n", len(module.Tables))
+		base := module.NumImported(wasm.TableExtern)
+		for i, table := range module.Tables {
+			d.printf(" - table[%d] type=%s %s\n", base+uint32(i), table.Type.Elem, limits(table.Type.Limits))
+		}
+	}
+
+	if len(module.Memories) > 0 {
+		d.printf("\nMemory[%d]:\n", len(module.Memories))
+		base := module.NumImported(wasm.MemoryExtern)
+		for i, memory := range module.Memories {
+			d.printf(" - memory[%d] pages: %s\n", base+uint32(i), limits(memory.Type.Limits))
+		}
+	}
+
+	if len(module.Globals) > 0 {
+		d.printf("\nGlobal[%d]:\n", len(module.Globals))
+		base := module.NumImported(wasm.GlobalExtern)
+		for i, global := range module.Globals {
+			d.printf(" - global[%d] %s - init %s\n", base+uint32(i), globalType(global.Type), d.expr(global.Init))
+		}
+	}
+
+	if len(module.Exports) > 0 {
+		d.printf("\nExport[%d]:\n", len(module.Exports))
+		for _, export := range module.Exports {
+			name := ""
+			if export.Kind == wasm.FuncExtern {
+				name = d.funcName(export.Index)
+			}
+			d.printf(" - %s[%d]%s -> %q\n", export.Kind, export.Index, name, export.Name)
+		}
+	}
+
+	if module.Start != nil {
+		d.printf("\nStart:\n - start function: %d%s\n", module.Start.Func, d.funcName(module.Start.Func))
+	}
+
+	if len(module.Elems) > 0 {
+		d.printf("\nElem[%d]:\n", len(module.Elems))
+		for i, elem := range module.Elems {
+			count := len(elem.Funcs) + len(elem.Exprs)
+			d.printf(" - segment[%d] %s", i, elem.Mode)
+			if elem.Mode == wasm.ActiveSegment {
+				d.printf(" table=%d", elem.Table)
+			}
+			d.printf(" type=%s count=%d", elem.Type, count)
+			if elem.Mode == wasm.ActiveSegment {
+				d.printf(" - init %s", d.expr(elem.Offset))
+			}
+			d.printf("\n")
+			for j, index := range elem.Funcs {
+				d.printf("  - elem[%d] = func[%d]%s\n", j, index, d.funcName(index))
+			}
+			for j, expr := range elem.Exprs {
+				d.printf("  - elem[%d] = %s\n", j, d.expr(expr))
+			}
+		}
+	}
+
+	if module.DataCount != nil {
+		d.printf("\nDataCount:\n - data count: %d\n", *module.DataCount)
+	}
+
+	if len(module.Datas) > 0 {
+		d.printf("\nData[%d]:\n", len(module.Datas))
+		for i, data := range module.Datas {
+			d.printf(" - segment[%d] %s", i, data.Mode)
+			if data.Mode == wasm.ActiveSegment {
+				d.printf(" memory=%d", data.Memory)
+			}
+			d.printf(" size=%d", len(data.Init))
+			if data.Mode == wasm.ActiveSegment {
+				d.printf(" - init %s", d.expr(data.Offset))
+			}
+			d.printf("\n")
+			d.hexDump(data.Init)
+		}
+	}
+
+	if len(module.Customs) > 0 {
+		d.printf("\nCustom[%d]:\n", len(module.Customs))
+		for _, custom := range module.Customs {
+			d.printf(" - name: %q size=%d\n", custom.Name, len(custom.Data))
+		}
+	}
+}
+
+// hexDump prints data sixteen bytes to a line, with offsets relative to
+// the start of data.
+func (d *dumper) hexDump(data []byte) {
+	for offset := 0; offset < len(data); offset += 16 {
+		end := offset + 16
+		if end > len(data) {
+			end = len(data)
+		}
+		line := data[offset:end]
+		text := make([]byte, len(line))
+		for i, ch := range line {
+			if ch < 0x20 || ch >= 0x7f {
+				ch = '.'
+			}
+			text[i] = ch
+		}
+		d.printf("  - %07x: %-47s  %s\n", offset, hexfmt.Bytes(line), text)
+	}
+}
+
+// expr formats a constant expression on one line, without its "end".
+func (d *dumper) expr(expr wasm.Expr) string {
+	list, err := wasm.DecodeExpr(expr)
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	if n := len(list); n > 0 && list[n-1].Opcode == wasm.OpEnd {
+		list = list[:n-1]
+	}
+	parts := make([]string, len(list))
+	for i := range list {
+		parts[i] = d.instr(&list[i])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (d *dumper) disassemble() error {
+	module := d.module
+	d.printf("\nCode Disassembly:\n")
+	base := module.NumImported(wasm.FuncExtern)
+	for i, fn := range module.Funcs {
+		index := base + uint32(i)
+		list, err := wasm.DecodeExpr(fn.Body)
+		if err != nil {
+			return fmt.Errorf("func[%d]: %w", index, err)
+		}
+
+		// The body's bytes end where its size says, and the locals come
+		// between the size and the instructions.
+		entry := fn.Origin.Offset
+		rest, size, err := leb128.Uint32(d.bin[entry:])
+		if err != nil {
+			return fmt.Errorf("func[%d]: %w", index, err)
+		}
+		localsStart := uint64(len(d.bin) - len(rest))
+		bodyStart := localsStart + uint64(size) - uint64(len(fn.Body))
+
+		d.printf("\n%06x func[%d]%s:\n", entry, index, d.funcName(index))
+		d.line(localsStart, d.bin[localsStart:bodyStart], d.locals(fn.Locals))
+
+		depth := 0
+		for j := range list {
+			instr := &list[j]
+			start := instr.Origin.Offset
+			end := uint64(len(fn.Body))
+			if j+1 < len(list) {
+				end = list[j+1].Origin.Offset
+			}
+			switch instr.Opcode {
+			case wasm.OpElse, wasm.OpEnd:
+				if depth > 0 {
+					depth--
+				}
+			}
+			indent := strings.Repeat(" ", depth+1)
+			d.line(bodyStart+start, fn.Body[start:end], indent+d.instr(instr))
+			switch instr.Opcode {
+			case wasm.OpBlock, wasm.OpLoop, wasm.OpIf, wasm.OpElse:
+				depth++
+			}
+		}
+	}
+	return nil
+}
+
+// line prints one line of disassembly: the offset, the raw bytes, and what
+// they mean.
+func (d *dumper) line(offset uint64, raw []byte, text string) {
+	d.printf(" %06x: %-26s |%s\n", offset, hexfmt.Bytes(raw), text)
+}
+
+func (d *dumper) locals(locals []wasm.Local) string {
+	var sb strings.Builder
+	var index uint32
+	for _, local := range locals {
+		// An entry may declare no locals at all.
+		if local.Count == 0 {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		if local.Count == 1 {
+			fmt.Fprintf(&sb, " local[%d] type=%s", index, local.Type)
+		} else {
+			fmt.Fprintf(&sb, " local[%d..%d] type=%s", index, index+local.Count-1, local.Type)
+		}
+		index += local.Count
+	}
+	if sb.Len() == 0 {
+		return " (no locals)"
+	}
+	return sb.String()
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+)
+
+var (
+	flagOutput = flag.String("o", "", "output file, or \"-\" for stdout (default: rewrite the input file)")
+	flagStrip  = flag.String("strip", "all", "comma-separated `categories` of custom sections to remove")
+	flagKeep   = flag.String("keep", "", "comma-separated `globs` of custom section names to keep")
+	flagQuiet  = flag.Bool("q", false, "do not report the sections removed")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	stripper, err := parseFlags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wasm-strip: %v\n", err)
+		os.Exit(2)
+	}
+
+	var name string
+	var in io.Reader
+	switch flag.NArg() {
+	case 0:
+		name = "<standard input>"
+		in = os.Stdin
+		if *flagOutput == "" {
+			fmt.Fprintln(os.Stderr, "wasm-strip: -o is required with standard input")
+			os.Exit(2)
+		}
+	case 1:
+		name = flag.Arg(0)
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "wasm-strip: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	output := *flagOutput
+	if output == "" {
+		output = name
+	}
+
+	bin, stripped, err := strip(name, in, stripper)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if output == "-" {
+		_, err = os.Stdout.Write(bin)
+	} else {
+		err = os.WriteFile(output, bin, 0o666)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wasm-strip: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !*flagQuiet {
+		report(name, stripped)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: wasm-strip [flags] [file.wasm]")
+	flag.PrintDefaults()
+	fmt.Fprintln(os.Stderr, "\ncategories: debug, names, producers, all")
+}
+
+func parseFlags() (*wasm.Stripper, error) {
+	stripper := new(wasm.Stripper)
+	for _, name := range strings.Split(*flagStrip, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		category, ok := wasm.StripCategoryByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown category %q", name)
+		}
+		stripper.Category(category)
+	}
+	for _, pattern := range strings.Split(*flagKeep, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			stripper.Keep(pattern)
+		}
+	}
+	return stripper, nil
+}
+
+func strip(name string, in io.Reader, stripper *wasm.Stripper) ([]byte, []wasm.Stripped, error) {
+	bin, err := io.ReadAll(in)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	module, err := wasm.Decode(bin)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	stripped, err := stripper.Strip(module)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wasm-strip: -keep: %w", err)
+	}
+
+	out, err := wasm.Encode(module)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, stripped, nil
+}
+
+// report lists the sections removed and the bytes saved on stderr.
+func report(name string, stripped []wasm.Stripped) {
+	total := 0
+	for _, s := range stripped {
+		fmt.Fprintf(os.Stderr, "%s: removed %q: %d bytes\n", name, s.Name, s.Size)
+		total += s.Size
+	}
+	fmt.Fprintf(os.Stderr, "%s: saved %d bytes in %d sections\n", name, total, len(stripped))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wasm/text"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+var (
+	flagOutput        = flag.String("o", "", "output file (default: stdout)")
+	flagFolded        = flag.Bool("f", false, "write instructions in folded s-expression style")
+	flagInlineExports = flag.Bool("inline-exports", false, "write exports inside the definitions they export")
+	flagNoNames       = flag.Bool("no-names", false, "ignore the name section and synthesize every name")
+	flagIndent        = flag.Uint("indent", 2, "indent width, in spaces")
+	flagWidth         = flag.Uint("width", 80, "preferred maximum line width")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	var name string
+	var in io.Reader
+	switch flag.NArg() {
+	case 0:
+		name = "<standard input>"
+		in = os.Stdin
+	case 1:
+		name = flag.Arg(0)
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "wasm2wat: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	src, err := disassemble(name, in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *flagOutput == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*flagOutput, src, 0o666)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wasm2wat: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: wasm2wat [flags] [file.wasm]")
+	flag.PrintDefaults()
+}
+
+func disassemble(name string, in io.Reader) ([]byte, error) {
+	bin, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+
+	module, err := wasm.Decode(bin)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	if *flagNoNames {
+		module.Names = nil
+	} else if module.Names == nil {
+		// Decode leaves out a malformed name section; say why.
+		if custom := module.Custom(wasm.NameSectionName); custom != nil {
+			if _, err := wasm.DecodeNames(custom.Data); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: warning: ignoring name section: %v\n", name, err)
+			}
+		}
+	}
+
+	var raiser text.Raiser
+	raiser.Folded(*flagFolded).InlineExports(*flagInlineExports)
+	root, err := raiser.Raise(module)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var formatter wat.Formatter
+	formatter.IndentWidth(*flagIndent).LineWidth(*flagWidth)
+	return formatter.Append(nil, root), nil
+}
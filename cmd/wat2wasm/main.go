@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wasm/text"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+var (
+	flagOutput     = flag.String("o", "", "output file, or \"-\" for stdout (default: input with .wasm extension)")
+	flagEnable     = flag.String("enable", "", "comma-separated `features` to enable in addition to the defaults, or \"all\"")
+	flagDisable    = flag.String("disable", "", "comma-separated `features` to disable, or \"all\"")
+	flagDebugNames = flag.Bool("debug-names", false, "emit a name section from $identifiers")
+	flagFixedWidth = flag.Bool("fixed-width", false, "pad sizes and indices to 5-byte LEB128")
+	flagNoCheck    = flag.Bool("no-check", false, "skip validation of the module")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	features, err := parseFeatures()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wat2wasm: %v\n", err)
+		os.Exit(2)
+	}
+
+	var name string
+	var in io.Reader
+	switch flag.NArg() {
+	case 0:
+		name = "<standard input>"
+		in = os.Stdin
+		if *flagOutput == "" {
+			fmt.Fprintln(os.Stderr, "wat2wasm: -o is required with standard input")
+			os.Exit(2)
+		}
+	case 1:
+		name = flag.Arg(0)
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "wat2wasm: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	output := *flagOutput
+	if output == "" {
+		output = strings.TrimSuffix(name, filepath.Ext(name)) + ".wasm"
+	}
+
+	bin, errs := compile(name, in, features)
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+
+	if output == "-" {
+		_, err = os.Stdout.Write(bin)
+	} else {
+		err = os.WriteFile(output, bin, 0o666)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wat2wasm: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: wat2wasm [flags] [file.wat]")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\nfeatures: %s\ndefault: %s\n", wasm.AllFeatures, wasm.DefaultFeatures)
+}
+
+func parseFeatures() (wasm.Features, error) {
+	features := wasm.DefaultFeatures
+	if *flagEnable == "all" {
+		features = wasm.AllFeatures
+	} else {
+		set, err := wasm.ParseFeatures(*flagEnable)
+		if err != nil {
+			return 0, err
+		}
+		features |= set
+	}
+	if *flagDisable == "all" {
+		features = 0
+	} else {
+		set, err := wasm.ParseFeatures(*flagDisable)
+		if err != nil {
+			return 0, err
+		}
+		features &^= set
+	}
+	return features, nil
+}
+
+func compile(name string, in io.Reader, features wasm.Features) ([]byte, []error) {
+	src, err := io.ReadAll(in)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var parser wat.Parser
+	parser.DisableCaching(true).RecoverErrors(true)
+	root, err := parser.Parse(wat.NewLexer(src))
+	if err != nil {
+		return nil, diagnostics(name, err)
+	}
+
+	module, err := text.Lower(root)
+	if err != nil {
+		return nil, diagnostics(name, err)
+	}
+
+	if missing := module.RequiredFeatures() &^ features; missing != 0 {
+		return nil, []error{fmt.Errorf("%s: module requires features that are not enabled: %s", name, missing)}
+	}
+
+	if !*flagNoCheck {
+		if err := module.Validate(); err != nil {
+			return nil, diagnostics(name, err)
+		}
+	}
+
+	if *flagDebugNames && !module.Names.IsEmpty() {
+		module.ReplaceCustom(wasm.NameSectionName, wasm.EncodeNames(module.Names))
+	}
+
+	bin, err := new(wasm.Encoder).FixedWidth(*flagFixedWidth).Encode(module)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", name, err)}
+	}
+	return bin, nil
+}
+
+// diagnostics formats errors as "file:line:col: message".
+func diagnostics(name string, err error) []error {
+	var syntaxErrs wat.SyntaxErrors
+	var syntaxErr *wat.SyntaxError
+	var validationErrs wasm.ValidationErrors
+
+	var list []error
+	add := func(pos wat.Position, message string) {
+		list = append(list, fmt.Errorf("%s:%d:%d: %s", name, pos.Line+1, pos.Column+1, message))
+	}
+	switch {
+	case errors.As(err, &syntaxErrs):
+		for _, se := range syntaxErrs {
+			add(se.Span.Begin, se.Message())
+		}
+	case errors.As(err, &syntaxErr):
+		add(syntaxErr.Span.Begin, syntaxErr.Message())
+	case errors.As(err, &validationErrs):
+		for _, ve := range validationErrs {
+			add(ve.Origin.Span().Begin, ve.Message())
+		}
+	default:
+		list = append(list, fmt.Errorf("%s: %w", name, err))
+	}
+	return list
+}
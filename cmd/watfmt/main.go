@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/internal/diff"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+var (
+	flagList   = flag.Bool("l", false, "list files whose formatting differs from watfmt's")
+	flagWrite  = flag.Bool("w", false, "write result to (source) file instead of stdout")
+	flagDiff   = flag.Bool("d", false, "display diffs instead of rewriting files")
+	flagIndent = flag.Uint("indent", 2, "indent width, in spaces")
+	flagWidth  = flag.Uint("width", 80, "preferred maximum line width")
+)
+
+var exitCode = 0
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	var formatter wat.Formatter
+	formatter.IndentWidth(*flagIndent).LineWidth(*flagWidth)
+
+	if flag.NArg() == 0 {
+		if *flagWrite {
+			fmt.Fprintln(os.Stderr, "watfmt: cannot use -w with standard input")
+			os.Exit(2)
+		}
+		if err := processFile(&formatter, "<standard input>", os.Stdin, os.Stdout); err != nil {
+			report(err)
+		}
+		os.Exit(exitCode)
+	}
+
+	for _, arg := range flag.Args() {
+		info, err := os.Stat(arg)
+		switch {
+		case err != nil:
+			report(err)
+		case info.IsDir():
+			walkDir(&formatter, arg)
+		default:
+			if err := processPath(&formatter, arg); err != nil {
+				report(err)
+			}
+		}
+	}
+	os.Exit(exitCode)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: watfmt [flags] [path ...]")
+	flag.PrintDefaults()
+}
+
+func walkDir(formatter *wat.Formatter, root string) {
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			report(err)
+			return nil
+		}
+		if entry.IsDir() || !isWATFile(entry.Name()) {
+			return nil
+		}
+		if err := processPath(formatter, path); err != nil {
+			report(err)
+		}
+		return nil
+	})
+	if err != nil {
+		report(err)
+	}
+}
+
+func isWATFile(name string) bool {
+	return !strings.HasPrefix(name, ".") && (strings.HasSuffix(name, ".wat") || strings.HasSuffix(name, ".wast"))
+}
+
+func processPath(formatter *wat.Formatter, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return processFile(formatter, path, f, os.Stdout)
+}
+
+func processFile(formatter *wat.Formatter, name string, in io.Reader, out io.Writer) error {
+	src, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	res, err := formatter.Source(src)
+	if err != nil {
+		var se *wat.SyntaxError
+		if errors.As(err, &se) {
+			pos := se.Span.Begin
+			return fmt.Errorf("%s:%d:%d: %s", name, pos.Line+1, pos.Column+1, se.Message())
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	if bytes.Equal(src, res) {
+		if !*flagList && !*flagWrite && !*flagDiff {
+			_, err = out.Write(res)
+		}
+		return err
+	}
+
+	if *flagList {
+		fmt.Fprintln(out, name)
+	}
+	if *flagWrite {
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, res, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if *flagDiff {
+		out.Write(diff.Unified(name+".orig", name, src, res))
+	}
+	if !*flagList && !*flagWrite && !*flagDiff {
+		_, err = out.Write(res)
+	}
+	return err
+}
+
+func report(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	exitCode = 2
+}
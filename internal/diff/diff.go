@@ -0,0 +1,200 @@
+package diff
+
+import (
+	"bytes"
+	"strconv"
+)
+
+const contextLines = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	text string
+}
+
+func Unified(oldName string, newName string, oldText []byte, newText []byte) []byte {
+	if bytes.Equal(oldText, newText) {
+		return nil
+	}
+
+	ops := editScript(splitLines(oldText), splitLines(newText))
+	opsLen := uint(len(ops))
+
+	oldLine := make([]uint, opsLen+1)
+	newLine := make([]uint, opsLen+1)
+	for i := uint(0); i < opsLen; i++ {
+		oldLine[i+1] = oldLine[i]
+		newLine[i+1] = newLine[i]
+		if ops[i].kind != opInsert {
+			oldLine[i+1]++
+		}
+		if ops[i].kind != opDelete {
+			newLine[i+1]++
+		}
+	}
+
+	var out []byte
+	out = append(out, "--- "...)
+	out = append(out, oldName...)
+	out = append(out, '\n')
+	out = append(out, "+++ "...)
+	out = append(out, newName...)
+	out = append(out, '\n')
+
+	i := uint(0)
+	for i < opsLen {
+		if ops[i].kind == opEqual {
+			i++
+			continue
+		}
+
+		start := uint(0)
+		if i > contextLines {
+			start = i - contextLines
+		}
+
+		end := i
+		for end < opsLen {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < opsLen && ops[run].kind == opEqual {
+				run++
+			}
+			if run >= opsLen || run-end > 2*contextLines {
+				end += contextLines
+				if end > opsLen {
+					end = opsLen
+				}
+				break
+			}
+			end = run
+		}
+
+		out = append(out, "@@ -"...)
+		out = appendRange(out, oldLine[start], oldLine[end]-oldLine[start])
+		out = append(out, " +"...)
+		out = appendRange(out, newLine[start], newLine[end]-newLine[start])
+		out = append(out, " @@\n"...)
+		for _, x := range ops[start:end] {
+			out = append(out, byte(x.kind))
+			out = append(out, x.text...)
+			if len(x.text) == 0 || x.text[len(x.text)-1] != '\n' {
+				out = append(out, "\n\\ No newline at end of file\n"...)
+			}
+		}
+
+		i = end
+	}
+	return out
+}
+
+func appendRange(out []byte, start uint, count uint) []byte {
+	if count > 0 {
+		start++
+	}
+	out = strconv.AppendUint(out, uint64(start), 10)
+	if count != 1 {
+		out = append(out, ',')
+		out = strconv.AppendUint(out, uint64(count), 10)
+	}
+	return out
+}
+
+func splitLines(text []byte) []string {
+	var lines []string
+	for len(text) > 0 {
+		i := bytes.IndexByte(text, '\n')
+		if i < 0 {
+			lines = append(lines, string(text))
+			break
+		}
+		lines = append(lines, string(text[:i+1]))
+		text = text[i+1:]
+	}
+	return lines
+}
+
+// editScript computes a shortest edit script using Myers' O(ND)
+// algorithm, then walks the recorded frontiers backwards to recover it.
+func editScript(a []string, b []string) []op {
+	n := len(a)
+	m := len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	var trace [][]int
+	found := false
+	for d := 0; d <= max && !found; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	ops := make([]op, 0, max)
+	x := n
+	y := m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, op{opEqual, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, op{opInsert, b[y-1]})
+			} else {
+				ops = append(ops, op{opDelete, a[x-1]})
+			}
+		}
+		x = prevX
+		y = prevY
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
@@ -0,0 +1,56 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Old    string
+		New    string
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Same",
+			Old:    "a\nb\n",
+			New:    "a\nb\n",
+			Expect: "",
+		},
+		{
+			Name:   "Change",
+			Old:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			New:    "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			Expect: "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			Name:   "TwoHunks",
+			Old:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			New:    "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			Expect: "--- a\n+++ b\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -9,4 +10,3 @@\n 9\n 10\n 11\n-12\n",
+		},
+		{
+			Name:   "NoNewline",
+			Old:    "a",
+			New:    "a\n",
+			Expect: "--- a\n+++ b\n@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+a\n",
+		},
+		{
+			Name:   "FromEmpty",
+			Old:    "",
+			New:    "x\ny\n",
+			Expect: "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual := string(Unified("a", "b", []byte(row.Old), []byte(row.New)))
+			if actual != row.Expect {
+				t.Errorf("wrong output\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package hexfmt formats bytes as space-separated hex pairs.
+package hexfmt
+
+import (
+	"fmt"
+)
+
+// Bytes formats as "de ad be ef".
+type Bytes []byte
+
+func (hex Bytes) GoString() string {
+	return string(hex.AppendTo(nil))
+}
+
+func (hex Bytes) String() string {
+	return hex.GoString()
+}
+
+func (hex Bytes) AppendTo(out []byte) []byte {
+	const hexDigits = "0123456789abcdef"
+	for i, ch := range hex {
+		if i > 0 {
+			out = append(out, ' ')
+		}
+		out = append(out, hexDigits[ch>>4], hexDigits[ch&0xf])
+	}
+	return out
+}
+
+var (
+	_ fmt.GoStringer = Bytes(nil)
+	_ fmt.Stringer   = Bytes(nil)
+)
@@ -0,0 +1,31 @@
+package hexfmt
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  Bytes
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{"Nil", nil, ""},
+		{"One", Bytes{0x0b}, "0b"},
+		{"Many", Bytes{0xde, 0xad, 0xbe, 0xef}, "de ad be ef"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if actual := row.Input.String(); actual != row.Expect {
+				t.Errorf("String: expect %q, got %q", row.Expect, actual)
+			}
+			if actual := fmt.Sprintf("%-14v|", row.Input); actual != fmt.Sprintf("%-14s|", row.Expect) {
+				t.Errorf("padded: got %q", actual)
+			}
+		})
+	}
+}
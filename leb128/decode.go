@@ -0,0 +1,291 @@
+package leb128
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrTruncated means that the input ended in the middle of a value.
+	ErrTruncated = errors.New("leb128: truncated encoding")
+
+	// ErrOverflow means that a value continued past the maximum number
+	// of bytes for its width.
+	ErrOverflow = errors.New("leb128: encoding too long")
+
+	// ErrUnusedBits means that the final byte of a value had bits set
+	// beyond its width, or for a signed value, bits that were not copies
+	// of the sign bit.
+	ErrUnusedBits = errors.New("leb128: unused bits set")
+
+	// ErrOverlong means that a value was not minimally encoded.  It is
+	// only reported by a Decoder with RejectOverlong enabled.
+	ErrOverlong = errors.New("leb128: non-minimal encoding")
+)
+
+// Decoder holds decoding options.  The zero value, or a nil *Decoder,
+// accepts padded encodings as the WebAssembly binary format does.
+type Decoder struct {
+	rejectOverlong bool
+}
+
+// RejectOverlong makes the decoder fail with ErrOverlong when a value
+// uses more bytes than necessary.
+func (dec *Decoder) RejectOverlong(value bool) *Decoder {
+	dec.rejectOverlong = value
+	return dec
+}
+
+// UintN decodes an unsigned value of at most numBits bits from the front of
+// in.  On error, rest is in.
+func (dec *Decoder) UintN(in []byte, numBits uint) (rest []byte, out uint64, err error) {
+	r := sliceReader{in: in}
+	out, err = dec.decode(&r, numBits, false)
+	if err != nil {
+		return in, 0, sliceError(err)
+	}
+	return in[r.pos:], out, nil
+}
+
+// IntN decodes a signed value of at most numBits bits from the front of
+// in.  On error, rest is in.
+func (dec *Decoder) IntN(in []byte, numBits uint) (rest []byte, out int64, err error) {
+	r := sliceReader{in: in}
+	var u64 uint64
+	u64, err = dec.decode(&r, numBits, true)
+	if err != nil {
+		return in, 0, sliceError(err)
+	}
+	return in[r.pos:], int64(u64), nil
+}
+
+// ReadUintN reads an unsigned value of at most numBits bits.  It returns
+// io.EOF only if no bytes could be read at all.
+func (dec *Decoder) ReadUintN(r io.ByteReader, numBits uint) (uint64, error) {
+	return dec.decode(r, numBits, false)
+}
+
+// ReadIntN reads a signed value of at most numBits bits.  It returns io.EOF
+// only if no bytes could be read at all.
+func (dec *Decoder) ReadIntN(r io.ByteReader, numBits uint) (int64, error) {
+	u64, err := dec.decode(r, numBits, true)
+	return int64(u64), err
+}
+
+func (dec *Decoder) decode(r io.ByteReader, numBits uint, signed bool) (uint64, error) {
+	maxLen := (numBits + 6) / 7
+	var u64 uint64
+	var shift uint
+	var prev byte
+	for i := uint(0); ; i++ {
+		ch, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				err = ErrTruncated
+			}
+			return 0, err
+		}
+
+		payload := ch & 0x7f
+		more := (ch & 0x80) != 0
+		if i == maxLen-1 {
+			if more {
+				return 0, ErrOverflow
+			}
+			if used := numBits - shift; used < 7 {
+				if signed {
+					extra := payload >> (used - 1)
+					if extra != 0 && extra != (0x7f>>(used-1)) {
+						return 0, ErrUnusedBits
+					}
+				} else if (payload >> used) != 0 {
+					return 0, ErrUnusedBits
+				}
+			}
+		}
+
+		u64 |= uint64(payload) << shift
+		shift += 7
+		if more {
+			prev = ch
+			continue
+		}
+
+		if dec != nil && dec.rejectOverlong && i > 0 && isRedundant(ch, prev, signed) {
+			return 0, ErrOverlong
+		}
+		if signed && (payload&0x40) != 0 && shift < 64 {
+			// extend the sign bit to fill unused bits of uint64
+			u64 |= ^((uint64(1) << shift) - 1)
+		}
+		return u64, nil
+	}
+}
+
+// isRedundant reports whether the final byte of a multi-byte encoding adds
+// nothing to the byte before it.
+func isRedundant(ch byte, prev byte, signed bool) bool {
+	if !signed {
+		return ch == 0x00
+	}
+	prevNeg := (prev & 0x40) != 0
+	return (ch == 0x00 && !prevNeg) || (ch == 0x7f && prevNeg)
+}
+
+type sliceReader struct {
+	in  []byte
+	pos int
+}
+
+func (r *sliceReader) ReadByte() (byte, error) {
+	if r.pos >= len(r.in) {
+		return 0, io.EOF
+	}
+	ch := r.in[r.pos]
+	r.pos++
+	return ch, nil
+}
+
+func sliceError(err error) error {
+	if err == io.EOF {
+		return ErrTruncated
+	}
+	return err
+}
+
+// Len returns the length of the value at the front of in, and whether it
+// is complete.
+func Len(in []byte) (uint, bool) {
+	inLen := uint(len(in))
+	i := uint(0)
+	ok := false
+	for i < inLen {
+		ch := in[i]
+		i++
+		if (ch & 0x80) == 0 {
+			ok = true
+			break
+		}
+	}
+	return i, ok
+}
+
+func UintN(in []byte, numBits uint) (rest []byte, out uint64, err error) {
+	return (*Decoder)(nil).UintN(in, numBits)
+}
+
+func Uint64(in []byte) (rest []byte, out uint64, err error) {
+	return UintN(in, 64)
+}
+
+func Uint32(in []byte) (rest []byte, out uint32, err error) {
+	var u64 uint64
+	rest, u64, err = UintN(in, 32)
+	out = uint32(u64)
+	return
+}
+
+func Uint16(in []byte) (rest []byte, out uint16, err error) {
+	var u64 uint64
+	rest, u64, err = UintN(in, 16)
+	out = uint16(u64)
+	return
+}
+
+func Uint8(in []byte) (rest []byte, out uint8, err error) {
+	var u64 uint64
+	rest, u64, err = UintN(in, 8)
+	out = uint8(u64)
+	return
+}
+
+func IntN(in []byte, numBits uint) (rest []byte, out int64, err error) {
+	return (*Decoder)(nil).IntN(in, numBits)
+}
+
+func Int64(in []byte) (rest []byte, out int64, err error) {
+	return IntN(in, 64)
+}
+
+func Int33(in []byte) (rest []byte, out uint32, neg bool, err error) {
+	var s64 int64
+	rest, s64, err = IntN(in, 33)
+	out = uint32(s64)
+	neg = (s64 < 0)
+	return
+}
+
+func Int32(in []byte) (rest []byte, out int32, err error) {
+	var s64 int64
+	rest, s64, err = IntN(in, 32)
+	out = int32(s64)
+	return
+}
+
+func Int16(in []byte) (rest []byte, out int16, err error) {
+	var s64 int64
+	rest, s64, err = IntN(in, 16)
+	out = int16(s64)
+	return
+}
+
+func Int8(in []byte) (rest []byte, out int8, err error) {
+	var s64 int64
+	rest, s64, err = IntN(in, 8)
+	out = int8(s64)
+	return
+}
+
+func ReadUintN(r io.ByteReader, numBits uint) (uint64, error) {
+	return (*Decoder)(nil).ReadUintN(r, numBits)
+}
+
+func ReadUint64(r io.ByteReader) (uint64, error) {
+	return ReadUintN(r, 64)
+}
+
+func ReadUint32(r io.ByteReader) (uint32, error) {
+	u64, err := ReadUintN(r, 32)
+	return uint32(u64), err
+}
+
+func ReadUint16(r io.ByteReader) (uint16, error) {
+	u64, err := ReadUintN(r, 16)
+	return uint16(u64), err
+}
+
+func ReadUint8(r io.ByteReader) (uint8, error) {
+	u64, err := ReadUintN(r, 8)
+	return uint8(u64), err
+}
+
+func ReadIntN(r io.ByteReader, numBits uint) (int64, error) {
+	return (*Decoder)(nil).ReadIntN(r, numBits)
+}
+
+func ReadInt64(r io.ByteReader) (int64, error) {
+	return ReadIntN(r, 64)
+}
+
+func ReadInt33(r io.ByteReader) (out uint32, neg bool, err error) {
+	var s64 int64
+	s64, err = ReadIntN(r, 33)
+	out = uint32(s64)
+	neg = (s64 < 0)
+	return
+}
+
+func ReadInt32(r io.ByteReader) (int32, error) {
+	s64, err := ReadIntN(r, 32)
+	return int32(s64), err
+}
+
+func ReadInt16(r io.ByteReader) (int16, error) {
+	s64, err := ReadIntN(r, 16)
+	return int16(s64), err
+}
+
+func ReadInt8(r io.ByteReader) (int8, error) {
+	s64, err := ReadIntN(r, 8)
+	return int8(s64), err
+}
@@ -0,0 +1,111 @@
+package leb128
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestDecoder_Errors(t *testing.T) {
+	type testCase struct {
+		Encoded        []byte
+		NumBits        uint
+		Signed         bool
+		RejectOverlong bool
+		Expect         int64
+		Err            error
+	}
+
+	testData := [...]testCase{
+		{[]byte{}, 32, false, false, 0, ErrTruncated},
+		{[]byte{0x80}, 32, false, false, 0, ErrTruncated},
+		{[]byte{0xff, 0xff}, 32, true, false, 0, ErrTruncated},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80}, 32, false, false, 0, ErrOverflow},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0x1f}, 32, false, false, 0, ErrUnusedBits},
+		{[]byte{0xff, 0x03}, 8, false, false, 0, ErrUnusedBits},
+		{[]byte{0xff, 0x01}, 8, false, false, 0xff, nil},
+		{[]byte{0x80, 0x00}, 32, false, false, 0, nil},
+		{[]byte{0x80, 0x00}, 32, false, true, 0, ErrOverlong},
+		{[]byte{0x80, 0x01}, 32, false, true, 0x80, nil},
+		{[]byte{0xff, 0x7f}, 32, true, true, 0, ErrOverlong},
+		{[]byte{0xc0, 0x00}, 32, true, true, 0x40, nil},
+		{[]byte{0x80, 0x7f}, 32, true, true, -0x80, nil},
+		{[]byte{0x00}, 32, true, true, 0, nil},
+	}
+
+	for _, row := range testData {
+		name := fmt.Sprintf("%v/%d/%v/%v", PrettyBytes(row.Encoded), row.NumBits, row.Signed, row.RejectOverlong)
+		t.Run(name, func(t *testing.T) {
+			dec := new(Decoder).RejectOverlong(row.RejectOverlong)
+
+			var rest []byte
+			var decoded int64
+			var err error
+			if row.Signed {
+				rest, decoded, err = dec.IntN(row.Encoded, row.NumBits)
+			} else {
+				var u64 uint64
+				rest, u64, err = dec.UintN(row.Encoded, row.NumBits)
+				decoded = int64(u64)
+			}
+			if err != row.Err {
+				t.Errorf("expect error %v, got %v", row.Err, err)
+			}
+			if err != nil && len(rest) != len(row.Encoded) {
+				t.Errorf("expect rest to be the whole input on error, got %v", PrettyBytes(rest))
+			}
+			if err == nil && decoded != row.Expect {
+				t.Errorf("wrong result: expect %d, got %d", row.Expect, decoded)
+			}
+		})
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := WriteUint32(&buf, uint32(624485)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := WriteInt64(&buf, int64(-123456)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := WriteUintPadded(&buf, uint32(3), 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expect := []byte{0xe5, 0x8e, 0x26, 0xc0, 0xbb, 0x78, 0x83, 0x80, 0x80, 0x80, 0x00}
+	if !bytes.Equal(buf.Bytes(), expect) {
+		t.Fatalf("wrong result:\n\texpect: %v\n\tactual: %v", PrettyBytes(expect), PrettyBytes(buf.Bytes()))
+	}
+
+	r := bytes.NewReader(buf.Bytes())
+	if u32, err := ReadUint32(r); err != nil || u32 != 624485 {
+		t.Errorf("ReadUint32: expect 624485, got %d, %v", u32, err)
+	}
+	if s64, err := ReadInt64(r); err != nil || s64 != -123456 {
+		t.Errorf("ReadInt64: expect -123456, got %d, %v", s64, err)
+	}
+	if _, err := new(Decoder).RejectOverlong(true).ReadUintN(r, 32); err != ErrOverlong {
+		t.Errorf("ReadUintN: expect ErrOverlong, got %v", err)
+	}
+	if _, err := ReadUint32(r); err != io.EOF {
+		t.Errorf("ReadUint32 at end: expect io.EOF, got %v", err)
+	}
+	if _, err := ReadUint32(bytes.NewReader([]byte{0x80})); err != ErrTruncated {
+		t.Errorf("ReadUint32 of partial value: expect ErrTruncated, got %v", err)
+	}
+
+	failure := errors.New("read failure")
+	if _, err := ReadUint32(failingReader{failure}); err != failure {
+		t.Errorf("ReadUint32 of failing reader: expect %v, got %v", failure, err)
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) ReadByte() (byte, error) {
+	return 0, r.err
+}
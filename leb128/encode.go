@@ -2,6 +2,7 @@ package leb128
 
 import (
 	"fmt"
+	"io"
 	"math/bits"
 )
 
@@ -67,6 +68,24 @@ func AppendUint8[T Uintish](out []byte, value T) []byte {
 	return AppendUintN(out, value, 8)
 }
 
+// PutUintPadded encodes value using exactly numBytes bytes, padding with
+// continuation bytes as needed, so that the field can be patched in place
+// later without moving anything after it.
+func PutUintPadded[T Uintish](out []byte, value T, numBytes uint) uint {
+	u64 := uint64(value)
+	minBits := 64 - uint(bits.LeadingZeros64(u64))
+	if numBytes < encodedLen(u64, minBits) || numBytes > 10 {
+		panic(fmt.Errorf("value %d cannot be represented in exactly %d bytes", u64, numBytes))
+	}
+	return encode(out, u64, 7*numBytes)
+}
+
+func AppendUintPadded[T Uintish](out []byte, value T, numBytes uint) []byte {
+	return appendImpl(out, numBytes, func(p []byte) uint {
+		return PutUintPadded(p, value, numBytes)
+	})
+}
+
 type Intish interface {
 	~int64 | ~int32 | ~int16 | ~int8 | ~int
 }
@@ -121,7 +140,7 @@ func PutIntN[T Intish](out []byte, value T, numBits uint) uint {
 	if minBits > numBits {
 		panic(fmt.Errorf("int%d value %d requires %d bits to represent", numBits, s64, minBits))
 	}
-	return encode(out, u64, minBits)
+	return encodeSigned(out, s64, minBits)
 }
 
 func PutInt64[T Intish](out []byte, value T) uint {
@@ -179,6 +198,62 @@ func AppendInt33(out []byte, u32 uint32, neg bool) []byte {
 	return AppendIntN(out, s64, 33)
 }
 
+func WriteUintN[T Uintish](w io.Writer, value T, numBits uint) (int, error) {
+	var scratch [10]byte
+	n := PutUintN(scratch[:], value, numBits)
+	return w.Write(scratch[:n])
+}
+
+func WriteUint64[T Uintish](w io.Writer, value T) (int, error) {
+	return WriteUintN(w, value, 64)
+}
+
+func WriteUint32[T Uintish](w io.Writer, value T) (int, error) {
+	return WriteUintN(w, value, 32)
+}
+
+func WriteUint16[T Uintish](w io.Writer, value T) (int, error) {
+	return WriteUintN(w, value, 16)
+}
+
+func WriteUint8[T Uintish](w io.Writer, value T) (int, error) {
+	return WriteUintN(w, value, 8)
+}
+
+func WriteUintPadded[T Uintish](w io.Writer, value T, numBytes uint) (int, error) {
+	var scratch [10]byte
+	n := PutUintPadded(scratch[:], value, numBytes)
+	return w.Write(scratch[:n])
+}
+
+func WriteIntN[T Intish](w io.Writer, value T, numBits uint) (int, error) {
+	var scratch [10]byte
+	n := PutIntN(scratch[:], value, numBits)
+	return w.Write(scratch[:n])
+}
+
+func WriteInt64[T Intish](w io.Writer, value T) (int, error) {
+	return WriteIntN(w, value, 64)
+}
+
+func WriteInt32[T Intish](w io.Writer, value T) (int, error) {
+	return WriteIntN(w, value, 32)
+}
+
+func WriteInt16[T Intish](w io.Writer, value T) (int, error) {
+	return WriteIntN(w, value, 16)
+}
+
+func WriteInt8[T Intish](w io.Writer, value T) (int, error) {
+	return WriteIntN(w, value, 8)
+}
+
+func WriteInt33(w io.Writer, u32 uint32, neg bool) (int, error) {
+	var scratch [10]byte
+	n := PutInt33(scratch[:], u32, neg)
+	return w.Write(scratch[:n])
+}
+
 func encodedLen(value uint64, numBits uint) uint {
 	var numBytes uint = 1
 	for numBits > 7 {
@@ -201,11 +276,24 @@ func encode(out []byte, value uint64, numBits uint) uint {
 	return size
 }
 
+func encodeSigned(out []byte, value int64, numBits uint) uint {
+	var size uint
+	for numBits > 7 {
+		out[size] = byte(value) | 0x80
+		size++
+		value >>= 7
+		numBits -= 7
+	}
+	out[size] = byte(value) & 0x7f
+	size++
+	return size
+}
+
 func appendImpl(out []byte, maxSize uint, putfn func([]byte) uint) []byte {
 	outLen := uint(len(out))
 	outCap := uint(cap(out))
 	minCap := (outLen + maxSize)
-	if minCap < outCap {
+	if outCap < minCap {
 		newCap := outCap << 2
 		if newCap < minCap {
 			newCap = minCap
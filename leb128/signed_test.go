@@ -103,7 +103,7 @@ func TestSigned(t *testing.T) {
 		},
 		{
 			Input:   -0x8000000000000000,
-			Encoded: []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01},
+			Encoded: []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x7f},
 		},
 	}
 
@@ -116,9 +116,9 @@ func TestSigned(t *testing.T) {
 				t.Errorf("AppendInt64 gives wrong result:\n\texpect: %v\n\tactual: %v", PrettyBytes(row.Encoded), PrettyBytes(encoded))
 				return
 			}
-			rest, decoded, ok := Int64(encoded)
-			if !ok {
-				t.Error("Int64 fails unexpectedly")
+			rest, decoded, err := Int64(encoded)
+			if err != nil {
+				t.Errorf("Int64 fails unexpectedly: %v", err)
 				return
 			}
 			if decoded != row.Input {
@@ -130,3 +130,34 @@ func TestSigned(t *testing.T) {
 		})
 	}
 }
+
+func TestSigned_Padded(t *testing.T) {
+	type testCase struct {
+		Encoded []byte
+		NumBits uint
+		Expect  int64
+		Err     error
+	}
+
+	testData := [...]testCase{
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0x7f}, 32, -1, nil},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x00}, 32, 0, nil},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0x0f}, 32, -1, ErrUnusedBits},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x70}, 32, 0, ErrUnusedBits},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x00}, 32, 0, ErrOverflow},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, 64, 0, ErrUnusedBits},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, 64, -1, nil},
+	}
+
+	for _, row := range testData {
+		t.Run(fmt.Sprintf("%v", PrettyBytes(row.Encoded)), func(t *testing.T) {
+			_, decoded, err := IntN(row.Encoded, row.NumBits)
+			if err != row.Err {
+				t.Errorf("IntN: expect error %v, got %v", row.Err, err)
+			}
+			if err == nil && decoded != row.Expect {
+				t.Errorf("IntN gives wrong result: expect %d, got %d", row.Expect, decoded)
+			}
+		})
+	}
+}
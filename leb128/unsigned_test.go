@@ -75,9 +75,9 @@ func TestUnsigned(t *testing.T) {
 			if !bytes.Equal(encoded, row.Encoded) {
 				t.Errorf("AppendUint64 gives wrong result:\n\texpect: %v\n\tactual: %v", PrettyBytes(row.Encoded), PrettyBytes(encoded))
 			}
-			rest, decoded, ok := Uint64(encoded)
-			if !ok {
-				t.Error("Uint64 fails unexpectedly")
+			rest, decoded, err := Uint64(encoded)
+			if err != nil {
+				t.Errorf("Uint64 fails unexpectedly: %v", err)
 				return
 			}
 			if decoded != row.Input {
@@ -90,6 +90,40 @@ func TestUnsigned(t *testing.T) {
 	}
 }
 
+func TestAppendGrows(t *testing.T) {
+	encoded := AppendUint32([]byte{0x2a}, uint32(300))
+	encoded = AppendInt32(encoded, int32(-300))
+	expect := []byte{0x2a, 0xac, 0x02, 0xd4, 0x7d}
+	if !bytes.Equal(encoded, expect) {
+		t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", PrettyBytes(expect), PrettyBytes(encoded))
+	}
+}
+
+func TestUintPadded(t *testing.T) {
+	type testCase struct {
+		Input    uint64
+		NumBytes uint
+		Encoded  []byte
+	}
+
+	testData := [...]testCase{
+		{0, 1, []byte{0x00}},
+		{0, 5, []byte{0x80, 0x80, 0x80, 0x80, 0x00}},
+		{300, 5, []byte{0xac, 0x82, 0x80, 0x80, 0x00}},
+		{0xffffffff, 5, []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+
+	for _, row := range testData {
+		encoded := AppendUintPadded(nil, row.Input, row.NumBytes)
+		if !bytes.Equal(encoded, row.Encoded) {
+			t.Errorf("AppendUintPadded(%d, %d) gives wrong result:\n\texpect: %v\n\tactual: %v", row.Input, row.NumBytes, PrettyBytes(row.Encoded), PrettyBytes(encoded))
+		}
+		if _, decoded, err := Uint32(encoded); err != nil || uint64(decoded) != row.Input {
+			t.Errorf("Uint32 fails to decode %v: %v", PrettyBytes(encoded), err)
+		}
+	}
+}
+
 type PrettyBytes []byte
 
 func (pb PrettyBytes) String() string {
@@ -0,0 +1,113 @@
+package wasm
+
+import (
+	"path"
+)
+
+// CustomPlace locates a custom section relative to the known sections.
+// The zero value is the end of the module.
+type CustomPlace struct {
+	// Section is the known section that the custom section is written
+	// next to, or CustomSection for the end of the module.  If the module
+	// has no such section, the custom section goes where it would be.
+	Section SectionID
+
+	// Before writes the custom section just before Section, rather than
+	// just after it.
+	Before bool
+}
+
+// rank orders a custom section among the known sections, each of which
+// has rank 2*sectionOrder[id].
+func (place CustomPlace) rank() int {
+	if place.Section == CustomSection || !place.Section.IsKnown() {
+		return 2*len(sectionOrder) + 1
+	}
+	rank := 2 * int(sectionOrder[place.Section])
+	if place.Before {
+		return rank - 1
+	}
+	return rank + 1
+}
+
+// Custom returns the first custom section with the given name, or nil if
+// there is none.
+func (module *Module) Custom(name string) *Custom {
+	for _, custom := range module.Customs {
+		if custom.Name == name {
+			return custom
+		}
+	}
+	return nil
+}
+
+// MatchCustoms returns the custom sections whose names match pattern, a
+// glob as in path.Match, in order.
+func (module *Module) MatchCustoms(pattern string) ([]*Custom, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	var list []*Custom
+	for _, custom := range module.Customs {
+		if matched, _ := path.Match(pattern, custom.Name); matched {
+			list = append(list, custom)
+		}
+	}
+	return list, nil
+}
+
+// InsertCustom adds a custom section at the given place, after any that
+// are already there.
+func (module *Module) InsertCustom(place CustomPlace, name string, data []byte) *Custom {
+	custom := &Custom{Name: name, Data: data, Place: place}
+	module.Customs = append(module.Customs, custom)
+	return custom
+}
+
+// ReplaceCustom replaces the data of the first custom section with the
+// given name, which keeps its place, or else adds one at the end of the
+// module.
+func (module *Module) ReplaceCustom(name string, data []byte) *Custom {
+	if custom := module.Custom(name); custom != nil {
+		custom.Data = data
+		return custom
+	}
+	return module.InsertCustom(CustomPlace{}, name, data)
+}
+
+// StripCustoms removes the custom sections whose names match pattern, a
+// glob as in path.Match, and returns how many it removed.  If it removes
+// the "name" section, it also sets Names to nil.
+func (module *Module) StripCustoms(pattern string) (int, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return 0, err
+	}
+	removed := module.removeCustoms(func(custom *Custom) bool {
+		matched, _ := path.Match(pattern, custom.Name)
+		return matched
+	})
+	return len(removed), nil
+}
+
+// removeCustoms removes the custom sections for which strip returns true,
+// and returns them in order.  Names goes with the "name" section, since it
+// is what Decode made of it.
+func (module *Module) removeCustoms(strip func(custom *Custom) bool) []*Custom {
+	var removed []*Custom
+	kept := module.Customs[:0]
+	for _, custom := range module.Customs {
+		if !strip(custom) {
+			kept = append(kept, custom)
+			continue
+		}
+		removed = append(removed, custom)
+		if custom.Name == NameSectionName {
+			module.Names = nil
+		}
+	}
+	for i := len(kept); i < len(module.Customs); i++ {
+		module.Customs[i] = nil
+	}
+	module.Customs = kept
+	return removed
+}
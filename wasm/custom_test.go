@@ -0,0 +1,91 @@
+package wasm
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCustoms(t *testing.T) {
+	input := bin(
+		sec(CustomSection, 0x01, 'a'),
+		sec(TypeSection, 0x01, 0x60, 0x00, 0x00),
+		sec(CustomSection, 0x03, 'b', '.', '1', 0x01),
+		sec(CustomSection, 0x03, 'b', '.', '2', 0x02),
+		sec(FunctionSection, 0x01, 0x00),
+		sec(CodeSection, 0x01, 0x02, 0x00, 0x0b),
+		sec(CustomSection, 0x01, 'c'),
+	)
+	module, err := Decode(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	places := make([]CustomPlace, 0, len(module.Customs))
+	for _, custom := range module.Customs {
+		places = append(places, custom.Place)
+	}
+	expectPlaces := []CustomPlace{
+		{Section: TypeSection, Before: true},
+		{Section: TypeSection},
+		{Section: TypeSection},
+		{Section: CodeSection},
+	}
+	if !reflect.DeepEqual(places, expectPlaces) {
+		t.Errorf("wrong places:\n\texpect: %+v\n\tactual: %+v", expectPlaces, places)
+	}
+
+	list, err := module.MatchCustoms("b.*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "b.1" || list[1].Name != "b.2" {
+		t.Errorf("wrong match: %+v", list)
+	}
+	if _, err := module.MatchCustoms("["); err == nil {
+		t.Errorf("expect error for bad pattern")
+	}
+
+	if n, err := module.StripCustoms("b.1"); err != nil || n != 1 {
+		t.Errorf("wrong strip: %d, %v", n, err)
+	}
+	module.ReplaceCustom("c", []byte{0x03})
+	module.ReplaceCustom("d", []byte{0x04})
+	module.InsertCustom(CustomPlace{Section: ImportSection, Before: true}, "e", nil)
+	module.InsertCustom(CustomPlace{Section: TypeSection, Before: true}, "f", nil)
+
+	expect := bin(
+		sec(CustomSection, 0x01, 'a'),
+		sec(CustomSection, 0x01, 'f'),
+		sec(TypeSection, 0x01, 0x60, 0x00, 0x00),
+		sec(CustomSection, 0x03, 'b', '.', '2', 0x02),
+		sec(CustomSection, 0x01, 'e'),
+		sec(FunctionSection, 0x01, 0x00),
+		sec(CodeSection, 0x01, 0x02, 0x00, 0x0b),
+		sec(CustomSection, 0x01, 'c', 0x03),
+		sec(CustomSection, 0x01, 'd', 0x04),
+	)
+	actual, err := Encode(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+}
+
+func TestStripCustoms_Names(t *testing.T) {
+	module, err := Decode(bin(sec(CustomSection, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x02, 0x01, 'm')))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if module.Names == nil {
+		t.Fatalf("expect names")
+	}
+	if n, err := module.StripCustoms(NameSectionName); err != nil || n != 1 {
+		t.Errorf("wrong strip: %d, %v", n, err)
+	}
+	if module.Names != nil {
+		t.Errorf("expect no names, got %+v", module.Names)
+	}
+}
@@ -0,0 +1,712 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+const (
+	Magic   = "\x00asm"
+	Version = 1
+)
+
+type decoder struct {
+	data   []byte
+	pos    int
+	end    int
+	bound  string
+	where  string
+	err    *DecodeError
+	module *Module
+
+	sawNames bool
+
+	// lastKnown is the most recent known section, and pending holds
+	// the custom sections found before any known section.
+	lastKnown SectionID
+	pending   []*Custom
+}
+
+// Decode parses a module in the WebAssembly binary format.  Every item of
+// the resulting module records in its Origin the byte offset at which it
+// begins; for a Func, that is the offset of its entry in the code section.
+//
+// The first "name" custom section, if any, is also decoded into Names.
+// As the specification requires of custom sections, a name section that
+// is malformed does not make the module malformed; it is left out of Names.
+//
+// The module refers to data rather than copying from it, so data must
+// not be modified while the module is in use.  Errors are of type
+// *DecodeError.
+func Decode(data []byte) (*Module, error) {
+	d := &decoder{
+		data:   data,
+		end:    len(data),
+		bound:  "input",
+		module: &Module{},
+	}
+	d.header()
+	d.sections()
+	d.finish()
+	if d.err != nil {
+		return nil, d.err
+	}
+	snapshotSections(d.module)
+	return d.module, nil
+}
+
+func (d *decoder) header() {
+	d.where = "header"
+	if magic := d.bytes(4); d.err == nil && string(magic) != Magic {
+		d.failf(0, "bad magic number % x", magic)
+		return
+	}
+	if version := d.bytes(4); d.err == nil {
+		if v := uint32(version[0]) | uint32(version[1])<<8 | uint32(version[2])<<16 | uint32(version[3])<<24; v != Version {
+			d.failf(4, "unsupported version %d", v)
+		}
+	}
+}
+
+func (d *decoder) sections() {
+	var lastOrder byte
+	for d.err == nil && d.pos < len(d.data) {
+		offset := d.pos
+		d.where = "section header"
+		id := SectionID(d.byte())
+		size := d.u32()
+		if d.err != nil {
+			return
+		}
+		if !id.IsKnown() {
+			d.failf(offset, "unknown section id %d", byte(id))
+			return
+		}
+		if remaining := len(d.data) - d.pos; uint64(size) > uint64(remaining) {
+			d.fail(d.pos, fmt.Sprintf("%s section size %d exceeds the %d bytes remaining", id, size, remaining), io.ErrUnexpectedEOF)
+			return
+		}
+		if id != CustomSection {
+			order := sectionOrder[id]
+			if order <= lastOrder {
+				d.failf(offset, "unexpected %s section: duplicate or out of order", id)
+				return
+			}
+			lastOrder = order
+			for _, custom := range d.pending {
+				custom.Place = CustomPlace{Section: id, Before: true}
+			}
+			d.pending = nil
+			d.lastKnown = id
+		}
+
+		d.end = d.pos + int(size)
+		d.module.Sections = append(d.module.Sections, &Section{
+			ID:            id,
+			Offset:        uint64(offset),
+			PayloadOffset: uint64(d.pos),
+			Payload:       d.data[d.pos:d.end],
+		})
+		d.where = id.String() + " section"
+		d.bound = "section"
+		d.section(id)
+		if d.err == nil && d.pos != d.end {
+			d.failf(d.pos, "section size mismatch: %d bytes left over", d.end-d.pos)
+		}
+		d.pos = d.end
+		d.end = len(d.data)
+		d.bound = "input"
+	}
+}
+
+func (d *decoder) section(id SectionID) {
+	module := d.module
+	switch id {
+	case CustomSection:
+		custom := &Custom{Origin: d.origin(), Place: CustomPlace{Section: d.lastKnown}}
+		custom.Name = d.name()
+		custom.Data = d.bytes(d.end - d.pos)
+		module.Customs = append(module.Customs, custom)
+		module.Sections[len(module.Sections)-1].custom = custom
+		if d.lastKnown == CustomSection {
+			d.pending = append(d.pending, custom)
+		}
+		if custom.Name == NameSectionName && !d.sawNames && d.err == nil {
+			d.sawNames = true
+			module.Names, _ = DecodeNames(custom.Data)
+		}
+
+	case TypeSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			module.Types = append(module.Types, d.funcType())
+		}
+
+	case ImportSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			module.Imports = append(module.Imports, d.importEntry())
+		}
+
+	case FunctionSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			module.Funcs = append(module.Funcs, &Func{Origin: d.origin(), Type: d.u32()})
+		}
+
+	case TableSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			module.Tables = append(module.Tables, &Table{Origin: d.origin(), Type: d.tableType()})
+		}
+
+	case MemorySection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			module.Memories = append(module.Memories, &Memory{Origin: d.origin(), Type: d.memoryType()})
+		}
+
+	case GlobalSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			global := &Global{Origin: d.origin()}
+			global.Type = d.globalType()
+			global.Init = d.constExpr()
+			module.Globals = append(module.Globals, global)
+		}
+
+	case ExportSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			export := &Export{Origin: d.origin()}
+			export.Name = d.name()
+			export.Kind = d.externKind()
+			export.Index = d.u32()
+			module.Exports = append(module.Exports, export)
+		}
+
+	case StartSection:
+		module.Start = &Start{Origin: d.origin(), Func: d.u32()}
+
+	case ElementSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			module.Elems = append(module.Elems, d.elem())
+		}
+
+	case CodeSection:
+		offset := d.pos
+		n := d.vec()
+		if d.err == nil && n != len(module.Funcs) {
+			d.failf(offset, "function and code section have inconsistent lengths: %d vs %d", len(module.Funcs), n)
+			return
+		}
+		for i := 0; i < n && d.err == nil; i++ {
+			d.code(module.Funcs[i])
+		}
+
+	case DataSection:
+		for n := d.vec(); n > 0 && d.err == nil; n-- {
+			module.Datas = append(module.Datas, d.dataEntry())
+		}
+
+	case DataCountSection:
+		count := d.u32()
+		module.DataCount = &count
+	}
+}
+
+func (d *decoder) finish() {
+	if d.err != nil {
+		return
+	}
+	module := d.module
+	d.where = ""
+	if len(module.Funcs) > 0 && !d.hasSection(CodeSection) {
+		d.failf(d.pos, "function and code section have inconsistent lengths: %d vs 0", len(module.Funcs))
+		return
+	}
+	if module.DataCount != nil && uint64(*module.DataCount) != uint64(len(module.Datas)) {
+		d.failf(d.pos, "data count and data section have inconsistent lengths: %d vs %d", *module.DataCount, len(module.Datas))
+	}
+}
+
+func (d *decoder) hasSection(id SectionID) bool {
+	for _, section := range d.module.Sections {
+		if section.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *decoder) funcType() *FuncType {
+	ft := &FuncType{Origin: d.origin()}
+	offset := d.pos
+	if form := d.byte(); d.err == nil && form != 0x60 {
+		d.failf(offset, "malformed function type form 0x%02x", form)
+		return ft
+	}
+	for n := d.vec(); n > 0 && d.err == nil; n-- {
+		ft.Params = append(ft.Params, d.valType())
+	}
+	for n := d.vec(); n > 0 && d.err == nil; n-- {
+		ft.Results = append(ft.Results, d.valType())
+	}
+	return ft
+}
+
+func (d *decoder) importEntry() *Import {
+	imp := &Import{Origin: d.origin()}
+	imp.Module = d.name()
+	imp.Name = d.name()
+	imp.Kind = d.externKind()
+	switch imp.Kind {
+	case FuncExtern:
+		imp.Type = d.u32()
+	case TableExtern:
+		imp.Table = d.tableType()
+	case MemoryExtern:
+		imp.Memory = d.memoryType()
+	case GlobalExtern:
+		imp.Global = d.globalType()
+	}
+	return imp
+}
+
+func (d *decoder) code(fn *Func) {
+	fn.Origin.Offset = uint64(d.pos)
+	size := d.u32()
+	if d.err != nil {
+		return
+	}
+	if remaining := d.end - d.pos; uint64(size) > uint64(remaining) {
+		d.fail(d.pos, fmt.Sprintf("function body size %d exceeds the %d bytes remaining", size, remaining), io.ErrUnexpectedEOF)
+		return
+	}
+
+	sectionEnd := d.end
+	d.end = d.pos + int(size)
+	d.bound = "function body"
+	defer func() {
+		d.end = sectionEnd
+		d.bound = "section"
+	}()
+
+	var total uint64
+	for n := d.vec(); n > 0 && d.err == nil; n-- {
+		offset := d.pos
+		local := Local{Count: d.u32(), Type: d.valType()}
+		total += uint64(local.Count)
+		if total > 0xffffffff {
+			d.failf(offset, "too many locals")
+			return
+		}
+		fn.Locals = append(fn.Locals, local)
+	}
+	if d.err != nil {
+		return
+	}
+
+	// The body is kept as raw bytes, but decoded here so that malformed
+	// instructions are reported by Decode.
+	start := d.pos
+	var last Opcode
+	for d.err == nil && d.pos < d.end {
+		last = d.instr().Opcode
+	}
+	if d.err != nil {
+		return
+	}
+	fn.Body = Expr(d.data[start:d.end])
+	if len(fn.Body) == 0 || last != OpEnd {
+		d.failf(d.end, "function body does not end with \"end\"")
+	}
+}
+
+func (d *decoder) elem() *Elem {
+	elem := &Elem{Origin: d.origin()}
+	offset := d.pos
+	flags := d.u32()
+	if d.err != nil {
+		return elem
+	}
+	if flags > 7 {
+		d.failf(offset, "malformed element segment flags %d", flags)
+		return elem
+	}
+
+	switch {
+	case (flags & 1) == 0:
+		elem.Mode = ActiveSegment
+	case (flags & 2) == 0:
+		elem.Mode = PassiveSegment
+	default:
+		elem.Mode = DeclarativeSegment
+	}
+	if (flags & 3) == 2 {
+		elem.Table = d.u32()
+	}
+	if elem.Mode == ActiveSegment {
+		elem.Offset = d.constExpr()
+	}
+
+	usesExprs := (flags & 4) != 0
+	elem.Type = FuncRef
+	if (flags & 3) != 0 {
+		if usesExprs {
+			elem.Type = d.refType()
+		} else if offset, kind := d.pos, d.byte(); d.err == nil && kind != 0x00 {
+			d.failf(offset, "malformed element kind 0x%02x", kind)
+		}
+	}
+
+	n := d.vec()
+	if usesExprs {
+		elem.Exprs = make([]Expr, 0, n)
+		for ; n > 0 && d.err == nil; n-- {
+			elem.Exprs = append(elem.Exprs, d.constExpr())
+		}
+	} else {
+		elem.Funcs = make([]uint32, 0, n)
+		for ; n > 0 && d.err == nil; n-- {
+			elem.Funcs = append(elem.Funcs, d.u32())
+		}
+	}
+	return elem
+}
+
+func (d *decoder) dataEntry() *Data {
+	data := &Data{Origin: d.origin()}
+	offset := d.pos
+	flags := d.u32()
+	switch {
+	case d.err != nil:
+		return data
+	case flags == 0:
+		data.Mode = ActiveSegment
+		data.Offset = d.constExpr()
+	case flags == 1:
+		data.Mode = PassiveSegment
+	case flags == 2:
+		data.Mode = ActiveSegment
+		data.Memory = d.u32()
+		data.Offset = d.constExpr()
+	default:
+		d.failf(offset, "malformed data segment flags %d", flags)
+		return data
+	}
+	data.Init = d.byteVec()
+	return data
+}
+
+// constExpr returns the encoding of a constant expression, which has no
+// length prefix, so its instructions must be decoded to find its end.
+func (d *decoder) constExpr() Expr {
+	start := d.pos
+	for d.err == nil {
+		offset := d.pos
+		instr := Instr{Opcode: d.opcode()}
+		switch op := instr.Opcode; {
+		case d.err != nil:
+		case op == OpEnd:
+			return Expr(d.data[start:d.pos])
+		case op.IsConstant():
+			d.immediates(&instr)
+		case op.Prefix() != 0:
+			d.failf(offset, "unsupported instruction 0x%02x %d in constant expression", op.Prefix(), op.Code())
+		default:
+			d.failf(offset, "unsupported instruction 0x%02x in constant expression", byte(op))
+		}
+	}
+	return nil
+}
+
+func (d *decoder) instr() Instr {
+	offset := d.pos
+	instr := Instr{Opcode: d.opcode(), Origin: Origin{Offset: uint64(offset)}}
+	switch op := instr.Opcode; {
+	case d.err != nil:
+	case !op.IsValid() && op.Prefix() != 0:
+		d.failf(offset, "illegal opcode 0x%02x %d", op.Prefix(), op.Code())
+	case !op.IsValid():
+		d.failf(offset, "illegal opcode 0x%02x", byte(op))
+	default:
+		d.immediates(&instr)
+		if d.err != nil {
+			// Report a bad immediate against its instruction.
+			d.err.Offset = uint64(offset)
+			d.err.Detail = op.String() + ": " + d.err.Detail
+		}
+	}
+	return instr
+}
+
+// opcode reads the opcode of an instruction, without checking that it is
+// assigned.
+func (d *decoder) opcode() Opcode {
+	prefix := d.byte()
+	if d.err != nil || !isPrefix(prefix) {
+		return Opcode(prefix)
+	}
+	offset := d.pos
+	code := d.u32()
+	if d.err == nil && code > 0xffff {
+		d.failf(offset, "sub-opcode %d too large", code)
+	}
+	return Opcode(prefix)<<16 | Opcode(code&0xffff)
+}
+
+// immediates reads the immediate arguments of an instruction whose
+// opcode is valid.
+func (d *decoder) immediates(instr *Instr) {
+	numIndices := 0
+	for _, imm := range instr.Opcode.Info().Immediates {
+		switch imm {
+		case BlockTypeImmediate:
+			offset := d.pos
+			s64, _ := d.leb(33, true)
+			instr.BlockType = BlockType(s64)
+			if vt, ok := instr.BlockType.ValType(); ok && d.err == nil && !vt.IsValid() {
+				d.failf(offset, "malformed block type 0x%02x", byte(vt))
+			}
+		case LabelVecImmediate:
+			n := d.vec()
+			instr.Labels = make([]uint32, 0, n+1)
+			for ; n > 0 && d.err == nil; n-- {
+				instr.Labels = append(instr.Labels, d.u32())
+			}
+			instr.Labels = append(instr.Labels, d.u32())
+		case MemArgImmediate:
+			instr.MemArg.Align = d.u32()
+			instr.MemArg.Offset = d.u32()
+		case LaneImmediate:
+			instr.Lane = d.byte()
+		case ShuffleImmediate, V128Immediate:
+			copy(instr.V128[:], d.bytes(16))
+		case I32Immediate:
+			u64, _ := d.leb(32, true)
+			instr.Value = uint64(uint32(u64))
+		case I64Immediate:
+			instr.Value, _ = d.leb(64, true)
+		case F32Immediate:
+			if b := d.bytes(4); b != nil {
+				instr.Value = uint64(binary.LittleEndian.Uint32(b))
+			}
+		case F64Immediate:
+			if b := d.bytes(8); b != nil {
+				instr.Value = binary.LittleEndian.Uint64(b)
+			}
+		case HeapTypeImmediate:
+			instr.HeapType = d.refType()
+		case ValTypeVecImmediate:
+			n := d.vec()
+			instr.Types = make([]ValType, 0, n)
+			for ; n > 0 && d.err == nil; n-- {
+				instr.Types = append(instr.Types, d.valType())
+			}
+		case ZeroByteImmediate:
+			offset := d.pos
+			if b := d.byte(); d.err == nil && b != 0x00 {
+				d.failf(offset, "zero byte expected")
+			}
+		default:
+			if numIndices > 0 {
+				instr.Index2 = d.u32()
+			} else {
+				instr.Index = d.u32()
+			}
+			numIndices++
+		}
+	}
+}
+
+func (d *decoder) valType() ValType {
+	offset := d.pos
+	vt := ValType(d.byte())
+	if d.err == nil && !vt.IsValid() {
+		d.failf(offset, "malformed value type 0x%02x", byte(vt))
+	}
+	return vt
+}
+
+func (d *decoder) refType() ValType {
+	offset := d.pos
+	vt := ValType(d.byte())
+	if d.err == nil && !vt.IsRef() {
+		d.failf(offset, "malformed reference type 0x%02x", byte(vt))
+	}
+	return vt
+}
+
+func (d *decoder) externKind() ExternKind {
+	offset := d.pos
+	kind := ExternKind(d.byte())
+	if d.err == nil && kind > GlobalExtern {
+		d.failf(offset, "malformed external kind 0x%02x", byte(kind))
+	}
+	return kind
+}
+
+func (d *decoder) limits(allowShared bool) Limits {
+	var limits Limits
+	offset := d.pos
+	flags := d.byte()
+	switch {
+	case d.err != nil:
+		return limits
+	case flags == 0x00:
+	case flags == 0x01:
+		limits.HasMax = true
+	case flags == 0x03 && allowShared:
+		limits.HasMax = true
+		limits.Shared = true
+	default:
+		d.failf(offset, "malformed limits flags 0x%02x", flags)
+		return limits
+	}
+	limits.Min = d.u32()
+	if limits.HasMax {
+		limits.Max = d.u32()
+	}
+	return limits
+}
+
+func (d *decoder) tableType() TableType {
+	var tt TableType
+	tt.Elem = d.refType()
+	tt.Limits = d.limits(false)
+	return tt
+}
+
+func (d *decoder) memoryType() MemoryType {
+	return MemoryType{Limits: d.limits(true)}
+}
+
+func (d *decoder) globalType() GlobalType {
+	var gt GlobalType
+	gt.Type = d.valType()
+	offset := d.pos
+	switch mut := d.byte(); {
+	case d.err != nil:
+	case mut == 0x00:
+	case mut == 0x01:
+		gt.Mutable = true
+	default:
+		d.failf(offset, "malformed mutability 0x%02x", mut)
+	}
+	return gt
+}
+
+func (d *decoder) name() string {
+	offset := d.pos
+	raw := d.byteVec()
+	if d.err == nil && !utf8.Valid(raw) {
+		d.failf(offset, "malformed UTF-8 encoding in name")
+	}
+	return string(raw)
+}
+
+func (d *decoder) byteVec() []byte {
+	n := d.u32()
+	if d.err != nil {
+		return nil
+	}
+	if remaining := d.end - d.pos; uint64(n) > uint64(remaining) {
+		d.fail(d.pos, fmt.Sprintf("length %d exceeds the %d bytes remaining", n, remaining), io.ErrUnexpectedEOF)
+		return nil
+	}
+	return d.bytes(int(n))
+}
+
+// vec reads the length of a vector.  Every vector element occupies at
+// least one byte, so a length beyond the end of the section is rejected
+// before anything is allocated for it.
+func (d *decoder) vec() int {
+	offset := d.pos
+	n := d.u32()
+	if d.err != nil {
+		return 0
+	}
+	if remaining := d.end - d.pos; uint64(n) > uint64(remaining) {
+		d.fail(offset, fmt.Sprintf("vector length %d exceeds the %d bytes remaining", n, remaining), io.ErrUnexpectedEOF)
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) u32() uint32 {
+	u64, _ := d.leb(32, false)
+	return uint32(u64)
+}
+
+func (d *decoder) leb(numBits uint, signed bool) (uint64, bool) {
+	if d.err != nil {
+		return 0, false
+	}
+	in := d.data[d.pos:d.end]
+
+	var rest []byte
+	var u64 uint64
+	var err error
+	if signed {
+		var s64 int64
+		rest, s64, err = leb128.IntN(in, numBits)
+		u64 = uint64(s64)
+	} else {
+		rest, u64, err = leb128.UintN(in, numBits)
+	}
+	switch err {
+	case nil:
+		d.pos += len(in) - len(rest)
+		return u64, true
+	case leb128.ErrTruncated:
+		d.unexpectedEnd()
+	case leb128.ErrOverflow:
+		d.failf(d.pos, "integer representation too long")
+	default:
+		d.failf(d.pos, "integer too large")
+	}
+	return 0, false
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if d.pos >= d.end {
+		d.unexpectedEnd()
+		return 0
+	}
+	ch := d.data[d.pos]
+	d.pos++
+	return ch
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n > d.end-d.pos {
+		d.unexpectedEnd()
+		return nil
+	}
+	out := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return out
+}
+
+func (d *decoder) origin() Origin {
+	return Origin{Offset: uint64(d.pos)}
+}
+
+func (d *decoder) unexpectedEnd() {
+	d.fail(d.end, "unexpected end of "+d.bound, io.ErrUnexpectedEOF)
+}
+
+func (d *decoder) failf(offset int, format string, args ...any) {
+	d.fail(offset, fmt.Sprintf(format, args...), nil)
+}
+
+func (d *decoder) fail(offset int, detail string, err error) {
+	if d.err != nil {
+		return
+	}
+	d.err = &DecodeError{Offset: uint64(offset), Where: d.where, Detail: detail, Err: err}
+}
@@ -0,0 +1,316 @@
+package wasm
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func sec(id SectionID, payload ...byte) []byte {
+	out := make([]byte, 0, len(payload)+2)
+	out = append(out, byte(id), byte(len(payload)))
+	return append(out, payload...)
+}
+
+func bin(sections ...[]byte) []byte {
+	out := []byte(Magic + "\x01\x00\x00\x00")
+	for _, section := range sections {
+		out = append(out, section...)
+	}
+	return out
+}
+
+func TestDecode(t *testing.T) {
+	input := bin(
+		sec(TypeSection, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f),
+		sec(ImportSection, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'f', 0x00, 0x00),
+		sec(FunctionSection, 0x01, 0x00),
+		sec(TableSection, 0x01, 0x70, 0x00, 0x01),
+		sec(MemorySection, 0x01, 0x01, 0x01, 0x02),
+		sec(GlobalSection, 0x01, 0x7f, 0x01, 0x41, 0x2a, 0x0b),
+		sec(ExportSection, 0x01, 0x01, 'g', 0x00, 0x01),
+		sec(StartSection, 0x01),
+		sec(ElementSection, 0x02,
+			0x00, 0x41, 0x00, 0x0b, 0x01, 0x01,
+			0x05, 0x70, 0x01, 0xd0, 0x70, 0x0b),
+		sec(DataCountSection, 0x01),
+		sec(CodeSection, 0x01, 0x06, 0x01, 0x02, 0x7e, 0x20, 0x00, 0x0b),
+		sec(DataSection, 0x01, 0x01, 0x02, 'h', 'i'),
+		sec(CustomSection, 0x04, 'n', 'o', 't', 'e', 0xde, 0xad),
+	)
+
+	module, err := Decode(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if actual := module.Imports[0].Origin.Offset; actual != 19 {
+		t.Errorf("wrong offset for import: expect 19, got %d", actual)
+	}
+	if actual := module.Funcs[0].Origin.Offset; actual != 82 {
+		t.Errorf("wrong offset for func: expect 82, got %d", actual)
+	}
+	if len(module.Sections) != 13 {
+		t.Fatalf("expect 13 sections, got %d", len(module.Sections))
+	}
+	if section := module.Sections[10]; section.ID != CodeSection || section.Offset != 79 || section.PayloadOffset != 81 || len(section.Payload) != 8 {
+		t.Errorf("wrong layout for code section: %+v", section)
+	}
+
+	count := uint32(1)
+	expect := &Module{
+		Types: []*FuncType{{Params: []ValType{I32}, Results: []ValType{I32}}},
+		Imports: []*Import{
+			{Module: "env", Name: "f", Kind: FuncExtern, Type: 0},
+		},
+		Funcs: []*Func{
+			{Type: 0, Locals: []Local{{Count: 2, Type: I64}}, Body: Expr{0x20, 0x00, 0x0b}},
+		},
+		Tables:   []*Table{{Type: TableType{Elem: FuncRef, Limits: Limits{Min: 1}}}},
+		Memories: []*Memory{{Type: MemoryType{Limits: Limits{Min: 1, Max: 2, HasMax: true}}}},
+		Globals:  []*Global{{Type: GlobalType{Type: I32, Mutable: true}, Init: Expr{0x41, 0x2a, 0x0b}}},
+		Exports:  []*Export{{Name: "g", Kind: FuncExtern, Index: 1}},
+		Start:    &Start{Func: 1},
+		Elems: []*Elem{
+			{Mode: ActiveSegment, Offset: Expr{0x41, 0x00, 0x0b}, Type: FuncRef, Funcs: []uint32{1}},
+			{Mode: PassiveSegment, Type: FuncRef, Exprs: []Expr{{0xd0, 0x70, 0x0b}}},
+		},
+		Datas:     []*Data{{Mode: PassiveSegment, Init: []byte("hi")}},
+		DataCount: &count,
+		Customs:   []*Custom{{Name: "note", Data: []byte{0xde, 0xad}, Place: CustomPlace{Section: DataSection}}},
+	}
+
+	module.Sections = nil
+	stripOrigins(module)
+	if !reflect.DeepEqual(module, expect) {
+		t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", expect, module)
+	}
+}
+
+func TestDecode_Padded(t *testing.T) {
+	// Linkers pad LEB128 fields to five bytes so they can patch them later.
+	input := bin(
+		[]byte{0x01, 0x85, 0x80, 0x80, 0x80, 0x00, 0x80, 0x80, 0x80, 0x80, 0x00},
+		[]byte{0x06, 0x0a, 0x01, 0x7f, 0x00, 0x41, 0xff, 0xff, 0xff, 0xff, 0x7f, 0x0b},
+	)
+	module, err := Decode(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := module.Sections[0].PayloadOffset; actual != 14 {
+		t.Errorf("wrong payload offset: expect 14, got %d", actual)
+	}
+	if len(module.Types) != 0 || len(module.Globals) != 1 {
+		t.Errorf("wrong result: %+v", module)
+	}
+}
+
+func TestDecode_Names(t *testing.T) {
+	names := sec(CustomSection, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x02, 0x01, 'm')
+	module, err := Decode(bin(names))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if module.Names == nil || module.Names.Module != "m" {
+		t.Errorf("wrong names: %+v", module.Names)
+	}
+
+	// A malformed name section is ignored, as is any name section after
+	// the first.
+	malformed := sec(CustomSection, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x05, 0x01, 'm')
+	module, err = Decode(bin(malformed, names))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if module.Names != nil {
+		t.Errorf("expect no names, got %+v", module.Names)
+	}
+	if len(module.Customs) != 2 {
+		t.Errorf("expect 2 custom sections, got %d", len(module.Customs))
+	}
+}
+
+func stripOrigins(module *Module) {
+	for _, x := range module.Types {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Imports {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Funcs {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Tables {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Memories {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Globals {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Exports {
+		x.Origin = Origin{}
+	}
+	if module.Start != nil {
+		module.Start.Origin = Origin{}
+	}
+	for _, x := range module.Elems {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Datas {
+		x.Origin = Origin{}
+	}
+	for _, x := range module.Customs {
+		x.Origin = Origin{}
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     []byte
+		Expect    string
+		Truncated bool
+	}
+
+	testData := [...]testCase{
+		{
+			Name:      "Empty",
+			Input:     []byte{},
+			Expect:    "wasm: offset 0x0: header: unexpected end of input",
+			Truncated: true,
+		},
+		{
+			Name:   "BadMagic",
+			Input:  []byte("\x00asn\x01\x00\x00\x00"),
+			Expect: "wasm: offset 0x0: header: bad magic number 00 61 73 6e",
+		},
+		{
+			Name:   "BadVersion",
+			Input:  []byte("\x00asm\x02\x00\x00\x00"),
+			Expect: "wasm: offset 0x4: header: unsupported version 2",
+		},
+		{
+			Name:   "UnknownSection",
+			Input:  bin(sec(42)),
+			Expect: "wasm: offset 0x8: section header: unknown section id 42",
+		},
+		{
+			Name:      "SectionTooBig",
+			Input:     bin([]byte{0x01, 0x05, 0x00}),
+			Expect:    "wasm: offset 0xa: section header: type section size 5 exceeds the 1 bytes remaining",
+			Truncated: true,
+		},
+		{
+			Name:   "OutOfOrder",
+			Input:  bin(sec(FunctionSection, 0x00), sec(TypeSection, 0x00)),
+			Expect: "wasm: offset 0xb: section header: unexpected type section: duplicate or out of order",
+		},
+		{
+			Name:   "Duplicate",
+			Input:  bin(sec(TypeSection, 0x00), sec(TypeSection, 0x00)),
+			Expect: "wasm: offset 0xb: section header: unexpected type section: duplicate or out of order",
+		},
+		{
+			Name:      "TruncatedLEB",
+			Input:     bin(sec(TypeSection, 0x80)),
+			Expect:    "wasm: offset 0xb: type section: unexpected end of section",
+			Truncated: true,
+		},
+		{
+			Name:   "TooLong",
+			Input:  bin(sec(TypeSection, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00)),
+			Expect: "wasm: offset 0xa: type section: integer representation too long",
+		},
+		{
+			Name:   "TooLarge",
+			Input:  bin(sec(TypeSection, 0xff, 0xff, 0xff, 0xff, 0x1f)),
+			Expect: "wasm: offset 0xa: type section: integer too large",
+		},
+		{
+			Name:   "SizeMismatch",
+			Input:  bin(sec(TypeSection, 0x00, 0x00)),
+			Expect: "wasm: offset 0xb: type section: section size mismatch: 1 bytes left over",
+		},
+		{
+			Name:      "VectorTooLong",
+			Input:     bin(sec(TypeSection, 0x05)),
+			Expect:    "wasm: offset 0xa: type section: vector length 5 exceeds the 0 bytes remaining",
+			Truncated: true,
+		},
+		{
+			Name:   "BadUTF8",
+			Input:  bin(sec(ExportSection, 0x01, 0x01, 0xff, 0x00, 0x00)),
+			Expect: "wasm: offset 0xb: export section: malformed UTF-8 encoding in name",
+		},
+		{
+			Name:   "BadValType",
+			Input:  bin(sec(TypeSection, 0x01, 0x60, 0x01, 0x40, 0x00)),
+			Expect: "wasm: offset 0xd: type section: malformed value type 0x40",
+		},
+		{
+			Name:   "NoCode",
+			Input:  bin(sec(FunctionSection, 0x01, 0x00)),
+			Expect: "wasm: offset 0xc: function and code section have inconsistent lengths: 1 vs 0",
+		},
+		{
+			Name:   "DataCount",
+			Input:  bin(sec(DataCountSection, 0x01)),
+			Expect: "wasm: offset 0xb: data count and data section have inconsistent lengths: 1 vs 0",
+		},
+		{
+			Name:   "MissingEnd",
+			Input:  bin(sec(FunctionSection, 0x01, 0x00), sec(CodeSection, 0x01, 0x02, 0x00, 0x01)),
+			Expect: "wasm: offset 0x12: code section: function body does not end with \"end\"",
+		},
+		{
+			Name:   "EndImmediate",
+			Input:  bin(sec(FunctionSection, 0x01, 0x00), sec(CodeSection, 0x01, 0x03, 0x00, 0x41, 0x0b)),
+			Expect: "wasm: offset 0x13: code section: function body does not end with \"end\"",
+		},
+		{
+			Name:   "IllegalOpcode",
+			Input:  bin(sec(FunctionSection, 0x01, 0x00), sec(CodeSection, 0x01, 0x03, 0x00, 0xff, 0x0b)),
+			Expect: "wasm: offset 0x11: code section: illegal opcode 0xff",
+		},
+		{
+			Name:   "OverlongImmediate",
+			Input:  bin(sec(FunctionSection, 0x01, 0x00), sec(CodeSection, 0x01, 0x09, 0x00, 0x41, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00, 0x0b)),
+			Expect: "wasm: offset 0x11: code section: i32.const: integer representation too long",
+		},
+		{
+			Name:      "TruncatedImmediate",
+			Input:     bin(sec(FunctionSection, 0x01, 0x00), sec(CodeSection, 0x01, 0x03, 0x00, 0x41, 0x80)),
+			Expect:    "wasm: offset 0x11: code section: i32.const: unexpected end of function body",
+			Truncated: true,
+		},
+		{
+			Name:   "NotConst",
+			Input:  bin(sec(GlobalSection, 0x01, 0x7f, 0x00, 0x28, 0x0b)),
+			Expect: "wasm: offset 0xd: global section: unsupported instruction 0x28 in constant expression",
+		},
+		{
+			Name:   "ElemFlags",
+			Input:  bin(sec(ElementSection, 0x01, 0x08)),
+			Expect: "wasm: offset 0xb: element section: malformed element segment flags 8",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := Decode(row.Input)
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("expect *wasm.DecodeError, got %#v", err)
+			}
+			if actual := err.Error(); actual != row.Expect {
+				t.Errorf("wrong error:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+			if truncated := errors.Is(err, io.ErrUnexpectedEOF); truncated != row.Truncated {
+				t.Errorf("expect errors.Is(err, io.ErrUnexpectedEOF) == %v", row.Truncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package wasm
+
+import (
+	"strconv"
+)
+
+// DecodeError describes malformed input to Decode, located by the byte
+// offset at which the problem was detected.  Err is the underlying cause,
+// if any, such as io.ErrUnexpectedEOF for truncated input.
+type DecodeError struct {
+	Offset uint64
+	Where  string
+	Detail string
+	Err    error
+}
+
+func (err *DecodeError) Error() string {
+	var scratch [128]byte
+	out := append(scratch[:0], "wasm: offset 0x"...)
+	out = strconv.AppendUint(out, err.Offset, 16)
+	out = append(out, ": "...)
+	if err.Where != "" {
+		out = append(out, err.Where...)
+		out = append(out, ": "...)
+	}
+	out = append(out, err.Detail...)
+	return string(out)
+}
+
+func (err *DecodeError) Unwrap() error {
+	return err.Err
+}
+
+var _ error = (*DecodeError)(nil)
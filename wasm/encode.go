@@ -0,0 +1,416 @@
+package wasm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+// paddedLen is the width of a padded u32 in fixed-width mode.
+const paddedLen = 5
+
+// Encoder writes modules in the WebAssembly binary format.  The zero
+// value, or a nil *Encoder, produces minimal LEB128 encodings.
+type Encoder struct {
+	fixedWidth bool
+}
+
+// FixedWidth selects padded 5-byte LEB128 encodings for section sizes,
+// function body sizes, and the indices that the encoder itself writes, so
+// that tools can patch those fields in place.  Instruction sequences are
+// copied as they are.  Every section is encoded afresh in this mode, even
+// one that has not changed since Decode.
+func (enc *Encoder) FixedWidth(value bool) *Encoder {
+	enc.fixedWidth = value
+	return enc
+}
+
+// Encode serializes a module in the WebAssembly binary format.
+//
+// When the module came from Decode, any section whose contents have not
+// changed since then is copied from its original bytes, so that a module
+// is reproduced byte for byte if nothing changed; FixedWidth turns this
+// off.  Known sections are
+// written in the order that the binary format requires, and each custom
+// section according to its Place.
+func (enc *Encoder) Encode(module *Module) ([]byte, error) {
+	return enc.Append(nil, module)
+}
+
+// Append is like Encode, but appends to out.
+func (enc *Encoder) Append(out []byte, module *Module) ([]byte, error) {
+	var fixedWidth bool
+	if enc != nil {
+		fixedWidth = enc.fixedWidth
+	}
+	minimal := &encoder{module: module}
+	e := &encoder{module: module, fixedWidth: fixedWidth}
+
+	out = append(out, Magic...)
+	out = binary.LittleEndian.AppendUint32(out, Version)
+	for _, ps := range planSections(module) {
+		payload, err := minimal.payload(ps.id, ps.custom)
+		if err != nil {
+			return nil, err
+		}
+
+		if raw := ps.raw; !fixedWidth && raw != nil && raw.canonical != nil && bytes.Equal(payload, raw.canonical) {
+			out = append(out, byte(raw.ID))
+			out = leb128.AppendUintPadded(out, uint32(len(raw.Payload)), uint(raw.PayloadOffset-raw.Offset-1))
+			out = append(out, raw.Payload...)
+			continue
+		}
+
+		if ps.custom == nil && isEmptyPayload(ps.id, payload) {
+			continue
+		}
+		if fixedWidth {
+			payload, _ = e.payload(ps.id, ps.custom)
+		}
+		out = append(out, byte(ps.id))
+		out = e.u32(out, uint32(len(payload)))
+		out = append(out, payload...)
+	}
+	return out, nil
+}
+
+// Encode serializes a module with minimal LEB128 encodings.
+func Encode(module *Module) ([]byte, error) {
+	return (*Encoder)(nil).Encode(module)
+}
+
+type plannedSection struct {
+	id     SectionID
+	raw    *Section
+	custom *Custom
+}
+
+func (ps plannedSection) rank() int {
+	if ps.custom != nil {
+		return ps.custom.Place.rank()
+	}
+	return 2 * int(sectionOrder[ps.id])
+}
+
+// knownSections lists the known sections in the order of the binary
+// format.
+var knownSections = [...]SectionID{
+	TypeSection,
+	ImportSection,
+	FunctionSection,
+	TableSection,
+	MemorySection,
+	GlobalSection,
+	ExportSection,
+	StartSection,
+	ElementSection,
+	DataCountSection,
+	CodeSection,
+	DataSection,
+}
+
+// planSections lists the sections to write: every known section, with
+// those recorded by Decode, in order, and the custom sections placed
+// among them.  Custom sections in the same place keep their order in
+// Customs.
+func planSections(module *Module) []plannedSection {
+	var raws [len(sectionOrder)]*Section
+	customRaws := make(map[*Custom]*Section, len(module.Customs))
+	for _, section := range module.Sections {
+		if section.ID != CustomSection {
+			raws[section.ID] = section
+		} else if section.custom != nil {
+			customRaws[section.custom] = section
+		}
+	}
+
+	list := make([]plannedSection, 0, len(knownSections)+len(module.Customs))
+	for _, id := range knownSections {
+		list = append(list, plannedSection{id: id, raw: raws[id]})
+	}
+	for _, custom := range module.Customs {
+		list = append(list, plannedSection{id: CustomSection, raw: customRaws[custom], custom: custom})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].rank() < list[j].rank()
+	})
+	return list
+}
+
+// isEmptyPayload reports whether a section has nothing in it, and so can be
+// left out.
+func isEmptyPayload(id SectionID, payload []byte) bool {
+	switch id {
+	case StartSection, DataCountSection:
+		return len(payload) == 0
+	default:
+		return len(payload) == 0 || (len(payload) == 1 && payload[0] == 0x00)
+	}
+}
+
+// snapshotSections records the canonical encoding of each section that
+// Decode found, which Encode compares against to detect changes.
+func snapshotSections(module *Module) {
+	e := &encoder{module: module}
+	for _, section := range module.Sections {
+		if payload, err := e.payload(section.ID, section.custom); err == nil {
+			section.canonical = payload
+		}
+	}
+}
+
+type encoder struct {
+	module     *Module
+	fixedWidth bool
+}
+
+// payload returns the encoded contents of one section, or an empty slice
+// for a start or data count section that the module does not have.
+func (e *encoder) payload(id SectionID, custom *Custom) ([]byte, error) {
+	module := e.module
+	out := make([]byte, 0, 64)
+	switch id {
+	case CustomSection:
+		out = e.name(out, custom.Name)
+		out = append(out, custom.Data...)
+
+	case TypeSection:
+		out = e.vec(out, len(module.Types))
+		for i, ft := range module.Types {
+			out = append(out, 0x60)
+			out = e.vec(out, len(ft.Params))
+			for _, vt := range ft.Params {
+				if !vt.IsValid() {
+					return nil, e.errorf("type", i, "invalid value type %#v", vt)
+				}
+				out = append(out, byte(vt))
+			}
+			out = e.vec(out, len(ft.Results))
+			for _, vt := range ft.Results {
+				if !vt.IsValid() {
+					return nil, e.errorf("type", i, "invalid value type %#v", vt)
+				}
+				out = append(out, byte(vt))
+			}
+		}
+
+	case ImportSection:
+		out = e.vec(out, len(module.Imports))
+		for i, imp := range module.Imports {
+			out = e.name(out, imp.Module)
+			out = e.name(out, imp.Name)
+			out = append(out, byte(imp.Kind))
+			switch imp.Kind {
+			case FuncExtern:
+				out = e.u32(out, imp.Type)
+			case TableExtern:
+				out = e.tableType(out, imp.Table)
+			case MemoryExtern:
+				out = e.limits(out, imp.Memory.Limits)
+			case GlobalExtern:
+				out = e.globalType(out, imp.Global)
+			default:
+				return nil, e.errorf("import", i, "invalid kind %#v", imp.Kind)
+			}
+		}
+
+	case FunctionSection:
+		out = e.vec(out, len(module.Funcs))
+		for _, fn := range module.Funcs {
+			out = e.u32(out, fn.Type)
+		}
+
+	case TableSection:
+		out = e.vec(out, len(module.Tables))
+		for _, table := range module.Tables {
+			out = e.tableType(out, table.Type)
+		}
+
+	case MemorySection:
+		out = e.vec(out, len(module.Memories))
+		for _, memory := range module.Memories {
+			out = e.limits(out, memory.Type.Limits)
+		}
+
+	case GlobalSection:
+		out = e.vec(out, len(module.Globals))
+		for i, global := range module.Globals {
+			if len(global.Init) == 0 {
+				return nil, e.errorf("global", i, "missing initializer")
+			}
+			out = e.globalType(out, global.Type)
+			out = append(out, global.Init...)
+		}
+
+	case ExportSection:
+		out = e.vec(out, len(module.Exports))
+		for _, export := range module.Exports {
+			out = e.name(out, export.Name)
+			out = append(out, byte(export.Kind))
+			out = e.u32(out, export.Index)
+		}
+
+	case StartSection:
+		if module.Start != nil {
+			out = e.u32(out, module.Start.Func)
+		}
+
+	case ElementSection:
+		out = e.vec(out, len(module.Elems))
+		for i, elem := range module.Elems {
+			var err error
+			out, err = e.elem(out, i, elem)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case CodeSection:
+		out = e.vec(out, len(module.Funcs))
+		for i, fn := range module.Funcs {
+			if len(fn.Body) == 0 {
+				return nil, e.errorf("func", i, "missing body")
+			}
+			code := make([]byte, 0, len(fn.Body)+16)
+			code = e.vec(code, len(fn.Locals))
+			for _, local := range fn.Locals {
+				code = e.u32(code, local.Count)
+				code = append(code, byte(local.Type))
+			}
+			code = append(code, fn.Body...)
+			out = e.u32(out, uint32(len(code)))
+			out = append(out, code...)
+		}
+
+	case DataSection:
+		out = e.vec(out, len(module.Datas))
+		for i, data := range module.Datas {
+			switch {
+			case data.Mode == PassiveSegment:
+				out = append(out, 0x01)
+			case data.Mode != ActiveSegment:
+				return nil, e.errorf("data segment", i, "invalid mode %#v", data.Mode)
+			case data.Memory == 0:
+				out = append(out, 0x00)
+				out = append(out, data.Offset...)
+			default:
+				out = append(out, 0x02)
+				out = e.u32(out, data.Memory)
+				out = append(out, data.Offset...)
+			}
+			out = e.vec(out, len(data.Init))
+			out = append(out, data.Init...)
+		}
+
+	case DataCountSection:
+		if module.DataCount != nil {
+			out = e.u32(out, *module.DataCount)
+		}
+	}
+	return out, nil
+}
+
+func (e *encoder) elem(out []byte, index int, elem *Elem) ([]byte, error) {
+	usesExprs := (elem.Funcs == nil && elem.Exprs != nil)
+	if !usesExprs && elem.Type != FuncRef {
+		return nil, e.errorf("element segment", index, "function indices require type funcref, not %s", elem.Type)
+	}
+
+	var flags uint32
+	switch elem.Mode {
+	case ActiveSegment:
+		if elem.Table != 0 || elem.Type != FuncRef {
+			flags = 2
+		}
+	case PassiveSegment:
+		flags = 1
+	case DeclarativeSegment:
+		flags = 3
+	default:
+		return nil, e.errorf("element segment", index, "invalid mode %#v", elem.Mode)
+	}
+	if usesExprs {
+		flags |= 4
+	}
+
+	out = append(out, byte(flags))
+	if (flags & 3) == 2 {
+		out = e.u32(out, elem.Table)
+	}
+	if elem.Mode == ActiveSegment {
+		out = append(out, elem.Offset...)
+	}
+	if (flags & 3) != 0 {
+		if usesExprs {
+			out = append(out, byte(elem.Type))
+		} else {
+			out = append(out, 0x00)
+		}
+	}
+	if usesExprs {
+		out = e.vec(out, len(elem.Exprs))
+		for _, expr := range elem.Exprs {
+			out = append(out, expr...)
+		}
+	} else {
+		out = e.vec(out, len(elem.Funcs))
+		for _, funcIndex := range elem.Funcs {
+			out = e.u32(out, funcIndex)
+		}
+	}
+	return out, nil
+}
+
+func (e *encoder) tableType(out []byte, tt TableType) []byte {
+	out = append(out, byte(tt.Elem))
+	return e.limits(out, tt.Limits)
+}
+
+func (e *encoder) globalType(out []byte, gt GlobalType) []byte {
+	out = append(out, byte(gt.Type))
+	if gt.Mutable {
+		return append(out, 0x01)
+	}
+	return append(out, 0x00)
+}
+
+func (e *encoder) limits(out []byte, limits Limits) []byte {
+	switch {
+	case limits.Shared:
+		out = append(out, 0x03)
+	case limits.HasMax:
+		out = append(out, 0x01)
+	default:
+		out = append(out, 0x00)
+	}
+	out = leb128.AppendUint32(out, limits.Min)
+	if limits.HasMax || limits.Shared {
+		out = leb128.AppendUint32(out, limits.Max)
+	}
+	return out
+}
+
+func (e *encoder) name(out []byte, str string) []byte {
+	out = e.vec(out, len(str))
+	return append(out, str...)
+}
+
+// vec writes a vector length, which is never padded.
+func (e *encoder) vec(out []byte, n int) []byte {
+	return leb128.AppendUint32(out, uint32(n))
+}
+
+// u32 writes a size or index, which is padded in fixed-width mode.
+func (e *encoder) u32(out []byte, value uint32) []byte {
+	if e.fixedWidth {
+		return leb128.AppendUintPadded(out, value, paddedLen)
+	}
+	return leb128.AppendUint32(out, value)
+}
+
+func (e *encoder) errorf(what string, index int, format string, args ...any) error {
+	return fmt.Errorf("wasm: cannot encode %s %d: %s", what, index, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,247 @@
+package wasm
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	count := uint32(1)
+	module := &Module{
+		Types: []*FuncType{{Params: []ValType{I32}, Results: []ValType{I32}}},
+		Imports: []*Import{
+			{Module: "env", Name: "f", Kind: FuncExtern, Type: 0},
+		},
+		Funcs: []*Func{
+			{Type: 0, Locals: []Local{{Count: 2, Type: I64}}, Body: Expr{0x20, 0x00, 0x0b}},
+		},
+		Tables:   []*Table{{Type: TableType{Elem: FuncRef, Limits: Limits{Min: 1}}}},
+		Memories: []*Memory{{Type: MemoryType{Limits: Limits{Min: 1, Max: 2, HasMax: true}}}},
+		Globals:  []*Global{{Type: GlobalType{Type: I32, Mutable: true}, Init: Expr{0x41, 0x2a, 0x0b}}},
+		Exports:  []*Export{{Name: "g", Kind: FuncExtern, Index: 1}},
+		Start:    &Start{Func: 1},
+		Elems: []*Elem{
+			{Mode: ActiveSegment, Offset: Expr{0x41, 0x00, 0x0b}, Type: FuncRef, Funcs: []uint32{1}},
+			{Mode: PassiveSegment, Type: FuncRef, Exprs: []Expr{{0xd0, 0x70, 0x0b}}},
+		},
+		Datas:     []*Data{{Mode: PassiveSegment, Init: []byte("hi")}},
+		DataCount: &count,
+		Customs:   []*Custom{{Name: "note", Data: []byte{0xde, 0xad}}},
+	}
+	expect := bin(
+		sec(TypeSection, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f),
+		sec(ImportSection, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'f', 0x00, 0x00),
+		sec(FunctionSection, 0x01, 0x00),
+		sec(TableSection, 0x01, 0x70, 0x00, 0x01),
+		sec(MemorySection, 0x01, 0x01, 0x01, 0x02),
+		sec(GlobalSection, 0x01, 0x7f, 0x01, 0x41, 0x2a, 0x0b),
+		sec(ExportSection, 0x01, 0x01, 'g', 0x00, 0x01),
+		sec(StartSection, 0x01),
+		sec(ElementSection, 0x02,
+			0x00, 0x41, 0x00, 0x0b, 0x01, 0x01,
+			0x05, 0x70, 0x01, 0xd0, 0x70, 0x0b),
+		sec(DataCountSection, 0x01),
+		sec(CodeSection, 0x01, 0x06, 0x01, 0x02, 0x7e, 0x20, 0x00, 0x0b),
+		sec(DataSection, 0x01, 0x01, 0x02, 'h', 'i'),
+		sec(CustomSection, 0x04, 'n', 'o', 't', 'e', 0xde, 0xad),
+	)
+
+	actual, err := Encode(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+}
+
+func TestEncode_FixedWidth(t *testing.T) {
+	module := &Module{
+		Types:   []*FuncType{{}},
+		Funcs:   []*Func{{Type: 0, Body: Expr{0x0b}}},
+		Exports: []*Export{{Name: "f", Kind: FuncExtern, Index: 0}},
+	}
+	expect := bin(
+		[]byte{0x01, 0x84, 0x80, 0x80, 0x80, 0x00, 0x01, 0x60, 0x00, 0x00},
+		[]byte{0x03, 0x86, 0x80, 0x80, 0x80, 0x00, 0x01, 0x80, 0x80, 0x80, 0x80, 0x00},
+		[]byte{0x07, 0x89, 0x80, 0x80, 0x80, 0x00, 0x01, 0x01, 'f', 0x00, 0x80, 0x80, 0x80, 0x80, 0x00},
+		[]byte{0x0a, 0x88, 0x80, 0x80, 0x80, 0x00, 0x01, 0x82, 0x80, 0x80, 0x80, 0x00, 0x00, 0x0b},
+	)
+
+	actual, err := new(Encoder).FixedWidth(true).Encode(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+
+	decoded, err := Decode(actual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded.Sections = nil
+	stripOrigins(decoded)
+	if !reflect.DeepEqual(decoded, module) {
+		t.Errorf("wrong round trip:\n\texpect: %+v\n\tactual: %+v", module, decoded)
+	}
+}
+
+func TestEncode_FixedWidthDecoded(t *testing.T) {
+	// Sections that have not changed since Decode are padded too.
+	input := bin(
+		sec(TypeSection, 0x01, 0x60, 0x00, 0x00),
+		sec(FunctionSection, 0x01, 0x00),
+		sec(ExportSection, 0x01, 0x01, 'f', 0x00, 0x00),
+		sec(CodeSection, 0x01, 0x02, 0x00, 0x0b),
+		sec(CustomSection, 0x01, 'c'),
+	)
+	expect := bin(
+		[]byte{0x01, 0x84, 0x80, 0x80, 0x80, 0x00, 0x01, 0x60, 0x00, 0x00},
+		[]byte{0x03, 0x86, 0x80, 0x80, 0x80, 0x00, 0x01, 0x80, 0x80, 0x80, 0x80, 0x00},
+		[]byte{0x07, 0x89, 0x80, 0x80, 0x80, 0x00, 0x01, 0x01, 'f', 0x00, 0x80, 0x80, 0x80, 0x80, 0x00},
+		[]byte{0x0a, 0x88, 0x80, 0x80, 0x80, 0x00, 0x01, 0x82, 0x80, 0x80, 0x80, 0x00, 0x00, 0x0b},
+		[]byte{0x00, 0x82, 0x80, 0x80, 0x80, 0x00, 0x01, 'c'},
+	)
+
+	module, err := Decode(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actual, err := new(Encoder).FixedWidth(true).Encode(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+
+	decoded, err := Decode(actual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, section := range decoded.Sections {
+		if size := section.PayloadOffset - section.Offset - 1; size != paddedLen {
+			t.Errorf("%v section: expect a %d-byte size, got %d bytes", section.ID, paddedLen, size)
+		}
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Input []byte
+	}
+
+	testData := [...]testCase{
+		{
+			Name: "Padded",
+			Input: bin(
+				[]byte{0x01, 0x85, 0x80, 0x80, 0x80, 0x00, 0x80, 0x80, 0x80, 0x80, 0x00},
+				[]byte{0x06, 0x0a, 0x01, 0x7f, 0x00, 0x41, 0xff, 0xff, 0xff, 0xff, 0x7f, 0x0b},
+			),
+		},
+		{
+			Name: "CustomsInPlace",
+			Input: bin(
+				sec(CustomSection, 0x01, 'a'),
+				sec(TypeSection, 0x01, 0x60, 0x00, 0x00),
+				sec(CustomSection, 0x01, 'b', 0x01, 0x02),
+				sec(FunctionSection, 0x01, 0x00),
+				sec(CodeSection, 0x01, 0x02, 0x00, 0x0b),
+				sec(CustomSection, 0x01, 'c'),
+			),
+		},
+		{
+			Name:  "EmptySections",
+			Input: bin(sec(TypeSection, 0x00), sec(DataSection, 0x00)),
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			module, err := Decode(row.Input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actual, err := Encode(module)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(actual, row.Input) {
+				t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", row.Input, actual)
+			}
+		})
+	}
+}
+
+func TestEncode_Changed(t *testing.T) {
+	input := bin(
+		sec(CustomSection, 0x01, 'a'),
+		[]byte{0x01, 0x84, 0x80, 0x80, 0x80, 0x00, 0x01, 0x60, 0x00, 0x00},
+		sec(GlobalSection, 0x01, 0x7f, 0x00, 0x41, 0x01, 0x0b),
+		sec(CustomSection, 0x01, 'b'),
+	)
+	module, err := Decode(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	module.Globals[0].Init = Expr{0x41, 0x02, 0x0b}
+	module.Memories = []*Memory{{Type: MemoryType{Limits: Limits{Min: 1}}}}
+	module.Customs = append(module.Customs, &Custom{Name: "c"})
+
+	expect := bin(
+		sec(CustomSection, 0x01, 'a'),
+		[]byte{0x01, 0x84, 0x80, 0x80, 0x80, 0x00, 0x01, 0x60, 0x00, 0x00},
+		sec(MemorySection, 0x01, 0x00, 0x01),
+		sec(GlobalSection, 0x01, 0x7f, 0x00, 0x41, 0x02, 0x0b),
+		sec(CustomSection, 0x01, 'b'),
+		sec(CustomSection, 0x01, 'c'),
+	)
+	actual, err := Encode(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Module *Module
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "MissingBody",
+			Module: &Module{Types: []*FuncType{{}}, Funcs: []*Func{{}}},
+			Expect: "wasm: cannot encode func 0: missing body",
+		},
+		{
+			Name:   "BadValType",
+			Module: &Module{Types: []*FuncType{{Params: []ValType{0x40}}}},
+			Expect: "wasm: cannot encode type 0: invalid value type wasm.ValType(0x40)",
+		},
+		{
+			Name:   "FuncsWithExternRef",
+			Module: &Module{Elems: []*Elem{{Mode: PassiveSegment, Type: ExternRef, Funcs: []uint32{}}}},
+			Expect: "wasm: cannot encode element segment 0: function indices require type funcref, not externref",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := Encode(row.Module)
+			if err == nil {
+				t.Fatalf("expect error, got nil")
+			}
+			if actual := err.Error(); actual != row.Expect {
+				t.Errorf("wrong error:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+type ExternKind byte
+
+const (
+	FuncExtern ExternKind = iota
+	TableExtern
+	MemoryExtern
+	GlobalExtern
+)
+
+var externKindGoNames = [...]string{
+	"wasm.FuncExtern",
+	"wasm.TableExtern",
+	"wasm.MemoryExtern",
+	"wasm.GlobalExtern",
+}
+
+var externKindNames = [...]string{
+	"func",
+	"table",
+	"memory",
+	"global",
+}
+
+func (enum ExternKind) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum ExternKind) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum ExternKind) AppendTo(out []byte, verbose bool) []byte {
+	names := externKindNames
+	if verbose {
+		names = externKindGoNames
+	}
+	var str string
+	if enum < ExternKind(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wasm.ExternKind(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = ExternKind(0)
+	_ fmt.Stringer   = ExternKind(0)
+)
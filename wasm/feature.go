@@ -0,0 +1,330 @@
+package wasm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Feature is a WebAssembly proposal that a module may depend on.
+type Feature byte
+
+const (
+	MutableGlobalsFeature Feature = iota
+	NonTrappingFloatToIntFeature
+	SignExtensionFeature
+	MultiValueFeature
+	BulkMemoryFeature
+	ReferenceTypesFeature
+	SIMDFeature
+	ThreadsFeature
+	ExtendedConstFeature
+	numFeatures
+)
+
+var featureGoNames = [...]string{
+	"wasm.MutableGlobalsFeature",
+	"wasm.NonTrappingFloatToIntFeature",
+	"wasm.SignExtensionFeature",
+	"wasm.MultiValueFeature",
+	"wasm.BulkMemoryFeature",
+	"wasm.ReferenceTypesFeature",
+	"wasm.SIMDFeature",
+	"wasm.ThreadsFeature",
+	"wasm.ExtendedConstFeature",
+}
+
+var featureNames = [...]string{
+	"mutable-globals",
+	"nontrapping-float-to-int",
+	"sign-extension",
+	"multi-value",
+	"bulk-memory",
+	"reference-types",
+	"simd",
+	"threads",
+	"extended-const",
+}
+
+// featureTargetNames holds the name of each feature in the
+// "target_features" custom section, which follows the tool conventions
+// rather than the proposal names.
+var featureTargetNames = [...]string{
+	"mutable-globals",
+	"nontrapping-fptoint",
+	"sign-ext",
+	"multivalue",
+	"bulk-memory",
+	"reference-types",
+	"simd128",
+	"atomics",
+	"extended-const",
+}
+
+func FeatureByName(name string) (Feature, bool) {
+	for i, str := range featureNames {
+		if str == name {
+			return Feature(i), true
+		}
+	}
+	return 0, false
+}
+
+func FeatureByTargetName(name string) (Feature, bool) {
+	for i, str := range featureTargetNames {
+		if str == name {
+			return Feature(i), true
+		}
+	}
+	return 0, false
+}
+
+// TargetName returns the name of the feature in the "target_features"
+// custom section.
+func (enum Feature) TargetName() string {
+	if enum < Feature(len(featureTargetNames)) {
+		return featureTargetNames[enum]
+	}
+	return enum.String()
+}
+
+func (enum Feature) GoString() string {
+	var scratch [40]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum Feature) String() string {
+	var scratch [40]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum Feature) AppendTo(out []byte, verbose bool) []byte {
+	names := featureNames
+	if verbose {
+		names = featureGoNames
+	}
+	var str string
+	if enum < Feature(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wasm.Feature(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+// Features is a set of Feature values.
+type Features uint32
+
+const (
+	// AllFeatures holds every known feature.
+	AllFeatures Features = (1 << numFeatures) - 1
+
+	// DefaultFeatures holds the features standardized in WebAssembly 2.0.
+	DefaultFeatures = AllFeatures &^ (1 << ThreadsFeature) &^ (1 << ExtendedConstFeature)
+)
+
+// ParseFeatures parses a comma-separated list of feature names.
+func ParseFeatures(str string) (Features, error) {
+	var set Features
+	if str == "" {
+		return set, nil
+	}
+	for _, name := range strings.Split(str, ",") {
+		feature, ok := FeatureByName(strings.TrimSpace(name))
+		if !ok {
+			return set, fmt.Errorf("wasm: unknown feature %q", name)
+		}
+		set = set.With(feature)
+	}
+	return set, nil
+}
+
+func (set Features) Has(feature Feature) bool {
+	return feature < numFeatures && (set&(1<<feature)) != 0
+}
+
+func (set Features) With(feature Feature) Features {
+	return set | (1 << feature)
+}
+
+func (set Features) Without(feature Feature) Features {
+	return set &^ (1 << feature)
+}
+
+func (set Features) List() []Feature {
+	list := make([]Feature, 0, numFeatures)
+	for feature := Feature(0); feature < numFeatures; feature++ {
+		if set.Has(feature) {
+			list = append(list, feature)
+		}
+	}
+	return list
+}
+
+func (set Features) GoString() string {
+	return fmt.Sprintf("wasm.Features(%#x)", uint32(set))
+}
+
+func (set Features) String() string {
+	var scratch [128]byte
+	return string(set.AppendTo(scratch[:0]))
+}
+
+func (set Features) AppendTo(out []byte) []byte {
+	for i, feature := range set.List() {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		out = feature.AppendTo(out, false)
+	}
+	return out
+}
+
+// RequiredFeatures returns the features that the module's types, imports,
+// exports, segments and instructions depend on.
+func (module *Module) RequiredFeatures() Features {
+	var set Features
+	valTypes := func(list []ValType) {
+		for _, vt := range list {
+			switch {
+			case vt == V128:
+				set = set.With(SIMDFeature)
+			case vt.IsRef():
+				set = set.With(ReferenceTypesFeature)
+			}
+		}
+	}
+	limits := func(limits Limits) {
+		if limits.Shared {
+			set = set.With(ThreadsFeature)
+		}
+	}
+	// Malformed expressions are left for the decoder or the validator
+	// to report.
+	instrs := func(expr Expr) []Instr {
+		list, _ := DecodeExpr(expr)
+		for _, instr := range list {
+			set |= instr.Opcode.Info().Features
+		}
+		return list
+	}
+	constExpr := func(expr Expr) {
+		for _, instr := range instrs(expr) {
+			switch instr.Opcode {
+			case OpI32Add, OpI32Sub, OpI32Mul, OpI64Add, OpI64Sub, OpI64Mul:
+				set = set.With(ExtendedConstFeature)
+			}
+		}
+	}
+	body := func(expr Expr) {
+		for _, instr := range instrs(expr) {
+			switch instr.Opcode {
+			case OpBlock, OpLoop, OpIf:
+				if vt, ok := instr.BlockType.ValType(); ok {
+					valTypes([]ValType{vt})
+				}
+				if _, ok := instr.BlockType.TypeIndex(); ok {
+					set = set.With(MultiValueFeature)
+				}
+			case OpSelectT:
+				valTypes(instr.Types)
+			}
+		}
+	}
+
+	for _, ft := range module.Types {
+		valTypes(ft.Params)
+		valTypes(ft.Results)
+		if len(ft.Results) > 1 {
+			set = set.With(MultiValueFeature)
+		}
+	}
+
+	var numTables int
+	for _, imp := range module.Imports {
+		switch imp.Kind {
+		case TableExtern:
+			numTables++
+			valTypes([]ValType{imp.Table.Elem})
+			limits(imp.Table.Limits)
+		case MemoryExtern:
+			limits(imp.Memory.Limits)
+		case GlobalExtern:
+			valTypes([]ValType{imp.Global.Type})
+			if imp.Global.Mutable {
+				set = set.With(MutableGlobalsFeature)
+			}
+		}
+	}
+	for _, fn := range module.Funcs {
+		for _, local := range fn.Locals {
+			valTypes([]ValType{local.Type})
+		}
+		body(fn.Body)
+	}
+	for _, table := range module.Tables {
+		numTables++
+		if table.Type.Elem != FuncRef {
+			set = set.With(ReferenceTypesFeature)
+		}
+	}
+	if numTables > 1 {
+		set = set.With(ReferenceTypesFeature)
+	}
+	for _, memory := range module.Memories {
+		limits(memory.Type.Limits)
+	}
+	for _, global := range module.Globals {
+		valTypes([]ValType{global.Type.Type})
+		constExpr(global.Init)
+	}
+	for _, export := range module.Exports {
+		if export.Kind == GlobalExtern && module.isMutableGlobal(export.Index) {
+			set = set.With(MutableGlobalsFeature)
+		}
+	}
+	for _, elem := range module.Elems {
+		switch {
+		case elem.Mode == DeclarativeSegment || elem.Table != 0 || elem.Type != FuncRef:
+			set = set.With(ReferenceTypesFeature)
+		case elem.Mode == PassiveSegment || elem.Funcs == nil:
+			set = set.With(BulkMemoryFeature)
+		}
+		constExpr(elem.Offset)
+		for _, expr := range elem.Exprs {
+			constExpr(expr)
+		}
+	}
+	if module.DataCount != nil {
+		set = set.With(BulkMemoryFeature)
+	}
+	for _, data := range module.Datas {
+		if data.Mode == PassiveSegment {
+			set = set.With(BulkMemoryFeature)
+		}
+		constExpr(data.Offset)
+	}
+	return set
+}
+
+func (module *Module) isMutableGlobal(index uint32) bool {
+	for _, imp := range module.Imports {
+		if imp.Kind != GlobalExtern {
+			continue
+		}
+		if index == 0 {
+			return imp.Global.Mutable
+		}
+		index--
+	}
+	if uint64(index) < uint64(len(module.Globals)) {
+		return module.Globals[index].Type.Mutable
+	}
+	return false
+}
+
+var (
+	_ fmt.GoStringer = Feature(0)
+	_ fmt.Stringer   = Feature(0)
+	_ fmt.GoStringer = Features(0)
+	_ fmt.Stringer   = Features(0)
+)
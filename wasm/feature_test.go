@@ -0,0 +1,93 @@
+package wasm
+
+import (
+	"testing"
+)
+
+func TestModule_RequiredFeatures(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Module *Module
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Empty",
+			Module: &Module{},
+			Expect: "",
+		},
+		{
+			Name: "MultiValue",
+			Module: &Module{
+				Types: []*FuncType{{Results: []ValType{I32, I64}}},
+			},
+			Expect: "multi-value",
+		},
+		{
+			Name: "MutableGlobalExport",
+			Module: &Module{
+				Imports: []*Import{{Kind: GlobalExtern, Global: GlobalType{Type: I32}}},
+				Globals: []*Global{{Type: GlobalType{Type: V128, Mutable: true}, Init: Expr{0xfd, 0x0c, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x0b}}},
+				Exports: []*Export{{Name: "g", Kind: GlobalExtern, Index: 1}},
+			},
+			Expect: "mutable-globals,simd",
+		},
+		{
+			Name: "Segments",
+			Module: &Module{
+				Memories:  []*Memory{{Type: MemoryType{Limits: Limits{Min: 1, Max: 1, HasMax: true, Shared: true}}}},
+				Elems:     []*Elem{{Mode: DeclarativeSegment, Type: FuncRef, Funcs: []uint32{}}},
+				Datas:     []*Data{{Mode: PassiveSegment}},
+				DataCount: new(uint32),
+			},
+			Expect: "bulk-memory,reference-types,threads",
+		},
+		{
+			Name: "ExtendedConst",
+			Module: &Module{
+				Globals: []*Global{
+					{Type: GlobalType{Type: I32}, Init: Expr{0x41, 0x6a, 0x0b}},
+					{Type: GlobalType{Type: I64}, Init: Expr{0x42, 0x01, 0x42, 0x02, 0x7c, 0x0b}},
+				},
+			},
+			Expect: "extended-const",
+		},
+		{
+			Name: "Instructions",
+			Module: &Module{
+				Funcs: []*Func{
+					// (block (type 0) (i32.extend8_s (i32.trunc_sat_f32_s (f32.const 0)))) drop
+					{Body: Expr{0x02, 0x00, 0x43, 0, 0, 0, 0, 0xfc, 0x00, 0xc0, 0x0b, 0x1a, 0x0b}},
+					// select (result v128) on two v128.const
+					{Body: Expr{0xfd, 0x0c, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x1c, 0x01, 0x7b, 0x0b}},
+				},
+			},
+			Expect: "nontrapping-float-to-int,sign-extension,multi-value,reference-types,simd",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if actual := row.Module.RequiredFeatures().String(); actual != row.Expect {
+				t.Errorf("wrong result: expect %q, got %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestParseFeatures(t *testing.T) {
+	set, err := ParseFeatures("simd, threads")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expect := Features(0).With(SIMDFeature).With(ThreadsFeature); set != expect {
+		t.Errorf("wrong result: expect %v, got %v", expect, set)
+	}
+	if _, err := ParseFeatures("simd,gc"); err == nil || err.Error() != `wasm: unknown feature "gc"` {
+		t.Errorf("wrong error: %v", err)
+	}
+	if DefaultFeatures.Has(ThreadsFeature) || !DefaultFeatures.Has(BulkMemoryFeature) {
+		t.Errorf("wrong default features: %v", DefaultFeatures)
+	}
+}
@@ -0,0 +1,72 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+// FeaturePolicy is the prefix of an entry in a "target_features" custom
+// section.
+type FeaturePolicy byte
+
+const (
+	// UsedFeature, written '+', marks a feature that the module uses.
+	UsedFeature FeaturePolicy = iota
+
+	// DisallowedFeature, written '-', marks a feature that the module
+	// must not be linked with modules that use.
+	DisallowedFeature
+
+	// RequiredFeature, written '=', marks a feature that every module it
+	// is linked with must also use.
+	RequiredFeature
+)
+
+var featurePolicyGoNames = [...]string{
+	"wasm.UsedFeature",
+	"wasm.DisallowedFeature",
+	"wasm.RequiredFeature",
+}
+
+var featurePolicyNames = [...]string{
+	"+",
+	"-",
+	"=",
+}
+
+func FeaturePolicyByPrefix(prefix byte) (FeaturePolicy, bool) {
+	for i, str := range featurePolicyNames {
+		if str[0] == prefix {
+			return FeaturePolicy(i), true
+		}
+	}
+	return 0, false
+}
+
+func (enum FeaturePolicy) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum FeaturePolicy) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum FeaturePolicy) AppendTo(out []byte, verbose bool) []byte {
+	names := featurePolicyNames
+	if verbose {
+		names = featurePolicyGoNames
+	}
+	var str string
+	if enum < FeaturePolicy(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wasm.FeaturePolicy(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = FeaturePolicy(0)
+	_ fmt.Stringer   = FeaturePolicy(0)
+)
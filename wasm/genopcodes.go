@@ -0,0 +1,311 @@
+//go:build ignore
+
+// genopcodes reads opcodes.txt and writes opcodetable.go.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var immediateGoNames = map[string]string{
+	"blocktype":     "BlockTypeImmediate",
+	"labelidx":      "LabelImmediate",
+	"vec(labelidx)": "LabelVecImmediate",
+	"funcidx":       "FuncImmediate",
+	"typeidx":       "TypeImmediate",
+	"tableidx":      "TableImmediate",
+	"localidx":      "LocalImmediate",
+	"globalidx":     "GlobalImmediate",
+	"elemidx":       "ElemImmediate",
+	"dataidx":       "DataImmediate",
+	"memarg":        "MemArgImmediate",
+	"laneidx":       "LaneImmediate",
+	"laneidx16":     "ShuffleImmediate",
+	"i32":           "I32Immediate",
+	"i64":           "I64Immediate",
+	"f32":           "F32Immediate",
+	"f64":           "F64Immediate",
+	"v128":          "V128Immediate",
+	"heaptype":      "HeapTypeImmediate",
+	"vec(valtype)":  "ValTypeVecImmediate",
+	"0x00":          "ZeroByteImmediate",
+}
+
+var valTypeGoNames = map[string]string{
+	"i32":       "I32",
+	"i64":       "I64",
+	"f32":       "F32",
+	"f64":       "F64",
+	"v128":      "V128",
+	"funcref":   "FuncRef",
+	"externref": "ExternRef",
+}
+
+var featureGoNames = map[string]string{
+	"mutable-globals":          "MutableGlobalsFeature",
+	"nontrapping-float-to-int": "NonTrappingFloatToIntFeature",
+	"sign-extension":           "SignExtensionFeature",
+	"multi-value":              "MultiValueFeature",
+	"bulk-memory":              "BulkMemoryFeature",
+	"reference-types":          "ReferenceTypesFeature",
+	"simd":                     "SIMDFeature",
+	"threads":                  "ThreadsFeature",
+	"extended-const":           "ExtendedConstFeature",
+}
+
+type row struct {
+	opcode     uint32
+	name       string
+	goName     string
+	immediates []string
+	params     []string
+	results    []string
+	dynamic    bool
+	feature    string
+	align      uint
+}
+
+func main() {
+	rows, err := readRows("opcodes.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(generate(rows))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("opcodetable.go", src, 0o666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readRows(path string) ([]row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows []row
+	var lineNum int
+	seen := make(map[string]int)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		lineNum++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		r, err := parseRow(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		if n := len(rows); n > 0 && rows[n-1].opcode >= r.opcode {
+			return nil, fmt.Errorf("%s:%d: opcode %#x is out of order", path, lineNum, r.opcode)
+		}
+		if prev, found := seen[r.goName]; found {
+			return nil, fmt.Errorf("%s:%d: Op%s is already defined on line %d", path, lineNum, r.goName, prev)
+		}
+		seen[r.goName] = lineNum
+		rows = append(rows, r)
+	}
+	return rows, sc.Err()
+}
+
+func parseRow(line string) (row, error) {
+	var r row
+	fields := strings.Fields(line)
+	if len(fields) != 5 && len(fields) != 6 {
+		return r, fmt.Errorf("expected 5 or 6 columns, found %d", len(fields))
+	}
+
+	prefix, code, isPrefixed := strings.Cut(fields[0], ":")
+	u64, err := strconv.ParseUint(prefix, 0, 8)
+	if err != nil {
+		return r, fmt.Errorf("invalid opcode %q: %w", fields[0], err)
+	}
+	r.opcode = uint32(u64)
+	if isPrefixed {
+		u64, err = strconv.ParseUint(code, 0, 16)
+		if err != nil {
+			return r, fmt.Errorf("invalid opcode %q: %w", fields[0], err)
+		}
+		r.opcode = r.opcode<<16 | uint32(u64)
+	}
+
+	r.name = fields[1]
+	r.goName = goName(r.name)
+	if len(fields) == 6 {
+		r.goName = fields[5]
+	}
+
+	if fields[2] != "-" {
+		for _, imm := range strings.Split(fields[2], ",") {
+			if _, found := immediateGoNames[imm]; !found {
+				return r, fmt.Errorf("unknown immediate %q", imm)
+			}
+			r.immediates = append(r.immediates, imm)
+		}
+	}
+
+	if fields[3] == "*" {
+		r.dynamic = true
+	} else {
+		params, results, ok := strings.Cut(fields[3], "->")
+		if !ok {
+			return r, fmt.Errorf("invalid signature %q", fields[3])
+		}
+		if r.params, err = parseTypes(params); err != nil {
+			return r, err
+		}
+		if r.results, err = parseTypes(results); err != nil {
+			return r, err
+		}
+	}
+
+	for _, imm := range r.immediates {
+		if imm == "memarg" {
+			r.align = naturalAlign(r.name)
+		}
+	}
+
+	if fields[4] != "-" {
+		if _, found := featureGoNames[fields[4]]; !found {
+			return r, fmt.Errorf("unknown feature %q", fields[4])
+		}
+		r.feature = fields[4]
+	}
+	return r, nil
+}
+
+func parseTypes(str string) ([]string, error) {
+	if len(str) < 2 || str[0] != '[' || str[len(str)-1] != ']' {
+		return nil, fmt.Errorf("invalid type list %q", str)
+	}
+	str = str[1 : len(str)-1]
+	if str == "" {
+		return nil, nil
+	}
+	list := strings.Split(str, ",")
+	for _, vt := range list {
+		if _, found := valTypeGoNames[vt]; !found {
+			return nil, fmt.Errorf("unknown value type %q", vt)
+		}
+	}
+	return list, nil
+}
+
+var accessWidthRE = regexp.MustCompile(`(?:load|store|rmw|wait)(8|16|32|64)(x\d+)?`)
+
+// naturalAlign returns the base-2 logarithm of the number of bytes that a
+// memory instruction accesses: the width in its mnemonic, if any, or else
+// the width of its type.
+func naturalAlign(name string) uint {
+	width := name[:strings.IndexByte(name, '.')]
+	if m := accessWidthRE.FindStringSubmatch(name); m != nil {
+		width = m[1]
+		if m[2] != "" {
+			width = "64"
+		}
+	}
+	switch width {
+	case "8":
+		return 0
+	case "16":
+		return 1
+	case "32", "i32", "f32", "memory":
+		return 2
+	case "64", "i64", "f64":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// goName turns a mnemonic like "i32.trunc_sat_f32_s" into
+// "I32TruncSatF32S".
+func goName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(ch rune) bool { return ch == '.' || ch == '_' }) {
+		sb.WriteString(strings.ToUpper(part[:1]))
+		sb.WriteString(part[1:])
+	}
+	return sb.String()
+}
+
+func generate(rows []row) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by genopcodes.go from opcodes.txt; DO NOT EDIT.\n\n")
+	buf.WriteString("package wasm\n\n")
+
+	buf.WriteString("const (\n")
+	for _, r := range rows {
+		if r.opcode > 0xff {
+			fmt.Fprintf(&buf, "\tOp%s Opcode = 0x%06x\n", r.goName, r.opcode)
+		} else {
+			fmt.Fprintf(&buf, "\tOp%s Opcode = 0x%02x\n", r.goName, r.opcode)
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// opcodeInfos is sorted by Opcode.\n")
+	buf.WriteString("var opcodeInfos = [...]OpcodeInfo{\n")
+	for _, r := range rows {
+		fmt.Fprintf(&buf, "\t{Opcode: Op%s, Name: %q", r.goName, r.name)
+		if len(r.immediates) != 0 {
+			buf.WriteString(", Immediates: []Immediate{")
+			writeList(&buf, r.immediates, immediateGoNames)
+			buf.WriteString("}")
+		}
+		if len(r.params) != 0 {
+			buf.WriteString(", Params: []ValType{")
+			writeList(&buf, r.params, valTypeGoNames)
+			buf.WriteString("}")
+		}
+		if len(r.results) != 0 {
+			buf.WriteString(", Results: []ValType{")
+			writeList(&buf, r.results, valTypeGoNames)
+			buf.WriteString("}")
+		}
+		if r.dynamic {
+			buf.WriteString(", Dynamic: true")
+		}
+		if r.align != 0 {
+			fmt.Fprintf(&buf, ", NaturalAlign: %d", r.align)
+		}
+		if r.feature != "" {
+			fmt.Fprintf(&buf, ", Features: 1 << %s", featureGoNames[r.feature])
+		}
+		fmt.Fprintf(&buf, ", goName: %q},\n", "wasm.Op"+r.goName)
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// opcodesByName holds the first opcode listed for each mnemonic.\n")
+	buf.WriteString("var opcodesByName = map[string]Opcode{\n")
+	named := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		if !named[r.name] {
+			named[r.name] = true
+			fmt.Fprintf(&buf, "\t%q: Op%s,\n", r.name, r.goName)
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func writeList(buf *bytes.Buffer, list []string, goNames map[string]string) {
+	for i, item := range list {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(goNames[item])
+	}
+}
@@ -0,0 +1,110 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+// Immediate is the kind of an immediate argument of an instruction, as it
+// is encoded in the binary format.
+type Immediate byte
+
+const (
+	BlockTypeImmediate Immediate = iota
+	LabelImmediate
+	LabelVecImmediate
+	FuncImmediate
+	TypeImmediate
+	TableImmediate
+	LocalImmediate
+	GlobalImmediate
+	ElemImmediate
+	DataImmediate
+	MemArgImmediate
+	LaneImmediate
+	ShuffleImmediate
+	I32Immediate
+	I64Immediate
+	F32Immediate
+	F64Immediate
+	V128Immediate
+	HeapTypeImmediate
+	ValTypeVecImmediate
+	ZeroByteImmediate
+)
+
+var immediateGoNames = [...]string{
+	"wasm.BlockTypeImmediate",
+	"wasm.LabelImmediate",
+	"wasm.LabelVecImmediate",
+	"wasm.FuncImmediate",
+	"wasm.TypeImmediate",
+	"wasm.TableImmediate",
+	"wasm.LocalImmediate",
+	"wasm.GlobalImmediate",
+	"wasm.ElemImmediate",
+	"wasm.DataImmediate",
+	"wasm.MemArgImmediate",
+	"wasm.LaneImmediate",
+	"wasm.ShuffleImmediate",
+	"wasm.I32Immediate",
+	"wasm.I64Immediate",
+	"wasm.F32Immediate",
+	"wasm.F64Immediate",
+	"wasm.V128Immediate",
+	"wasm.HeapTypeImmediate",
+	"wasm.ValTypeVecImmediate",
+	"wasm.ZeroByteImmediate",
+}
+
+var immediateNames = [...]string{
+	"blocktype",
+	"labelidx",
+	"vec(labelidx)",
+	"funcidx",
+	"typeidx",
+	"tableidx",
+	"localidx",
+	"globalidx",
+	"elemidx",
+	"dataidx",
+	"memarg",
+	"laneidx",
+	"laneidx16",
+	"i32",
+	"i64",
+	"f32",
+	"f64",
+	"v128",
+	"heaptype",
+	"vec(valtype)",
+	"0x00",
+}
+
+func (enum Immediate) GoString() string {
+	var scratch [32]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum Immediate) String() string {
+	var scratch [32]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum Immediate) AppendTo(out []byte, verbose bool) []byte {
+	names := immediateNames
+	if verbose {
+		names = immediateGoNames
+	}
+	var str string
+	if enum < Immediate(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wasm.Immediate(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = Immediate(0)
+	_ fmt.Stringer   = Immediate(0)
+)
@@ -0,0 +1,165 @@
+package wasm
+
+import (
+	"encoding/binary"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+// Instr is an instruction together with its immediate arguments.  Which
+// of the fields are meaningful depends on the Immediates of its Opcode.
+type Instr struct {
+	Opcode Opcode
+
+	// Index holds the first index immediate, which for br and br_if is a
+	// label depth.  Index2 holds the second, which call_indirect,
+	// table.init and table.copy have.
+	Index  uint32
+	Index2 uint32
+
+	// Labels holds the label depths of br_table, the default last.
+	Labels []uint32
+
+	BlockType BlockType
+	MemArg    MemArg
+	Lane      byte
+
+	// Value holds the bits of an i32, i64, f32 or f64 constant, zero
+	// extended.  V128 holds a v128 constant, or the lanes of
+	// i8x16.shuffle.
+	Value uint64
+	V128  [16]byte
+
+	// HeapType holds the reference type of ref.null, and Types the
+	// operand types of a typed select.
+	HeapType ValType
+	Types    []ValType
+
+	// Origin holds the instruction's offset within the Expr it was
+	// decoded from, or the wat.Node it was lowered from.
+	Origin Origin
+}
+
+// BlockType is the type of a block, loop or if, stored as its binary
+// encoding: a non-negative type index, or a negative 7-bit value for
+// EmptyBlock and for a single result type.
+type BlockType int64
+
+const EmptyBlock BlockType = -0x40
+
+func ValTypeBlock(vt ValType) BlockType {
+	return BlockType(int64(vt) - 0x80)
+}
+
+func TypeIndexBlock(index uint32) BlockType {
+	return BlockType(index)
+}
+
+// ValType returns the single result type of the block, if it has one.
+func (bt BlockType) ValType() (ValType, bool) {
+	if bt < 0 && bt != EmptyBlock {
+		return ValType(bt + 0x80), true
+	}
+	return 0, false
+}
+
+func (bt BlockType) TypeIndex() (uint32, bool) {
+	if bt >= 0 {
+		return uint32(bt), true
+	}
+	return 0, false
+}
+
+// MemArg is the immediate of a memory access.  Align is the base-2
+// logarithm of the alignment, as in the binary format.
+type MemArg struct {
+	Align  uint32
+	Offset uint32
+}
+
+// AppendBinary appends the binary encoding of the instruction.
+func (instr *Instr) AppendBinary(out []byte) []byte {
+	out = instr.Opcode.AppendBinary(out)
+	info := instr.Opcode.Info()
+	if info == nil {
+		return out
+	}
+	numIndices := 0
+	for _, imm := range info.Immediates {
+		switch imm {
+		case BlockTypeImmediate:
+			out = leb128.AppendInt64(out, int64(instr.BlockType))
+		case LabelVecImmediate:
+			labels := instr.Labels
+			if len(labels) == 0 {
+				labels = []uint32{0}
+			}
+			out = leb128.AppendUint32(out, uint32(len(labels)-1))
+			for _, label := range labels {
+				out = leb128.AppendUint32(out, label)
+			}
+		case MemArgImmediate:
+			out = leb128.AppendUint32(out, instr.MemArg.Align)
+			out = leb128.AppendUint32(out, instr.MemArg.Offset)
+		case LaneImmediate:
+			out = append(out, instr.Lane)
+		case ShuffleImmediate, V128Immediate:
+			out = append(out, instr.V128[:]...)
+		case I32Immediate:
+			out = leb128.AppendInt32(out, int32(uint32(instr.Value)))
+		case I64Immediate:
+			out = leb128.AppendInt64(out, int64(instr.Value))
+		case F32Immediate:
+			out = binary.LittleEndian.AppendUint32(out, uint32(instr.Value))
+		case F64Immediate:
+			out = binary.LittleEndian.AppendUint64(out, instr.Value)
+		case HeapTypeImmediate:
+			out = append(out, byte(instr.HeapType))
+		case ValTypeVecImmediate:
+			out = leb128.AppendUint32(out, uint32(len(instr.Types)))
+			for _, vt := range instr.Types {
+				out = append(out, byte(vt))
+			}
+		case ZeroByteImmediate:
+			out = append(out, 0x00)
+		default:
+			index := instr.Index
+			if numIndices > 0 {
+				index = instr.Index2
+			}
+			numIndices++
+			out = leb128.AppendUint32(out, index)
+		}
+	}
+	return out
+}
+
+// EncodeExpr returns the binary encoding of a list of instructions.  It
+// does not add a final "end"; the list must already have one.
+func EncodeExpr(list []Instr) Expr {
+	out := make(Expr, 0, 4*len(list))
+	for i := range list {
+		out = list[i].AppendBinary(out)
+	}
+	return out
+}
+
+// DecodeExpr decodes every instruction of an expression, without checking
+// that its blocks are properly nested.  Errors are of type *DecodeError,
+// with offsets relative to the start of expr.
+func DecodeExpr(expr Expr) ([]Instr, error) {
+	d := &decoder{
+		data:  expr,
+		end:   len(expr),
+		bound: "expression",
+		where: "expression",
+	}
+	list := make([]Instr, 0, len(expr)/2)
+	for d.err == nil && d.pos < d.end {
+		list = append(list, d.instr())
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return list, nil
+}
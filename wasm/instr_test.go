@@ -0,0 +1,78 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInstr(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Instr    Instr
+		Encoding []byte
+	}
+
+	testData := [...]testCase{
+		{"Nop", Instr{Opcode: OpNop}, []byte{0x01}},
+		{"Block", Instr{Opcode: OpBlock, BlockType: EmptyBlock}, []byte{0x02, 0x40}},
+		{"IfResult", Instr{Opcode: OpIf, BlockType: ValTypeBlock(I32)}, []byte{0x04, 0x7f}},
+		{"LoopType", Instr{Opcode: OpLoop, BlockType: TypeIndexBlock(200)}, []byte{0x03, 0xc8, 0x01}},
+		{"BrTable", Instr{Opcode: OpBrTable, Labels: []uint32{1, 2, 0}}, []byte{0x0e, 0x02, 0x01, 0x02, 0x00}},
+		{"CallIndirect", Instr{Opcode: OpCallIndirect, Index: 3, Index2: 1}, []byte{0x11, 0x03, 0x01}},
+		{"SelectT", Instr{Opcode: OpSelectT, Types: []ValType{I64}}, []byte{0x1c, 0x01, 0x7e}},
+		{"Load", Instr{Opcode: OpI64Load32U, MemArg: MemArg{Align: 2, Offset: 128}}, []byte{0x35, 0x02, 0x80, 0x01}},
+		{"MemoryGrow", Instr{Opcode: OpMemoryGrow}, []byte{0x40, 0x00}},
+		{"I32Const", Instr{Opcode: OpI32Const, Value: 0xffffffff}, []byte{0x41, 0x7f}},
+		{"F32Const", Instr{Opcode: OpF32Const, Value: 0x3fc00000}, []byte{0x43, 0x00, 0x00, 0xc0, 0x3f}},
+		{"RefNull", Instr{Opcode: OpRefNull, HeapType: ExternRef}, []byte{0xd0, 0x6f}},
+		{"TableInit", Instr{Opcode: OpTableInit, Index: 4, Index2: 1}, []byte{0xfc, 0x0c, 0x04, 0x01}},
+		{"MemoryInit", Instr{Opcode: OpMemoryInit, Index: 2}, []byte{0xfc, 0x08, 0x02, 0x00}},
+		{"ExtractLane", Instr{Opcode: OpI8x16ExtractLaneS, Lane: 15}, []byte{0xfd, 0x15, 0x0f}},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if actual := row.Instr.AppendBinary(nil); !bytes.Equal(actual, row.Encoding) {
+				t.Errorf("AppendBinary: expect % x, got % x", row.Encoding, actual)
+			}
+			list, err := DecodeExpr(Expr(row.Encoding))
+			if err != nil {
+				t.Fatalf("DecodeExpr: unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(list, []Instr{row.Instr}) {
+				t.Errorf("DecodeExpr: expect %+v, got %+v", row.Instr, list)
+			}
+		})
+	}
+}
+
+func TestDecodeExpr(t *testing.T) {
+	expr := Expr{0x41, 0x01, 0x41, 0x02, 0x6a, 0x0b}
+	list, err := DecodeExpr(expr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	offsets := []uint64{0, 2, 4, 5}
+	if len(list) != len(offsets) {
+		t.Fatalf("expect %d instructions, got %d", len(offsets), len(list))
+	}
+	for i, instr := range list {
+		if instr.Origin.Offset != offsets[i] {
+			t.Errorf("instruction %d: expect offset %d, got %d", i, offsets[i], instr.Origin.Offset)
+		}
+	}
+	for i := range list {
+		list[i].Origin = Origin{}
+	}
+	if actual := EncodeExpr(list); !bytes.Equal(actual, expr) {
+		t.Errorf("EncodeExpr: expect % x, got % x", []byte(expr), []byte(actual))
+	}
+
+	_, err = DecodeExpr(Expr{0x41, 0x80})
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Errorf("expect *DecodeError, got %#v", err)
+	}
+}
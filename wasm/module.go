@@ -0,0 +1,166 @@
+package wasm
+
+// Module is the semantic representation of a WebAssembly module, shared
+// by the text and binary formats.
+//
+// As in the binary format, each index space begins with the imports of
+// that kind, in order, followed by the module's own definitions.
+type Module struct {
+	Types     []*FuncType
+	Imports   []*Import
+	Funcs     []*Func
+	Tables    []*Table
+	Memories  []*Memory
+	Globals   []*Global
+	Exports   []*Export
+	Start     *Start
+	Elems     []*Elem
+	Datas     []*Data
+	DataCount *uint32
+	Customs   []*Custom
+	Names     *Names
+	Sections  []*Section
+	Origin    Origin
+}
+
+// Section records the layout and raw payload of one section of a binary
+// module, in the order they were decoded.
+type Section struct {
+	ID            SectionID
+	Offset        uint64
+	PayloadOffset uint64
+	Payload       []byte
+
+	// custom is the Custom that Decode made from a custom section.
+	custom *Custom
+
+	// canonical is the minimal encoding of what Decode found in the
+	// section, which lets Encode detect whether it has changed.
+	canonical []byte
+}
+
+// Expr is an encoded instruction sequence, including its final "end".
+type Expr []byte
+
+type Import struct {
+	Module string
+	Name   string
+	Kind   ExternKind
+	Type   uint32
+	Table  TableType
+	Memory MemoryType
+	Global GlobalType
+	Origin Origin
+}
+
+type Func struct {
+	Type   uint32
+	Locals []Local
+	Body   Expr
+
+	// InstrOrigins holds, for a function lowered from text, the Origin
+	// of each instruction in Body, in order.  The Offset of each is
+	// where the instruction begins within Body.
+	InstrOrigins []Origin
+
+	Origin Origin
+}
+
+type Local struct {
+	Count uint32
+	Type  ValType
+}
+
+type Table struct {
+	Type   TableType
+	Origin Origin
+}
+
+type Memory struct {
+	Type   MemoryType
+	Origin Origin
+}
+
+type Global struct {
+	Type   GlobalType
+	Init   Expr
+	Origin Origin
+}
+
+type Export struct {
+	Name   string
+	Kind   ExternKind
+	Index  uint32
+	Origin Origin
+}
+
+type Start struct {
+	Func   uint32
+	Origin Origin
+}
+
+// Elem is an element segment.  Its items are either function indices
+// (Exprs is nil) or constant expressions (Funcs is nil).
+type Elem struct {
+	Mode   SegmentMode
+	Table  uint32
+	Offset Expr
+	Type   ValType
+	Funcs  []uint32
+	Exprs  []Expr
+	Origin Origin
+}
+
+type Data struct {
+	Mode   SegmentMode
+	Memory uint32
+	Offset Expr
+	Init   []byte
+	Origin Origin
+}
+
+// Custom is a custom section, kept as raw bytes.  Place says where it is
+// written relative to the known sections; Decode records where each was
+// found, and the zero value puts a new one at the end of the module.
+type Custom struct {
+	Name   string
+	Data   []byte
+	Place  CustomPlace
+	Origin Origin
+}
+
+func (module *Module) NumImported(kind ExternKind) uint32 {
+	var count uint32
+	for _, imp := range module.Imports {
+		if imp.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// FuncType returns the type of the function with the given index,
+// imported or defined, or nil if there is no such function or type.
+func (module *Module) FuncType(funcIndex uint32) *FuncType {
+	typeIndex, ok := module.funcTypeIndex(funcIndex)
+	if !ok || typeIndex >= uint32(len(module.Types)) {
+		return nil
+	}
+	return module.Types[typeIndex]
+}
+
+func (module *Module) funcTypeIndex(funcIndex uint32) (uint32, bool) {
+	for _, imp := range module.Imports {
+		if imp.Kind != FuncExtern {
+			continue
+		}
+		if funcIndex == 0 {
+			return imp.Type, true
+		}
+		funcIndex--
+	}
+	if funcIndex < uint32(len(module.Funcs)) {
+		return module.Funcs[funcIndex].Type, true
+	}
+	return 0, false
+}
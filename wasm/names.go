@@ -0,0 +1,42 @@
+package wasm
+
+// Names holds the human-readable names of a module's items, keyed by
+// index.  It is the content of the "name" custom section, and is also
+// what the $identifiers of a text module lower to.
+//
+// The labels of a function are numbered in the order that its block, loop
+// and if instructions appear, rather than by depth.
+type Names struct {
+	Module   string
+	Funcs    NameMap
+	Locals   IndirectNameMap
+	Labels   IndirectNameMap
+	Types    NameMap
+	Tables   NameMap
+	Memories NameMap
+	Globals  NameMap
+	Elems    NameMap
+	Datas    NameMap
+	Fields   IndirectNameMap
+}
+
+type NameMap map[uint32]string
+
+// IndirectNameMap holds names of items within other items, keyed by the
+// index of the outer item: the locals or labels of a function, or the
+// fields of a type.
+type IndirectNameMap map[uint32]NameMap
+
+func (names *Names) IsEmpty() bool {
+	return names == nil || (names.Module == "" &&
+		len(names.Funcs) == 0 &&
+		len(names.Locals) == 0 &&
+		len(names.Labels) == 0 &&
+		len(names.Types) == 0 &&
+		len(names.Tables) == 0 &&
+		len(names.Memories) == 0 &&
+		len(names.Globals) == 0 &&
+		len(names.Elems) == 0 &&
+		len(names.Datas) == 0 &&
+		len(names.Fields) == 0)
+}
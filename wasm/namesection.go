@@ -0,0 +1,202 @@
+package wasm
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+// NameSectionName is the name of the custom section that holds Names.
+const NameSectionName = "name"
+
+// Name subsection ids, including those of the extended name section
+// proposal.
+const (
+	moduleNameSubsection byte = 0
+	funcNameSubsection   byte = 1
+	localNameSubsection  byte = 2
+	labelNameSubsection  byte = 3
+	typeNameSubsection   byte = 4
+	tableNameSubsection  byte = 5
+	memoryNameSubsection byte = 6
+	globalNameSubsection byte = 7
+	elemNameSubsection   byte = 8
+	dataNameSubsection   byte = 9
+	fieldNameSubsection  byte = 10
+)
+
+// EncodeNames returns the payload of a "name" custom section, without the
+// section name itself.  Empty subsections are left out.
+func EncodeNames(names *Names) []byte {
+	var out []byte
+	if names == nil {
+		return out
+	}
+	subsection := func(id byte, content []byte) {
+		out = append(out, id)
+		out = leb128.AppendUint32(out, uint32(len(content)))
+		out = append(out, content...)
+	}
+	nameMap := func(id byte, m NameMap) {
+		if len(m) != 0 {
+			subsection(id, appendNameMap(nil, m))
+		}
+	}
+	indirectNameMap := func(id byte, m IndirectNameMap) {
+		if len(m) != 0 {
+			subsection(id, appendIndirectNameMap(nil, m))
+		}
+	}
+
+	if names.Module != "" {
+		subsection(moduleNameSubsection, appendName(nil, names.Module))
+	}
+	nameMap(funcNameSubsection, names.Funcs)
+	indirectNameMap(localNameSubsection, names.Locals)
+	indirectNameMap(labelNameSubsection, names.Labels)
+	nameMap(typeNameSubsection, names.Types)
+	nameMap(tableNameSubsection, names.Tables)
+	nameMap(memoryNameSubsection, names.Memories)
+	nameMap(globalNameSubsection, names.Globals)
+	nameMap(elemNameSubsection, names.Elems)
+	nameMap(dataNameSubsection, names.Datas)
+	indirectNameMap(fieldNameSubsection, names.Fields)
+	return out
+}
+
+// DecodeNames parses the payload of a "name" custom section, without the
+// section name itself.  Subsections that Names has no place for are
+// skipped.  Errors are of type *DecodeError, with offsets relative to
+// the start of data.
+func DecodeNames(data []byte) (*Names, error) {
+	d := &decoder{
+		data:  data,
+		end:   len(data),
+		bound: "name section",
+		where: "name section",
+	}
+	names := &Names{}
+	last := -1
+	for d.err == nil && d.pos < len(d.data) {
+		offset := d.pos
+		id := d.byte()
+		size := d.u32()
+		if d.err != nil {
+			break
+		}
+		if int(id) <= last {
+			d.failf(offset, "name subsection %d: duplicate or out of order", id)
+			break
+		}
+		last = int(id)
+		if remaining := len(d.data) - d.pos; uint64(size) > uint64(remaining) {
+			d.fail(d.pos, fmt.Sprintf("name subsection size %d exceeds the %d bytes remaining", size, remaining), io.ErrUnexpectedEOF)
+			break
+		}
+
+		d.end = d.pos + int(size)
+		switch id {
+		case moduleNameSubsection:
+			names.Module = d.name()
+		case funcNameSubsection:
+			names.Funcs = d.nameMap()
+		case localNameSubsection:
+			names.Locals = d.indirectNameMap()
+		case labelNameSubsection:
+			names.Labels = d.indirectNameMap()
+		case typeNameSubsection:
+			names.Types = d.nameMap()
+		case tableNameSubsection:
+			names.Tables = d.nameMap()
+		case memoryNameSubsection:
+			names.Memories = d.nameMap()
+		case globalNameSubsection:
+			names.Globals = d.nameMap()
+		case elemNameSubsection:
+			names.Elems = d.nameMap()
+		case dataNameSubsection:
+			names.Datas = d.nameMap()
+		case fieldNameSubsection:
+			names.Fields = d.indirectNameMap()
+		default:
+			d.pos = d.end
+		}
+		if d.err == nil && d.pos != d.end {
+			d.failf(d.pos, "name subsection size mismatch: %d bytes left over", d.end-d.pos)
+		}
+		d.pos = d.end
+		d.end = len(d.data)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return names, nil
+}
+
+func (d *decoder) nameMap() NameMap {
+	n := d.vec()
+	m := make(NameMap, n)
+	last := int64(-1)
+	for ; n > 0 && d.err == nil; n-- {
+		offset := d.pos
+		index := d.u32()
+		name := d.name()
+		if d.err == nil && int64(index) <= last {
+			d.failf(offset, "name map index %d: duplicate or out of order", index)
+		}
+		last = int64(index)
+		m[index] = name
+	}
+	return m
+}
+
+func (d *decoder) indirectNameMap() IndirectNameMap {
+	n := d.vec()
+	m := make(IndirectNameMap, n)
+	last := int64(-1)
+	for ; n > 0 && d.err == nil; n-- {
+		offset := d.pos
+		index := d.u32()
+		inner := d.nameMap()
+		if d.err == nil && int64(index) <= last {
+			d.failf(offset, "name map index %d: duplicate or out of order", index)
+		}
+		last = int64(index)
+		m[index] = inner
+	}
+	return m
+}
+
+func appendNameMap(out []byte, m NameMap) []byte {
+	out = leb128.AppendUint32(out, uint32(len(m)))
+	for _, index := range sortedKeys(m) {
+		out = leb128.AppendUint32(out, index)
+		out = appendName(out, m[index])
+	}
+	return out
+}
+
+func appendIndirectNameMap(out []byte, m IndirectNameMap) []byte {
+	out = leb128.AppendUint32(out, uint32(len(m)))
+	for _, index := range sortedKeys(m) {
+		out = leb128.AppendUint32(out, index)
+		out = appendNameMap(out, m[index])
+	}
+	return out
+}
+
+func appendName(out []byte, str string) []byte {
+	out = leb128.AppendUint32(out, uint32(len(str)))
+	return append(out, str...)
+}
+
+func sortedKeys[V any](m map[uint32]V) []uint32 {
+	keys := make([]uint32, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,68 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeNames(t *testing.T) {
+	names := &Names{
+		Module:  "m",
+		Funcs:   NameMap{2: "b", 0: "a"},
+		Locals:  IndirectNameMap{0: {0: "x"}},
+		Globals: NameMap{0: "g"},
+	}
+	expect := []byte{
+		0x00, 0x02, 0x01, 'm',
+		0x01, 0x07, 0x02, 0x00, 0x01, 'a', 0x02, 0x01, 'b',
+		0x02, 0x06, 0x01, 0x00, 0x01, 0x00, 0x01, 'x',
+		0x07, 0x04, 0x01, 0x00, 0x01, 'g',
+	}
+	if actual := EncodeNames(names); !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+}
+
+func TestDecodeNames(t *testing.T) {
+	names := &Names{
+		Module:  "m",
+		Funcs:   NameMap{2: "b", 0: "a"},
+		Locals:  IndirectNameMap{0: {0: "x"}},
+		Labels:  IndirectNameMap{2: {0: "loop", 3: "done"}},
+		Globals: NameMap{0: "g"},
+		Fields:  IndirectNameMap{1: {0: "x", 1: "y"}},
+	}
+	actual, err := DecodeNames(append(EncodeNames(names), 0x0b, 0x01, 0xff))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual, names) {
+		t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", names, actual)
+	}
+}
+
+func TestDecodeNames_Errors(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Input []byte
+	}
+
+	testData := [...]testCase{
+		{"OutOfOrder", []byte{0x01, 0x01, 0x00, 0x00, 0x02, 0x01, 'm'}},
+		{"Truncated", []byte{0x01, 0x05, 0x01, 0x00}},
+		{"LeftOver", []byte{0x00, 0x03, 0x01, 'm', 0x00}},
+		{"IndexOrder", []byte{0x01, 0x07, 0x02, 0x01, 0x01, 'a', 0x00, 0x01, 'b'}},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := DecodeNames(row.Input)
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Errorf("expected *DecodeError, got %v", err)
+			}
+		})
+	}
+}
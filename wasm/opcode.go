@@ -0,0 +1,161 @@
+package wasm
+
+//go:generate go run genopcodes.go
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+// Opcode identifies an instruction by its binary encoding.  An instruction
+// that begins with one of the prefix bytes 0xfc, 0xfd or 0xfe holds the
+// prefix in bits 16 to 23 and the sub-opcode in bits 0 to 15.
+type Opcode uint32
+
+// OpcodeInfo describes an instruction.
+type OpcodeInfo struct {
+	Opcode Opcode
+
+	// Name is the mnemonic of the instruction in the text format.
+	Name string
+
+	// Immediates lists the immediate arguments in binary order, which
+	// is not always the order of the text format.
+	Immediates []Immediate
+
+	// Params and Results are the operands that the instruction pops
+	// and the values that it pushes.
+	Params  []ValType
+	Results []ValType
+
+	// Dynamic is true if the stack signature depends on the immediates
+	// or on the validation context, in which case Params and Results
+	// are nil.
+	Dynamic bool
+
+	// NaturalAlign is, for an instruction with a MemArgImmediate, the
+	// base-2 logarithm of the number of bytes it accesses, which is
+	// both the default and the largest valid alignment.
+	NaturalAlign uint32
+
+	// Features holds the proposal that introduced the instruction, or
+	// nothing for the instructions of WebAssembly 1.0.
+	Features Features
+
+	goName string
+}
+
+var (
+	ErrUnknownPrefix = errors.New("unknown opcode prefix")
+	ErrLongOpcode    = errors.New("sub-opcode too large")
+)
+
+// OpcodeByName returns the opcode for a text format mnemonic.  The typed
+// "select" shares its mnemonic with the untyped one, so OpSelectT is never
+// returned.
+func OpcodeByName(name string) (Opcode, bool) {
+	op, found := opcodesByName[name]
+	return op, found
+}
+
+// DecodeOpcode reads an opcode from the start of in.  It does not check
+// that the opcode is assigned to an instruction.
+func DecodeOpcode(in []byte) (rest []byte, op Opcode, err error) {
+	if len(in) == 0 {
+		return in, 0, leb128.ErrTruncated
+	}
+	prefix := in[0]
+	if !isPrefix(prefix) {
+		return in[1:], Opcode(prefix), nil
+	}
+	rest, code, err := leb128.Uint32(in[1:])
+	if err != nil {
+		return in, 0, err
+	}
+	if code > 0xffff {
+		return in, 0, ErrLongOpcode
+	}
+	return rest, Opcode(prefix)<<16 | Opcode(code), nil
+}
+
+// Prefix returns the prefix byte, or 0 if the opcode is a single byte.
+func (op Opcode) Prefix() byte {
+	return byte(op >> 16)
+}
+
+// Code returns the opcode without its prefix.
+func (op Opcode) Code() uint32 {
+	if op.Prefix() != 0 {
+		return uint32(op & 0xffff)
+	}
+	return uint32(op)
+}
+
+// Info returns the description of the instruction, or nil if the opcode is
+// not assigned.
+func (op Opcode) Info() *OpcodeInfo {
+	i := sort.Search(len(opcodeInfos), func(i int) bool { return opcodeInfos[i].Opcode >= op })
+	if i < len(opcodeInfos) && opcodeInfos[i].Opcode == op {
+		return &opcodeInfos[i]
+	}
+	return nil
+}
+
+func (op Opcode) IsValid() bool {
+	return op.Info() != nil
+}
+
+// IsConstant reports whether the instruction may appear in a constant
+// expression, counting the arithmetic of the extended-const proposal.
+func (op Opcode) IsConstant() bool {
+	switch op {
+	case OpI32Const, OpI64Const, OpF32Const, OpF64Const, OpV128Const:
+		return true
+	case OpGlobalGet, OpRefNull, OpRefFunc:
+		return true
+	case OpI32Add, OpI32Sub, OpI32Mul, OpI64Add, OpI64Sub, OpI64Mul:
+		return true
+	}
+	return false
+}
+
+// AppendBinary appends the binary encoding of the opcode.
+func (op Opcode) AppendBinary(out []byte) []byte {
+	if prefix := op.Prefix(); prefix != 0 {
+		out = append(out, prefix)
+		return leb128.AppendUint32(out, op.Code())
+	}
+	return append(out, byte(op))
+}
+
+func (op Opcode) GoString() string {
+	var scratch [40]byte
+	return string(op.AppendTo(scratch[:0], true))
+}
+
+func (op Opcode) String() string {
+	var scratch [40]byte
+	return string(op.AppendTo(scratch[:0], false))
+}
+
+func (op Opcode) AppendTo(out []byte, verbose bool) []byte {
+	if info := op.Info(); info != nil {
+		if verbose {
+			return append(out, info.goName...)
+		}
+		return append(out, info.Name...)
+	}
+	return fmt.Appendf(out, "wasm.Opcode(%#x)", uint32(op))
+}
+
+func isPrefix(ch byte) bool {
+	return ch == 0xfc || ch == 0xfd || ch == 0xfe
+}
+
+var (
+	_ fmt.GoStringer = Opcode(0)
+	_ fmt.Stringer   = Opcode(0)
+)
@@ -0,0 +1,99 @@
+package wasm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+func TestOpcode(t *testing.T) {
+	type testCase struct {
+		Opcode   Opcode
+		Name     string
+		GoName   string
+		Encoding []byte
+	}
+
+	testData := [...]testCase{
+		{OpUnreachable, "unreachable", "wasm.OpUnreachable", []byte{0x00}},
+		{OpI32Add, "i32.add", "wasm.OpI32Add", []byte{0x6a}},
+		{OpSelectT, "select", "wasm.OpSelectT", []byte{0x1c}},
+		{OpMemoryInit, "memory.init", "wasm.OpMemoryInit", []byte{0xfc, 0x08}},
+		{OpV128Const, "v128.const", "wasm.OpV128Const", []byte{0xfd, 0x0c}},
+		{OpI32x4DotI16x8S, "i32x4.dot_i16x8_s", "wasm.OpI32x4DotI16x8S", []byte{0xfd, 0xba, 0x01}},
+		{OpI64AtomicRmw32CmpxchgU, "i64.atomic.rmw32.cmpxchg_u", "wasm.OpI64AtomicRmw32CmpxchgU", []byte{0xfe, 0x4e}},
+		{Opcode(0x06), "wasm.Opcode(0x6)", "wasm.Opcode(0x6)", []byte{0x06}},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if actual := row.Opcode.String(); actual != row.Name {
+				t.Errorf("String: expect %q, got %q", row.Name, actual)
+			}
+			if actual := row.Opcode.GoString(); actual != row.GoName {
+				t.Errorf("GoString: expect %q, got %q", row.GoName, actual)
+			}
+			if actual := row.Opcode.AppendBinary(nil); !bytes.Equal(actual, row.Encoding) {
+				t.Errorf("AppendBinary: expect % x, got % x", row.Encoding, actual)
+			}
+			rest, op, err := DecodeOpcode(append(row.Encoding, 0xff))
+			if err != nil || op != row.Opcode || !bytes.Equal(rest, []byte{0xff}) {
+				t.Errorf("DecodeOpcode: expect %#v, got %#v, % x, %v", row.Opcode, op, rest, err)
+			}
+		})
+	}
+}
+
+func TestOpcodeTable(t *testing.T) {
+	counts := make(map[byte]int)
+	for i := range opcodeInfos {
+		info := &opcodeInfos[i]
+		counts[info.Opcode.Prefix()]++
+		if actual := info.Opcode.Info(); actual != info {
+			t.Errorf("%v: Info returned %p, expected %p", info.Opcode, actual, info)
+		}
+		op, found := OpcodeByName(info.Name)
+		if !found || (op != info.Opcode && info.Opcode != OpSelectT) {
+			t.Errorf("%v: OpcodeByName returned %#v, %v", info.Opcode, op, found)
+		}
+		if info.Dynamic && (info.Params != nil || info.Results != nil) {
+			t.Errorf("%v: dynamic instruction has a stack signature", info.Opcode)
+		}
+	}
+
+	// WebAssembly 2.0 assigns 437 opcodes, and the threads proposal
+	// adds the 67 under 0xfe.
+	expect := map[byte]int{0x00: 183, 0xfc: 18, 0xfd: 236, 0xfe: 67}
+	for prefix, n := range expect {
+		if counts[prefix] != n {
+			t.Errorf("prefix 0x%02x: expect %d instructions, got %d", prefix, n, counts[prefix])
+		}
+	}
+}
+
+func TestDecodeOpcode_Errors(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Input []byte
+		Err   error
+	}
+
+	testData := [...]testCase{
+		{"Empty", nil, leb128.ErrTruncated},
+		{"Truncated", []byte{0xfd, 0x80}, leb128.ErrTruncated},
+		{"TooLarge", []byte{0xfc, 0x80, 0x80, 0x04}, ErrLongOpcode},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			rest, _, err := DecodeOpcode(row.Input)
+			if err != row.Err {
+				t.Errorf("expect %v, got %v", row.Err, err)
+			}
+			if len(rest) != len(row.Input) {
+				t.Errorf("expected input to be returned unconsumed, got % x", rest)
+			}
+		})
+	}
+}
@@ -0,0 +1,1525 @@
+// Code generated by genopcodes.go from opcodes.txt; DO NOT EDIT.
+
+package wasm
+
+const (
+	OpUnreachable               Opcode = 0x00
+	OpNop                       Opcode = 0x01
+	OpBlock                     Opcode = 0x02
+	OpLoop                      Opcode = 0x03
+	OpIf                        Opcode = 0x04
+	OpElse                      Opcode = 0x05
+	OpEnd                       Opcode = 0x0b
+	OpBr                        Opcode = 0x0c
+	OpBrIf                      Opcode = 0x0d
+	OpBrTable                   Opcode = 0x0e
+	OpReturn                    Opcode = 0x0f
+	OpCall                      Opcode = 0x10
+	OpCallIndirect              Opcode = 0x11
+	OpDrop                      Opcode = 0x1a
+	OpSelect                    Opcode = 0x1b
+	OpSelectT                   Opcode = 0x1c
+	OpLocalGet                  Opcode = 0x20
+	OpLocalSet                  Opcode = 0x21
+	OpLocalTee                  Opcode = 0x22
+	OpGlobalGet                 Opcode = 0x23
+	OpGlobalSet                 Opcode = 0x24
+	OpTableGet                  Opcode = 0x25
+	OpTableSet                  Opcode = 0x26
+	OpI32Load                   Opcode = 0x28
+	OpI64Load                   Opcode = 0x29
+	OpF32Load                   Opcode = 0x2a
+	OpF64Load                   Opcode = 0x2b
+	OpI32Load8S                 Opcode = 0x2c
+	OpI32Load8U                 Opcode = 0x2d
+	OpI32Load16S                Opcode = 0x2e
+	OpI32Load16U                Opcode = 0x2f
+	OpI64Load8S                 Opcode = 0x30
+	OpI64Load8U                 Opcode = 0x31
+	OpI64Load16S                Opcode = 0x32
+	OpI64Load16U                Opcode = 0x33
+	OpI64Load32S                Opcode = 0x34
+	OpI64Load32U                Opcode = 0x35
+	OpI32Store                  Opcode = 0x36
+	OpI64Store                  Opcode = 0x37
+	OpF32Store                  Opcode = 0x38
+	OpF64Store                  Opcode = 0x39
+	OpI32Store8                 Opcode = 0x3a
+	OpI32Store16                Opcode = 0x3b
+	OpI64Store8                 Opcode = 0x3c
+	OpI64Store16                Opcode = 0x3d
+	OpI64Store32                Opcode = 0x3e
+	OpMemorySize                Opcode = 0x3f
+	OpMemoryGrow                Opcode = 0x40
+	OpI32Const                  Opcode = 0x41
+	OpI64Const                  Opcode = 0x42
+	OpF32Const                  Opcode = 0x43
+	OpF64Const                  Opcode = 0x44
+	OpI32Eqz                    Opcode = 0x45
+	OpI32Eq                     Opcode = 0x46
+	OpI32Ne                     Opcode = 0x47
+	OpI32LtS                    Opcode = 0x48
+	OpI32LtU                    Opcode = 0x49
+	OpI32GtS                    Opcode = 0x4a
+	OpI32GtU                    Opcode = 0x4b
+	OpI32LeS                    Opcode = 0x4c
+	OpI32LeU                    Opcode = 0x4d
+	OpI32GeS                    Opcode = 0x4e
+	OpI32GeU                    Opcode = 0x4f
+	OpI64Eqz                    Opcode = 0x50
+	OpI64Eq                     Opcode = 0x51
+	OpI64Ne                     Opcode = 0x52
+	OpI64LtS                    Opcode = 0x53
+	OpI64LtU                    Opcode = 0x54
+	OpI64GtS                    Opcode = 0x55
+	OpI64GtU                    Opcode = 0x56
+	OpI64LeS                    Opcode = 0x57
+	OpI64LeU                    Opcode = 0x58
+	OpI64GeS                    Opcode = 0x59
+	OpI64GeU                    Opcode = 0x5a
+	OpF32Eq                     Opcode = 0x5b
+	OpF32Ne                     Opcode = 0x5c
+	OpF32Lt                     Opcode = 0x5d
+	OpF32Gt                     Opcode = 0x5e
+	OpF32Le                     Opcode = 0x5f
+	OpF32Ge                     Opcode = 0x60
+	OpF64Eq                     Opcode = 0x61
+	OpF64Ne                     Opcode = 0x62
+	OpF64Lt                     Opcode = 0x63
+	OpF64Gt                     Opcode = 0x64
+	OpF64Le                     Opcode = 0x65
+	OpF64Ge                     Opcode = 0x66
+	OpI32Clz                    Opcode = 0x67
+	OpI32Ctz                    Opcode = 0x68
+	OpI32Popcnt                 Opcode = 0x69
+	OpI32Add                    Opcode = 0x6a
+	OpI32Sub                    Opcode = 0x6b
+	OpI32Mul                    Opcode = 0x6c
+	OpI32DivS                   Opcode = 0x6d
+	OpI32DivU                   Opcode = 0x6e
+	OpI32RemS                   Opcode = 0x6f
+	OpI32RemU                   Opcode = 0x70
+	OpI32And                    Opcode = 0x71
+	OpI32Or                     Opcode = 0x72
+	OpI32Xor                    Opcode = 0x73
+	OpI32Shl                    Opcode = 0x74
+	OpI32ShrS                   Opcode = 0x75
+	OpI32ShrU                   Opcode = 0x76
+	OpI32Rotl                   Opcode = 0x77
+	OpI32Rotr                   Opcode = 0x78
+	OpI64Clz                    Opcode = 0x79
+	OpI64Ctz                    Opcode = 0x7a
+	OpI64Popcnt                 Opcode = 0x7b
+	OpI64Add                    Opcode = 0x7c
+	OpI64Sub                    Opcode = 0x7d
+	OpI64Mul                    Opcode = 0x7e
+	OpI64DivS                   Opcode = 0x7f
+	OpI64DivU                   Opcode = 0x80
+	OpI64RemS                   Opcode = 0x81
+	OpI64RemU                   Opcode = 0x82
+	OpI64And                    Opcode = 0x83
+	OpI64Or                     Opcode = 0x84
+	OpI64Xor                    Opcode = 0x85
+	OpI64Shl                    Opcode = 0x86
+	OpI64ShrS                   Opcode = 0x87
+	OpI64ShrU                   Opcode = 0x88
+	OpI64Rotl                   Opcode = 0x89
+	OpI64Rotr                   Opcode = 0x8a
+	OpF32Abs                    Opcode = 0x8b
+	OpF32Neg                    Opcode = 0x8c
+	OpF32Ceil                   Opcode = 0x8d
+	OpF32Floor                  Opcode = 0x8e
+	OpF32Trunc                  Opcode = 0x8f
+	OpF32Nearest                Opcode = 0x90
+	OpF32Sqrt                   Opcode = 0x91
+	OpF32Add                    Opcode = 0x92
+	OpF32Sub                    Opcode = 0x93
+	OpF32Mul                    Opcode = 0x94
+	OpF32Div                    Opcode = 0x95
+	OpF32Min                    Opcode = 0x96
+	OpF32Max                    Opcode = 0x97
+	OpF32Copysign               Opcode = 0x98
+	OpF64Abs                    Opcode = 0x99
+	OpF64Neg                    Opcode = 0x9a
+	OpF64Ceil                   Opcode = 0x9b
+	OpF64Floor                  Opcode = 0x9c
+	OpF64Trunc                  Opcode = 0x9d
+	OpF64Nearest                Opcode = 0x9e
+	OpF64Sqrt                   Opcode = 0x9f
+	OpF64Add                    Opcode = 0xa0
+	OpF64Sub                    Opcode = 0xa1
+	OpF64Mul                    Opcode = 0xa2
+	OpF64Div                    Opcode = 0xa3
+	OpF64Min                    Opcode = 0xa4
+	OpF64Max                    Opcode = 0xa5
+	OpF64Copysign               Opcode = 0xa6
+	OpI32WrapI64                Opcode = 0xa7
+	OpI32TruncF32S              Opcode = 0xa8
+	OpI32TruncF32U              Opcode = 0xa9
+	OpI32TruncF64S              Opcode = 0xaa
+	OpI32TruncF64U              Opcode = 0xab
+	OpI64ExtendI32S             Opcode = 0xac
+	OpI64ExtendI32U             Opcode = 0xad
+	OpI64TruncF32S              Opcode = 0xae
+	OpI64TruncF32U              Opcode = 0xaf
+	OpI64TruncF64S              Opcode = 0xb0
+	OpI64TruncF64U              Opcode = 0xb1
+	OpF32ConvertI32S            Opcode = 0xb2
+	OpF32ConvertI32U            Opcode = 0xb3
+	OpF32ConvertI64S            Opcode = 0xb4
+	OpF32ConvertI64U            Opcode = 0xb5
+	OpF32DemoteF64              Opcode = 0xb6
+	OpF64ConvertI32S            Opcode = 0xb7
+	OpF64ConvertI32U            Opcode = 0xb8
+	OpF64ConvertI64S            Opcode = 0xb9
+	OpF64ConvertI64U            Opcode = 0xba
+	OpF64PromoteF32             Opcode = 0xbb
+	OpI32ReinterpretF32         Opcode = 0xbc
+	OpI64ReinterpretF64         Opcode = 0xbd
+	OpF32ReinterpretI32         Opcode = 0xbe
+	OpF64ReinterpretI64         Opcode = 0xbf
+	OpI32Extend8S               Opcode = 0xc0
+	OpI32Extend16S              Opcode = 0xc1
+	OpI64Extend8S               Opcode = 0xc2
+	OpI64Extend16S              Opcode = 0xc3
+	OpI64Extend32S              Opcode = 0xc4
+	OpRefNull                   Opcode = 0xd0
+	OpRefIsNull                 Opcode = 0xd1
+	OpRefFunc                   Opcode = 0xd2
+	OpI32TruncSatF32S           Opcode = 0xfc0000
+	OpI32TruncSatF32U           Opcode = 0xfc0001
+	OpI32TruncSatF64S           Opcode = 0xfc0002
+	OpI32TruncSatF64U           Opcode = 0xfc0003
+	OpI64TruncSatF32S           Opcode = 0xfc0004
+	OpI64TruncSatF32U           Opcode = 0xfc0005
+	OpI64TruncSatF64S           Opcode = 0xfc0006
+	OpI64TruncSatF64U           Opcode = 0xfc0007
+	OpMemoryInit                Opcode = 0xfc0008
+	OpDataDrop                  Opcode = 0xfc0009
+	OpMemoryCopy                Opcode = 0xfc000a
+	OpMemoryFill                Opcode = 0xfc000b
+	OpTableInit                 Opcode = 0xfc000c
+	OpElemDrop                  Opcode = 0xfc000d
+	OpTableCopy                 Opcode = 0xfc000e
+	OpTableGrow                 Opcode = 0xfc000f
+	OpTableSize                 Opcode = 0xfc0010
+	OpTableFill                 Opcode = 0xfc0011
+	OpV128Load                  Opcode = 0xfd0000
+	OpV128Load8x8S              Opcode = 0xfd0001
+	OpV128Load8x8U              Opcode = 0xfd0002
+	OpV128Load16x4S             Opcode = 0xfd0003
+	OpV128Load16x4U             Opcode = 0xfd0004
+	OpV128Load32x2S             Opcode = 0xfd0005
+	OpV128Load32x2U             Opcode = 0xfd0006
+	OpV128Load8Splat            Opcode = 0xfd0007
+	OpV128Load16Splat           Opcode = 0xfd0008
+	OpV128Load32Splat           Opcode = 0xfd0009
+	OpV128Load64Splat           Opcode = 0xfd000a
+	OpV128Store                 Opcode = 0xfd000b
+	OpV128Const                 Opcode = 0xfd000c
+	OpI8x16Shuffle              Opcode = 0xfd000d
+	OpI8x16Swizzle              Opcode = 0xfd000e
+	OpI8x16Splat                Opcode = 0xfd000f
+	OpI16x8Splat                Opcode = 0xfd0010
+	OpI32x4Splat                Opcode = 0xfd0011
+	OpI64x2Splat                Opcode = 0xfd0012
+	OpF32x4Splat                Opcode = 0xfd0013
+	OpF64x2Splat                Opcode = 0xfd0014
+	OpI8x16ExtractLaneS         Opcode = 0xfd0015
+	OpI8x16ExtractLaneU         Opcode = 0xfd0016
+	OpI8x16ReplaceLane          Opcode = 0xfd0017
+	OpI16x8ExtractLaneS         Opcode = 0xfd0018
+	OpI16x8ExtractLaneU         Opcode = 0xfd0019
+	OpI16x8ReplaceLane          Opcode = 0xfd001a
+	OpI32x4ExtractLane          Opcode = 0xfd001b
+	OpI32x4ReplaceLane          Opcode = 0xfd001c
+	OpI64x2ExtractLane          Opcode = 0xfd001d
+	OpI64x2ReplaceLane          Opcode = 0xfd001e
+	OpF32x4ExtractLane          Opcode = 0xfd001f
+	OpF32x4ReplaceLane          Opcode = 0xfd0020
+	OpF64x2ExtractLane          Opcode = 0xfd0021
+	OpF64x2ReplaceLane          Opcode = 0xfd0022
+	OpI8x16Eq                   Opcode = 0xfd0023
+	OpI8x16Ne                   Opcode = 0xfd0024
+	OpI8x16LtS                  Opcode = 0xfd0025
+	OpI8x16LtU                  Opcode = 0xfd0026
+	OpI8x16GtS                  Opcode = 0xfd0027
+	OpI8x16GtU                  Opcode = 0xfd0028
+	OpI8x16LeS                  Opcode = 0xfd0029
+	OpI8x16LeU                  Opcode = 0xfd002a
+	OpI8x16GeS                  Opcode = 0xfd002b
+	OpI8x16GeU                  Opcode = 0xfd002c
+	OpI16x8Eq                   Opcode = 0xfd002d
+	OpI16x8Ne                   Opcode = 0xfd002e
+	OpI16x8LtS                  Opcode = 0xfd002f
+	OpI16x8LtU                  Opcode = 0xfd0030
+	OpI16x8GtS                  Opcode = 0xfd0031
+	OpI16x8GtU                  Opcode = 0xfd0032
+	OpI16x8LeS                  Opcode = 0xfd0033
+	OpI16x8LeU                  Opcode = 0xfd0034
+	OpI16x8GeS                  Opcode = 0xfd0035
+	OpI16x8GeU                  Opcode = 0xfd0036
+	OpI32x4Eq                   Opcode = 0xfd0037
+	OpI32x4Ne                   Opcode = 0xfd0038
+	OpI32x4LtS                  Opcode = 0xfd0039
+	OpI32x4LtU                  Opcode = 0xfd003a
+	OpI32x4GtS                  Opcode = 0xfd003b
+	OpI32x4GtU                  Opcode = 0xfd003c
+	OpI32x4LeS                  Opcode = 0xfd003d
+	OpI32x4LeU                  Opcode = 0xfd003e
+	OpI32x4GeS                  Opcode = 0xfd003f
+	OpI32x4GeU                  Opcode = 0xfd0040
+	OpF32x4Eq                   Opcode = 0xfd0041
+	OpF32x4Ne                   Opcode = 0xfd0042
+	OpF32x4Lt                   Opcode = 0xfd0043
+	OpF32x4Gt                   Opcode = 0xfd0044
+	OpF32x4Le                   Opcode = 0xfd0045
+	OpF32x4Ge                   Opcode = 0xfd0046
+	OpF64x2Eq                   Opcode = 0xfd0047
+	OpF64x2Ne                   Opcode = 0xfd0048
+	OpF64x2Lt                   Opcode = 0xfd0049
+	OpF64x2Gt                   Opcode = 0xfd004a
+	OpF64x2Le                   Opcode = 0xfd004b
+	OpF64x2Ge                   Opcode = 0xfd004c
+	OpV128Not                   Opcode = 0xfd004d
+	OpV128And                   Opcode = 0xfd004e
+	OpV128Andnot                Opcode = 0xfd004f
+	OpV128Or                    Opcode = 0xfd0050
+	OpV128Xor                   Opcode = 0xfd0051
+	OpV128Bitselect             Opcode = 0xfd0052
+	OpV128AnyTrue               Opcode = 0xfd0053
+	OpV128Load8Lane             Opcode = 0xfd0054
+	OpV128Load16Lane            Opcode = 0xfd0055
+	OpV128Load32Lane            Opcode = 0xfd0056
+	OpV128Load64Lane            Opcode = 0xfd0057
+	OpV128Store8Lane            Opcode = 0xfd0058
+	OpV128Store16Lane           Opcode = 0xfd0059
+	OpV128Store32Lane           Opcode = 0xfd005a
+	OpV128Store64Lane           Opcode = 0xfd005b
+	OpV128Load32Zero            Opcode = 0xfd005c
+	OpV128Load64Zero            Opcode = 0xfd005d
+	OpF32x4DemoteF64x2Zero      Opcode = 0xfd005e
+	OpF64x2PromoteLowF32x4      Opcode = 0xfd005f
+	OpI8x16Abs                  Opcode = 0xfd0060
+	OpI8x16Neg                  Opcode = 0xfd0061
+	OpI8x16Popcnt               Opcode = 0xfd0062
+	OpI8x16AllTrue              Opcode = 0xfd0063
+	OpI8x16Bitmask              Opcode = 0xfd0064
+	OpI8x16NarrowI16x8S         Opcode = 0xfd0065
+	OpI8x16NarrowI16x8U         Opcode = 0xfd0066
+	OpF32x4Ceil                 Opcode = 0xfd0067
+	OpF32x4Floor                Opcode = 0xfd0068
+	OpF32x4Trunc                Opcode = 0xfd0069
+	OpF32x4Nearest              Opcode = 0xfd006a
+	OpI8x16Shl                  Opcode = 0xfd006b
+	OpI8x16ShrS                 Opcode = 0xfd006c
+	OpI8x16ShrU                 Opcode = 0xfd006d
+	OpI8x16Add                  Opcode = 0xfd006e
+	OpI8x16AddSatS              Opcode = 0xfd006f
+	OpI8x16AddSatU              Opcode = 0xfd0070
+	OpI8x16Sub                  Opcode = 0xfd0071
+	OpI8x16SubSatS              Opcode = 0xfd0072
+	OpI8x16SubSatU              Opcode = 0xfd0073
+	OpF64x2Ceil                 Opcode = 0xfd0074
+	OpF64x2Floor                Opcode = 0xfd0075
+	OpI8x16MinS                 Opcode = 0xfd0076
+	OpI8x16MinU                 Opcode = 0xfd0077
+	OpI8x16MaxS                 Opcode = 0xfd0078
+	OpI8x16MaxU                 Opcode = 0xfd0079
+	OpF64x2Trunc                Opcode = 0xfd007a
+	OpI8x16AvgrU                Opcode = 0xfd007b
+	OpI16x8ExtaddPairwiseI8x16S Opcode = 0xfd007c
+	OpI16x8ExtaddPairwiseI8x16U Opcode = 0xfd007d
+	OpI32x4ExtaddPairwiseI16x8S Opcode = 0xfd007e
+	OpI32x4ExtaddPairwiseI16x8U Opcode = 0xfd007f
+	OpI16x8Abs                  Opcode = 0xfd0080
+	OpI16x8Neg                  Opcode = 0xfd0081
+	OpI16x8Q15mulrSatS          Opcode = 0xfd0082
+	OpI16x8AllTrue              Opcode = 0xfd0083
+	OpI16x8Bitmask              Opcode = 0xfd0084
+	OpI16x8NarrowI32x4S         Opcode = 0xfd0085
+	OpI16x8NarrowI32x4U         Opcode = 0xfd0086
+	OpI16x8ExtendLowI8x16S      Opcode = 0xfd0087
+	OpI16x8ExtendHighI8x16S     Opcode = 0xfd0088
+	OpI16x8ExtendLowI8x16U      Opcode = 0xfd0089
+	OpI16x8ExtendHighI8x16U     Opcode = 0xfd008a
+	OpI16x8Shl                  Opcode = 0xfd008b
+	OpI16x8ShrS                 Opcode = 0xfd008c
+	OpI16x8ShrU                 Opcode = 0xfd008d
+	OpI16x8Add                  Opcode = 0xfd008e
+	OpI16x8AddSatS              Opcode = 0xfd008f
+	OpI16x8AddSatU              Opcode = 0xfd0090
+	OpI16x8Sub                  Opcode = 0xfd0091
+	OpI16x8SubSatS              Opcode = 0xfd0092
+	OpI16x8SubSatU              Opcode = 0xfd0093
+	OpF64x2Nearest              Opcode = 0xfd0094
+	OpI16x8Mul                  Opcode = 0xfd0095
+	OpI16x8MinS                 Opcode = 0xfd0096
+	OpI16x8MinU                 Opcode = 0xfd0097
+	OpI16x8MaxS                 Opcode = 0xfd0098
+	OpI16x8MaxU                 Opcode = 0xfd0099
+	OpI16x8AvgrU                Opcode = 0xfd009b
+	OpI16x8ExtmulLowI8x16S      Opcode = 0xfd009c
+	OpI16x8ExtmulHighI8x16S     Opcode = 0xfd009d
+	OpI16x8ExtmulLowI8x16U      Opcode = 0xfd009e
+	OpI16x8ExtmulHighI8x16U     Opcode = 0xfd009f
+	OpI32x4Abs                  Opcode = 0xfd00a0
+	OpI32x4Neg                  Opcode = 0xfd00a1
+	OpI32x4AllTrue              Opcode = 0xfd00a3
+	OpI32x4Bitmask              Opcode = 0xfd00a4
+	OpI32x4ExtendLowI16x8S      Opcode = 0xfd00a7
+	OpI32x4ExtendHighI16x8S     Opcode = 0xfd00a8
+	OpI32x4ExtendLowI16x8U      Opcode = 0xfd00a9
+	OpI32x4ExtendHighI16x8U     Opcode = 0xfd00aa
+	OpI32x4Shl                  Opcode = 0xfd00ab
+	OpI32x4ShrS                 Opcode = 0xfd00ac
+	OpI32x4ShrU                 Opcode = 0xfd00ad
+	OpI32x4Add                  Opcode = 0xfd00ae
+	OpI32x4Sub                  Opcode = 0xfd00b1
+	OpI32x4Mul                  Opcode = 0xfd00b5
+	OpI32x4MinS                 Opcode = 0xfd00b6
+	OpI32x4MinU                 Opcode = 0xfd00b7
+	OpI32x4MaxS                 Opcode = 0xfd00b8
+	OpI32x4MaxU                 Opcode = 0xfd00b9
+	OpI32x4DotI16x8S            Opcode = 0xfd00ba
+	OpI32x4ExtmulLowI16x8S      Opcode = 0xfd00bc
+	OpI32x4ExtmulHighI16x8S     Opcode = 0xfd00bd
+	OpI32x4ExtmulLowI16x8U      Opcode = 0xfd00be
+	OpI32x4ExtmulHighI16x8U     Opcode = 0xfd00bf
+	OpI64x2Abs                  Opcode = 0xfd00c0
+	OpI64x2Neg                  Opcode = 0xfd00c1
+	OpI64x2AllTrue              Opcode = 0xfd00c3
+	OpI64x2Bitmask              Opcode = 0xfd00c4
+	OpI64x2ExtendLowI32x4S      Opcode = 0xfd00c7
+	OpI64x2ExtendHighI32x4S     Opcode = 0xfd00c8
+	OpI64x2ExtendLowI32x4U      Opcode = 0xfd00c9
+	OpI64x2ExtendHighI32x4U     Opcode = 0xfd00ca
+	OpI64x2Shl                  Opcode = 0xfd00cb
+	OpI64x2ShrS                 Opcode = 0xfd00cc
+	OpI64x2ShrU                 Opcode = 0xfd00cd
+	OpI64x2Add                  Opcode = 0xfd00ce
+	OpI64x2Sub                  Opcode = 0xfd00d1
+	OpI64x2Mul                  Opcode = 0xfd00d5
+	OpI64x2Eq                   Opcode = 0xfd00d6
+	OpI64x2Ne                   Opcode = 0xfd00d7
+	OpI64x2LtS                  Opcode = 0xfd00d8
+	OpI64x2GtS                  Opcode = 0xfd00d9
+	OpI64x2LeS                  Opcode = 0xfd00da
+	OpI64x2GeS                  Opcode = 0xfd00db
+	OpI64x2ExtmulLowI32x4S      Opcode = 0xfd00dc
+	OpI64x2ExtmulHighI32x4S     Opcode = 0xfd00dd
+	OpI64x2ExtmulLowI32x4U      Opcode = 0xfd00de
+	OpI64x2ExtmulHighI32x4U     Opcode = 0xfd00df
+	OpF32x4Abs                  Opcode = 0xfd00e0
+	OpF32x4Neg                  Opcode = 0xfd00e1
+	OpF32x4Sqrt                 Opcode = 0xfd00e3
+	OpF32x4Add                  Opcode = 0xfd00e4
+	OpF32x4Sub                  Opcode = 0xfd00e5
+	OpF32x4Mul                  Opcode = 0xfd00e6
+	OpF32x4Div                  Opcode = 0xfd00e7
+	OpF32x4Min                  Opcode = 0xfd00e8
+	OpF32x4Max                  Opcode = 0xfd00e9
+	OpF32x4Pmin                 Opcode = 0xfd00ea
+	OpF32x4Pmax                 Opcode = 0xfd00eb
+	OpF64x2Abs                  Opcode = 0xfd00ec
+	OpF64x2Neg                  Opcode = 0xfd00ed
+	OpF64x2Sqrt                 Opcode = 0xfd00ef
+	OpF64x2Add                  Opcode = 0xfd00f0
+	OpF64x2Sub                  Opcode = 0xfd00f1
+	OpF64x2Mul                  Opcode = 0xfd00f2
+	OpF64x2Div                  Opcode = 0xfd00f3
+	OpF64x2Min                  Opcode = 0xfd00f4
+	OpF64x2Max                  Opcode = 0xfd00f5
+	OpF64x2Pmin                 Opcode = 0xfd00f6
+	OpF64x2Pmax                 Opcode = 0xfd00f7
+	OpI32x4TruncSatF32x4S       Opcode = 0xfd00f8
+	OpI32x4TruncSatF32x4U       Opcode = 0xfd00f9
+	OpF32x4ConvertI32x4S        Opcode = 0xfd00fa
+	OpF32x4ConvertI32x4U        Opcode = 0xfd00fb
+	OpI32x4TruncSatF64x2SZero   Opcode = 0xfd00fc
+	OpI32x4TruncSatF64x2UZero   Opcode = 0xfd00fd
+	OpF64x2ConvertLowI32x4S     Opcode = 0xfd00fe
+	OpF64x2ConvertLowI32x4U     Opcode = 0xfd00ff
+	OpMemoryAtomicNotify        Opcode = 0xfe0000
+	OpMemoryAtomicWait32        Opcode = 0xfe0001
+	OpMemoryAtomicWait64        Opcode = 0xfe0002
+	OpAtomicFence               Opcode = 0xfe0003
+	OpI32AtomicLoad             Opcode = 0xfe0010
+	OpI64AtomicLoad             Opcode = 0xfe0011
+	OpI32AtomicLoad8U           Opcode = 0xfe0012
+	OpI32AtomicLoad16U          Opcode = 0xfe0013
+	OpI64AtomicLoad8U           Opcode = 0xfe0014
+	OpI64AtomicLoad16U          Opcode = 0xfe0015
+	OpI64AtomicLoad32U          Opcode = 0xfe0016
+	OpI32AtomicStore            Opcode = 0xfe0017
+	OpI64AtomicStore            Opcode = 0xfe0018
+	OpI32AtomicStore8           Opcode = 0xfe0019
+	OpI32AtomicStore16          Opcode = 0xfe001a
+	OpI64AtomicStore8           Opcode = 0xfe001b
+	OpI64AtomicStore16          Opcode = 0xfe001c
+	OpI64AtomicStore32          Opcode = 0xfe001d
+	OpI32AtomicRmwAdd           Opcode = 0xfe001e
+	OpI64AtomicRmwAdd           Opcode = 0xfe001f
+	OpI32AtomicRmw8AddU         Opcode = 0xfe0020
+	OpI32AtomicRmw16AddU        Opcode = 0xfe0021
+	OpI64AtomicRmw8AddU         Opcode = 0xfe0022
+	OpI64AtomicRmw16AddU        Opcode = 0xfe0023
+	OpI64AtomicRmw32AddU        Opcode = 0xfe0024
+	OpI32AtomicRmwSub           Opcode = 0xfe0025
+	OpI64AtomicRmwSub           Opcode = 0xfe0026
+	OpI32AtomicRmw8SubU         Opcode = 0xfe0027
+	OpI32AtomicRmw16SubU        Opcode = 0xfe0028
+	OpI64AtomicRmw8SubU         Opcode = 0xfe0029
+	OpI64AtomicRmw16SubU        Opcode = 0xfe002a
+	OpI64AtomicRmw32SubU        Opcode = 0xfe002b
+	OpI32AtomicRmwAnd           Opcode = 0xfe002c
+	OpI64AtomicRmwAnd           Opcode = 0xfe002d
+	OpI32AtomicRmw8AndU         Opcode = 0xfe002e
+	OpI32AtomicRmw16AndU        Opcode = 0xfe002f
+	OpI64AtomicRmw8AndU         Opcode = 0xfe0030
+	OpI64AtomicRmw16AndU        Opcode = 0xfe0031
+	OpI64AtomicRmw32AndU        Opcode = 0xfe0032
+	OpI32AtomicRmwOr            Opcode = 0xfe0033
+	OpI64AtomicRmwOr            Opcode = 0xfe0034
+	OpI32AtomicRmw8OrU          Opcode = 0xfe0035
+	OpI32AtomicRmw16OrU         Opcode = 0xfe0036
+	OpI64AtomicRmw8OrU          Opcode = 0xfe0037
+	OpI64AtomicRmw16OrU         Opcode = 0xfe0038
+	OpI64AtomicRmw32OrU         Opcode = 0xfe0039
+	OpI32AtomicRmwXor           Opcode = 0xfe003a
+	OpI64AtomicRmwXor           Opcode = 0xfe003b
+	OpI32AtomicRmw8XorU         Opcode = 0xfe003c
+	OpI32AtomicRmw16XorU        Opcode = 0xfe003d
+	OpI64AtomicRmw8XorU         Opcode = 0xfe003e
+	OpI64AtomicRmw16XorU        Opcode = 0xfe003f
+	OpI64AtomicRmw32XorU        Opcode = 0xfe0040
+	OpI32AtomicRmwXchg          Opcode = 0xfe0041
+	OpI64AtomicRmwXchg          Opcode = 0xfe0042
+	OpI32AtomicRmw8XchgU        Opcode = 0xfe0043
+	OpI32AtomicRmw16XchgU       Opcode = 0xfe0044
+	OpI64AtomicRmw8XchgU        Opcode = 0xfe0045
+	OpI64AtomicRmw16XchgU       Opcode = 0xfe0046
+	OpI64AtomicRmw32XchgU       Opcode = 0xfe0047
+	OpI32AtomicRmwCmpxchg       Opcode = 0xfe0048
+	OpI64AtomicRmwCmpxchg       Opcode = 0xfe0049
+	OpI32AtomicRmw8CmpxchgU     Opcode = 0xfe004a
+	OpI32AtomicRmw16CmpxchgU    Opcode = 0xfe004b
+	OpI64AtomicRmw8CmpxchgU     Opcode = 0xfe004c
+	OpI64AtomicRmw16CmpxchgU    Opcode = 0xfe004d
+	OpI64AtomicRmw32CmpxchgU    Opcode = 0xfe004e
+)
+
+// opcodeInfos is sorted by Opcode.
+var opcodeInfos = [...]OpcodeInfo{
+	{Opcode: OpUnreachable, Name: "unreachable", Dynamic: true, goName: "wasm.OpUnreachable"},
+	{Opcode: OpNop, Name: "nop", goName: "wasm.OpNop"},
+	{Opcode: OpBlock, Name: "block", Immediates: []Immediate{BlockTypeImmediate}, Dynamic: true, goName: "wasm.OpBlock"},
+	{Opcode: OpLoop, Name: "loop", Immediates: []Immediate{BlockTypeImmediate}, Dynamic: true, goName: "wasm.OpLoop"},
+	{Opcode: OpIf, Name: "if", Immediates: []Immediate{BlockTypeImmediate}, Dynamic: true, goName: "wasm.OpIf"},
+	{Opcode: OpElse, Name: "else", Dynamic: true, goName: "wasm.OpElse"},
+	{Opcode: OpEnd, Name: "end", Dynamic: true, goName: "wasm.OpEnd"},
+	{Opcode: OpBr, Name: "br", Immediates: []Immediate{LabelImmediate}, Dynamic: true, goName: "wasm.OpBr"},
+	{Opcode: OpBrIf, Name: "br_if", Immediates: []Immediate{LabelImmediate}, Dynamic: true, goName: "wasm.OpBrIf"},
+	{Opcode: OpBrTable, Name: "br_table", Immediates: []Immediate{LabelVecImmediate}, Dynamic: true, goName: "wasm.OpBrTable"},
+	{Opcode: OpReturn, Name: "return", Dynamic: true, goName: "wasm.OpReturn"},
+	{Opcode: OpCall, Name: "call", Immediates: []Immediate{FuncImmediate}, Dynamic: true, goName: "wasm.OpCall"},
+	{Opcode: OpCallIndirect, Name: "call_indirect", Immediates: []Immediate{TypeImmediate, TableImmediate}, Dynamic: true, goName: "wasm.OpCallIndirect"},
+	{Opcode: OpDrop, Name: "drop", Dynamic: true, goName: "wasm.OpDrop"},
+	{Opcode: OpSelect, Name: "select", Dynamic: true, goName: "wasm.OpSelect"},
+	{Opcode: OpSelectT, Name: "select", Immediates: []Immediate{ValTypeVecImmediate}, Dynamic: true, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpSelectT"},
+	{Opcode: OpLocalGet, Name: "local.get", Immediates: []Immediate{LocalImmediate}, Dynamic: true, goName: "wasm.OpLocalGet"},
+	{Opcode: OpLocalSet, Name: "local.set", Immediates: []Immediate{LocalImmediate}, Dynamic: true, goName: "wasm.OpLocalSet"},
+	{Opcode: OpLocalTee, Name: "local.tee", Immediates: []Immediate{LocalImmediate}, Dynamic: true, goName: "wasm.OpLocalTee"},
+	{Opcode: OpGlobalGet, Name: "global.get", Immediates: []Immediate{GlobalImmediate}, Dynamic: true, goName: "wasm.OpGlobalGet"},
+	{Opcode: OpGlobalSet, Name: "global.set", Immediates: []Immediate{GlobalImmediate}, Dynamic: true, goName: "wasm.OpGlobalSet"},
+	{Opcode: OpTableGet, Name: "table.get", Immediates: []Immediate{TableImmediate}, Dynamic: true, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpTableGet"},
+	{Opcode: OpTableSet, Name: "table.set", Immediates: []Immediate{TableImmediate}, Dynamic: true, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpTableSet"},
+	{Opcode: OpI32Load, Name: "i32.load", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, NaturalAlign: 2, goName: "wasm.OpI32Load"},
+	{Opcode: OpI64Load, Name: "i64.load", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 3, goName: "wasm.OpI64Load"},
+	{Opcode: OpF32Load, Name: "f32.load", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{F32}, NaturalAlign: 2, goName: "wasm.OpF32Load"},
+	{Opcode: OpF64Load, Name: "f64.load", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{F64}, NaturalAlign: 3, goName: "wasm.OpF64Load"},
+	{Opcode: OpI32Load8S, Name: "i32.load8_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, goName: "wasm.OpI32Load8S"},
+	{Opcode: OpI32Load8U, Name: "i32.load8_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, goName: "wasm.OpI32Load8U"},
+	{Opcode: OpI32Load16S, Name: "i32.load16_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, NaturalAlign: 1, goName: "wasm.OpI32Load16S"},
+	{Opcode: OpI32Load16U, Name: "i32.load16_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, NaturalAlign: 1, goName: "wasm.OpI32Load16U"},
+	{Opcode: OpI64Load8S, Name: "i64.load8_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, goName: "wasm.OpI64Load8S"},
+	{Opcode: OpI64Load8U, Name: "i64.load8_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, goName: "wasm.OpI64Load8U"},
+	{Opcode: OpI64Load16S, Name: "i64.load16_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 1, goName: "wasm.OpI64Load16S"},
+	{Opcode: OpI64Load16U, Name: "i64.load16_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 1, goName: "wasm.OpI64Load16U"},
+	{Opcode: OpI64Load32S, Name: "i64.load32_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 2, goName: "wasm.OpI64Load32S"},
+	{Opcode: OpI64Load32U, Name: "i64.load32_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 2, goName: "wasm.OpI64Load32U"},
+	{Opcode: OpI32Store, Name: "i32.store", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, NaturalAlign: 2, goName: "wasm.OpI32Store"},
+	{Opcode: OpI64Store, Name: "i64.store", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, NaturalAlign: 3, goName: "wasm.OpI64Store"},
+	{Opcode: OpF32Store, Name: "f32.store", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, F32}, NaturalAlign: 2, goName: "wasm.OpF32Store"},
+	{Opcode: OpF64Store, Name: "f64.store", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, F64}, NaturalAlign: 3, goName: "wasm.OpF64Store"},
+	{Opcode: OpI32Store8, Name: "i32.store8", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, goName: "wasm.OpI32Store8"},
+	{Opcode: OpI32Store16, Name: "i32.store16", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, NaturalAlign: 1, goName: "wasm.OpI32Store16"},
+	{Opcode: OpI64Store8, Name: "i64.store8", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, goName: "wasm.OpI64Store8"},
+	{Opcode: OpI64Store16, Name: "i64.store16", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, NaturalAlign: 1, goName: "wasm.OpI64Store16"},
+	{Opcode: OpI64Store32, Name: "i64.store32", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, NaturalAlign: 2, goName: "wasm.OpI64Store32"},
+	{Opcode: OpMemorySize, Name: "memory.size", Immediates: []Immediate{ZeroByteImmediate}, Results: []ValType{I32}, goName: "wasm.OpMemorySize"},
+	{Opcode: OpMemoryGrow, Name: "memory.grow", Immediates: []Immediate{ZeroByteImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, goName: "wasm.OpMemoryGrow"},
+	{Opcode: OpI32Const, Name: "i32.const", Immediates: []Immediate{I32Immediate}, Results: []ValType{I32}, goName: "wasm.OpI32Const"},
+	{Opcode: OpI64Const, Name: "i64.const", Immediates: []Immediate{I64Immediate}, Results: []ValType{I64}, goName: "wasm.OpI64Const"},
+	{Opcode: OpF32Const, Name: "f32.const", Immediates: []Immediate{F32Immediate}, Results: []ValType{F32}, goName: "wasm.OpF32Const"},
+	{Opcode: OpF64Const, Name: "f64.const", Immediates: []Immediate{F64Immediate}, Results: []ValType{F64}, goName: "wasm.OpF64Const"},
+	{Opcode: OpI32Eqz, Name: "i32.eqz", Params: []ValType{I32}, Results: []ValType{I32}, goName: "wasm.OpI32Eqz"},
+	{Opcode: OpI32Eq, Name: "i32.eq", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Eq"},
+	{Opcode: OpI32Ne, Name: "i32.ne", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Ne"},
+	{Opcode: OpI32LtS, Name: "i32.lt_s", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32LtS"},
+	{Opcode: OpI32LtU, Name: "i32.lt_u", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32LtU"},
+	{Opcode: OpI32GtS, Name: "i32.gt_s", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32GtS"},
+	{Opcode: OpI32GtU, Name: "i32.gt_u", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32GtU"},
+	{Opcode: OpI32LeS, Name: "i32.le_s", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32LeS"},
+	{Opcode: OpI32LeU, Name: "i32.le_u", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32LeU"},
+	{Opcode: OpI32GeS, Name: "i32.ge_s", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32GeS"},
+	{Opcode: OpI32GeU, Name: "i32.ge_u", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32GeU"},
+	{Opcode: OpI64Eqz, Name: "i64.eqz", Params: []ValType{I64}, Results: []ValType{I32}, goName: "wasm.OpI64Eqz"},
+	{Opcode: OpI64Eq, Name: "i64.eq", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64Eq"},
+	{Opcode: OpI64Ne, Name: "i64.ne", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64Ne"},
+	{Opcode: OpI64LtS, Name: "i64.lt_s", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64LtS"},
+	{Opcode: OpI64LtU, Name: "i64.lt_u", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64LtU"},
+	{Opcode: OpI64GtS, Name: "i64.gt_s", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64GtS"},
+	{Opcode: OpI64GtU, Name: "i64.gt_u", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64GtU"},
+	{Opcode: OpI64LeS, Name: "i64.le_s", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64LeS"},
+	{Opcode: OpI64LeU, Name: "i64.le_u", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64LeU"},
+	{Opcode: OpI64GeS, Name: "i64.ge_s", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64GeS"},
+	{Opcode: OpI64GeU, Name: "i64.ge_u", Params: []ValType{I64, I64}, Results: []ValType{I32}, goName: "wasm.OpI64GeU"},
+	{Opcode: OpF32Eq, Name: "f32.eq", Params: []ValType{F32, F32}, Results: []ValType{I32}, goName: "wasm.OpF32Eq"},
+	{Opcode: OpF32Ne, Name: "f32.ne", Params: []ValType{F32, F32}, Results: []ValType{I32}, goName: "wasm.OpF32Ne"},
+	{Opcode: OpF32Lt, Name: "f32.lt", Params: []ValType{F32, F32}, Results: []ValType{I32}, goName: "wasm.OpF32Lt"},
+	{Opcode: OpF32Gt, Name: "f32.gt", Params: []ValType{F32, F32}, Results: []ValType{I32}, goName: "wasm.OpF32Gt"},
+	{Opcode: OpF32Le, Name: "f32.le", Params: []ValType{F32, F32}, Results: []ValType{I32}, goName: "wasm.OpF32Le"},
+	{Opcode: OpF32Ge, Name: "f32.ge", Params: []ValType{F32, F32}, Results: []ValType{I32}, goName: "wasm.OpF32Ge"},
+	{Opcode: OpF64Eq, Name: "f64.eq", Params: []ValType{F64, F64}, Results: []ValType{I32}, goName: "wasm.OpF64Eq"},
+	{Opcode: OpF64Ne, Name: "f64.ne", Params: []ValType{F64, F64}, Results: []ValType{I32}, goName: "wasm.OpF64Ne"},
+	{Opcode: OpF64Lt, Name: "f64.lt", Params: []ValType{F64, F64}, Results: []ValType{I32}, goName: "wasm.OpF64Lt"},
+	{Opcode: OpF64Gt, Name: "f64.gt", Params: []ValType{F64, F64}, Results: []ValType{I32}, goName: "wasm.OpF64Gt"},
+	{Opcode: OpF64Le, Name: "f64.le", Params: []ValType{F64, F64}, Results: []ValType{I32}, goName: "wasm.OpF64Le"},
+	{Opcode: OpF64Ge, Name: "f64.ge", Params: []ValType{F64, F64}, Results: []ValType{I32}, goName: "wasm.OpF64Ge"},
+	{Opcode: OpI32Clz, Name: "i32.clz", Params: []ValType{I32}, Results: []ValType{I32}, goName: "wasm.OpI32Clz"},
+	{Opcode: OpI32Ctz, Name: "i32.ctz", Params: []ValType{I32}, Results: []ValType{I32}, goName: "wasm.OpI32Ctz"},
+	{Opcode: OpI32Popcnt, Name: "i32.popcnt", Params: []ValType{I32}, Results: []ValType{I32}, goName: "wasm.OpI32Popcnt"},
+	{Opcode: OpI32Add, Name: "i32.add", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Add"},
+	{Opcode: OpI32Sub, Name: "i32.sub", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Sub"},
+	{Opcode: OpI32Mul, Name: "i32.mul", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Mul"},
+	{Opcode: OpI32DivS, Name: "i32.div_s", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32DivS"},
+	{Opcode: OpI32DivU, Name: "i32.div_u", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32DivU"},
+	{Opcode: OpI32RemS, Name: "i32.rem_s", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32RemS"},
+	{Opcode: OpI32RemU, Name: "i32.rem_u", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32RemU"},
+	{Opcode: OpI32And, Name: "i32.and", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32And"},
+	{Opcode: OpI32Or, Name: "i32.or", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Or"},
+	{Opcode: OpI32Xor, Name: "i32.xor", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Xor"},
+	{Opcode: OpI32Shl, Name: "i32.shl", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Shl"},
+	{Opcode: OpI32ShrS, Name: "i32.shr_s", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32ShrS"},
+	{Opcode: OpI32ShrU, Name: "i32.shr_u", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32ShrU"},
+	{Opcode: OpI32Rotl, Name: "i32.rotl", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Rotl"},
+	{Opcode: OpI32Rotr, Name: "i32.rotr", Params: []ValType{I32, I32}, Results: []ValType{I32}, goName: "wasm.OpI32Rotr"},
+	{Opcode: OpI64Clz, Name: "i64.clz", Params: []ValType{I64}, Results: []ValType{I64}, goName: "wasm.OpI64Clz"},
+	{Opcode: OpI64Ctz, Name: "i64.ctz", Params: []ValType{I64}, Results: []ValType{I64}, goName: "wasm.OpI64Ctz"},
+	{Opcode: OpI64Popcnt, Name: "i64.popcnt", Params: []ValType{I64}, Results: []ValType{I64}, goName: "wasm.OpI64Popcnt"},
+	{Opcode: OpI64Add, Name: "i64.add", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Add"},
+	{Opcode: OpI64Sub, Name: "i64.sub", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Sub"},
+	{Opcode: OpI64Mul, Name: "i64.mul", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Mul"},
+	{Opcode: OpI64DivS, Name: "i64.div_s", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64DivS"},
+	{Opcode: OpI64DivU, Name: "i64.div_u", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64DivU"},
+	{Opcode: OpI64RemS, Name: "i64.rem_s", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64RemS"},
+	{Opcode: OpI64RemU, Name: "i64.rem_u", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64RemU"},
+	{Opcode: OpI64And, Name: "i64.and", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64And"},
+	{Opcode: OpI64Or, Name: "i64.or", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Or"},
+	{Opcode: OpI64Xor, Name: "i64.xor", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Xor"},
+	{Opcode: OpI64Shl, Name: "i64.shl", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Shl"},
+	{Opcode: OpI64ShrS, Name: "i64.shr_s", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64ShrS"},
+	{Opcode: OpI64ShrU, Name: "i64.shr_u", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64ShrU"},
+	{Opcode: OpI64Rotl, Name: "i64.rotl", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Rotl"},
+	{Opcode: OpI64Rotr, Name: "i64.rotr", Params: []ValType{I64, I64}, Results: []ValType{I64}, goName: "wasm.OpI64Rotr"},
+	{Opcode: OpF32Abs, Name: "f32.abs", Params: []ValType{F32}, Results: []ValType{F32}, goName: "wasm.OpF32Abs"},
+	{Opcode: OpF32Neg, Name: "f32.neg", Params: []ValType{F32}, Results: []ValType{F32}, goName: "wasm.OpF32Neg"},
+	{Opcode: OpF32Ceil, Name: "f32.ceil", Params: []ValType{F32}, Results: []ValType{F32}, goName: "wasm.OpF32Ceil"},
+	{Opcode: OpF32Floor, Name: "f32.floor", Params: []ValType{F32}, Results: []ValType{F32}, goName: "wasm.OpF32Floor"},
+	{Opcode: OpF32Trunc, Name: "f32.trunc", Params: []ValType{F32}, Results: []ValType{F32}, goName: "wasm.OpF32Trunc"},
+	{Opcode: OpF32Nearest, Name: "f32.nearest", Params: []ValType{F32}, Results: []ValType{F32}, goName: "wasm.OpF32Nearest"},
+	{Opcode: OpF32Sqrt, Name: "f32.sqrt", Params: []ValType{F32}, Results: []ValType{F32}, goName: "wasm.OpF32Sqrt"},
+	{Opcode: OpF32Add, Name: "f32.add", Params: []ValType{F32, F32}, Results: []ValType{F32}, goName: "wasm.OpF32Add"},
+	{Opcode: OpF32Sub, Name: "f32.sub", Params: []ValType{F32, F32}, Results: []ValType{F32}, goName: "wasm.OpF32Sub"},
+	{Opcode: OpF32Mul, Name: "f32.mul", Params: []ValType{F32, F32}, Results: []ValType{F32}, goName: "wasm.OpF32Mul"},
+	{Opcode: OpF32Div, Name: "f32.div", Params: []ValType{F32, F32}, Results: []ValType{F32}, goName: "wasm.OpF32Div"},
+	{Opcode: OpF32Min, Name: "f32.min", Params: []ValType{F32, F32}, Results: []ValType{F32}, goName: "wasm.OpF32Min"},
+	{Opcode: OpF32Max, Name: "f32.max", Params: []ValType{F32, F32}, Results: []ValType{F32}, goName: "wasm.OpF32Max"},
+	{Opcode: OpF32Copysign, Name: "f32.copysign", Params: []ValType{F32, F32}, Results: []ValType{F32}, goName: "wasm.OpF32Copysign"},
+	{Opcode: OpF64Abs, Name: "f64.abs", Params: []ValType{F64}, Results: []ValType{F64}, goName: "wasm.OpF64Abs"},
+	{Opcode: OpF64Neg, Name: "f64.neg", Params: []ValType{F64}, Results: []ValType{F64}, goName: "wasm.OpF64Neg"},
+	{Opcode: OpF64Ceil, Name: "f64.ceil", Params: []ValType{F64}, Results: []ValType{F64}, goName: "wasm.OpF64Ceil"},
+	{Opcode: OpF64Floor, Name: "f64.floor", Params: []ValType{F64}, Results: []ValType{F64}, goName: "wasm.OpF64Floor"},
+	{Opcode: OpF64Trunc, Name: "f64.trunc", Params: []ValType{F64}, Results: []ValType{F64}, goName: "wasm.OpF64Trunc"},
+	{Opcode: OpF64Nearest, Name: "f64.nearest", Params: []ValType{F64}, Results: []ValType{F64}, goName: "wasm.OpF64Nearest"},
+	{Opcode: OpF64Sqrt, Name: "f64.sqrt", Params: []ValType{F64}, Results: []ValType{F64}, goName: "wasm.OpF64Sqrt"},
+	{Opcode: OpF64Add, Name: "f64.add", Params: []ValType{F64, F64}, Results: []ValType{F64}, goName: "wasm.OpF64Add"},
+	{Opcode: OpF64Sub, Name: "f64.sub", Params: []ValType{F64, F64}, Results: []ValType{F64}, goName: "wasm.OpF64Sub"},
+	{Opcode: OpF64Mul, Name: "f64.mul", Params: []ValType{F64, F64}, Results: []ValType{F64}, goName: "wasm.OpF64Mul"},
+	{Opcode: OpF64Div, Name: "f64.div", Params: []ValType{F64, F64}, Results: []ValType{F64}, goName: "wasm.OpF64Div"},
+	{Opcode: OpF64Min, Name: "f64.min", Params: []ValType{F64, F64}, Results: []ValType{F64}, goName: "wasm.OpF64Min"},
+	{Opcode: OpF64Max, Name: "f64.max", Params: []ValType{F64, F64}, Results: []ValType{F64}, goName: "wasm.OpF64Max"},
+	{Opcode: OpF64Copysign, Name: "f64.copysign", Params: []ValType{F64, F64}, Results: []ValType{F64}, goName: "wasm.OpF64Copysign"},
+	{Opcode: OpI32WrapI64, Name: "i32.wrap_i64", Params: []ValType{I64}, Results: []ValType{I32}, goName: "wasm.OpI32WrapI64"},
+	{Opcode: OpI32TruncF32S, Name: "i32.trunc_f32_s", Params: []ValType{F32}, Results: []ValType{I32}, goName: "wasm.OpI32TruncF32S"},
+	{Opcode: OpI32TruncF32U, Name: "i32.trunc_f32_u", Params: []ValType{F32}, Results: []ValType{I32}, goName: "wasm.OpI32TruncF32U"},
+	{Opcode: OpI32TruncF64S, Name: "i32.trunc_f64_s", Params: []ValType{F64}, Results: []ValType{I32}, goName: "wasm.OpI32TruncF64S"},
+	{Opcode: OpI32TruncF64U, Name: "i32.trunc_f64_u", Params: []ValType{F64}, Results: []ValType{I32}, goName: "wasm.OpI32TruncF64U"},
+	{Opcode: OpI64ExtendI32S, Name: "i64.extend_i32_s", Params: []ValType{I32}, Results: []ValType{I64}, goName: "wasm.OpI64ExtendI32S"},
+	{Opcode: OpI64ExtendI32U, Name: "i64.extend_i32_u", Params: []ValType{I32}, Results: []ValType{I64}, goName: "wasm.OpI64ExtendI32U"},
+	{Opcode: OpI64TruncF32S, Name: "i64.trunc_f32_s", Params: []ValType{F32}, Results: []ValType{I64}, goName: "wasm.OpI64TruncF32S"},
+	{Opcode: OpI64TruncF32U, Name: "i64.trunc_f32_u", Params: []ValType{F32}, Results: []ValType{I64}, goName: "wasm.OpI64TruncF32U"},
+	{Opcode: OpI64TruncF64S, Name: "i64.trunc_f64_s", Params: []ValType{F64}, Results: []ValType{I64}, goName: "wasm.OpI64TruncF64S"},
+	{Opcode: OpI64TruncF64U, Name: "i64.trunc_f64_u", Params: []ValType{F64}, Results: []ValType{I64}, goName: "wasm.OpI64TruncF64U"},
+	{Opcode: OpF32ConvertI32S, Name: "f32.convert_i32_s", Params: []ValType{I32}, Results: []ValType{F32}, goName: "wasm.OpF32ConvertI32S"},
+	{Opcode: OpF32ConvertI32U, Name: "f32.convert_i32_u", Params: []ValType{I32}, Results: []ValType{F32}, goName: "wasm.OpF32ConvertI32U"},
+	{Opcode: OpF32ConvertI64S, Name: "f32.convert_i64_s", Params: []ValType{I64}, Results: []ValType{F32}, goName: "wasm.OpF32ConvertI64S"},
+	{Opcode: OpF32ConvertI64U, Name: "f32.convert_i64_u", Params: []ValType{I64}, Results: []ValType{F32}, goName: "wasm.OpF32ConvertI64U"},
+	{Opcode: OpF32DemoteF64, Name: "f32.demote_f64", Params: []ValType{F64}, Results: []ValType{F32}, goName: "wasm.OpF32DemoteF64"},
+	{Opcode: OpF64ConvertI32S, Name: "f64.convert_i32_s", Params: []ValType{I32}, Results: []ValType{F64}, goName: "wasm.OpF64ConvertI32S"},
+	{Opcode: OpF64ConvertI32U, Name: "f64.convert_i32_u", Params: []ValType{I32}, Results: []ValType{F64}, goName: "wasm.OpF64ConvertI32U"},
+	{Opcode: OpF64ConvertI64S, Name: "f64.convert_i64_s", Params: []ValType{I64}, Results: []ValType{F64}, goName: "wasm.OpF64ConvertI64S"},
+	{Opcode: OpF64ConvertI64U, Name: "f64.convert_i64_u", Params: []ValType{I64}, Results: []ValType{F64}, goName: "wasm.OpF64ConvertI64U"},
+	{Opcode: OpF64PromoteF32, Name: "f64.promote_f32", Params: []ValType{F32}, Results: []ValType{F64}, goName: "wasm.OpF64PromoteF32"},
+	{Opcode: OpI32ReinterpretF32, Name: "i32.reinterpret_f32", Params: []ValType{F32}, Results: []ValType{I32}, goName: "wasm.OpI32ReinterpretF32"},
+	{Opcode: OpI64ReinterpretF64, Name: "i64.reinterpret_f64", Params: []ValType{F64}, Results: []ValType{I64}, goName: "wasm.OpI64ReinterpretF64"},
+	{Opcode: OpF32ReinterpretI32, Name: "f32.reinterpret_i32", Params: []ValType{I32}, Results: []ValType{F32}, goName: "wasm.OpF32ReinterpretI32"},
+	{Opcode: OpF64ReinterpretI64, Name: "f64.reinterpret_i64", Params: []ValType{I64}, Results: []ValType{F64}, goName: "wasm.OpF64ReinterpretI64"},
+	{Opcode: OpI32Extend8S, Name: "i32.extend8_s", Params: []ValType{I32}, Results: []ValType{I32}, Features: 1 << SignExtensionFeature, goName: "wasm.OpI32Extend8S"},
+	{Opcode: OpI32Extend16S, Name: "i32.extend16_s", Params: []ValType{I32}, Results: []ValType{I32}, Features: 1 << SignExtensionFeature, goName: "wasm.OpI32Extend16S"},
+	{Opcode: OpI64Extend8S, Name: "i64.extend8_s", Params: []ValType{I64}, Results: []ValType{I64}, Features: 1 << SignExtensionFeature, goName: "wasm.OpI64Extend8S"},
+	{Opcode: OpI64Extend16S, Name: "i64.extend16_s", Params: []ValType{I64}, Results: []ValType{I64}, Features: 1 << SignExtensionFeature, goName: "wasm.OpI64Extend16S"},
+	{Opcode: OpI64Extend32S, Name: "i64.extend32_s", Params: []ValType{I64}, Results: []ValType{I64}, Features: 1 << SignExtensionFeature, goName: "wasm.OpI64Extend32S"},
+	{Opcode: OpRefNull, Name: "ref.null", Immediates: []Immediate{HeapTypeImmediate}, Dynamic: true, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpRefNull"},
+	{Opcode: OpRefIsNull, Name: "ref.is_null", Dynamic: true, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpRefIsNull"},
+	{Opcode: OpRefFunc, Name: "ref.func", Immediates: []Immediate{FuncImmediate}, Results: []ValType{FuncRef}, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpRefFunc"},
+	{Opcode: OpI32TruncSatF32S, Name: "i32.trunc_sat_f32_s", Params: []ValType{F32}, Results: []ValType{I32}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI32TruncSatF32S"},
+	{Opcode: OpI32TruncSatF32U, Name: "i32.trunc_sat_f32_u", Params: []ValType{F32}, Results: []ValType{I32}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI32TruncSatF32U"},
+	{Opcode: OpI32TruncSatF64S, Name: "i32.trunc_sat_f64_s", Params: []ValType{F64}, Results: []ValType{I32}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI32TruncSatF64S"},
+	{Opcode: OpI32TruncSatF64U, Name: "i32.trunc_sat_f64_u", Params: []ValType{F64}, Results: []ValType{I32}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI32TruncSatF64U"},
+	{Opcode: OpI64TruncSatF32S, Name: "i64.trunc_sat_f32_s", Params: []ValType{F32}, Results: []ValType{I64}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI64TruncSatF32S"},
+	{Opcode: OpI64TruncSatF32U, Name: "i64.trunc_sat_f32_u", Params: []ValType{F32}, Results: []ValType{I64}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI64TruncSatF32U"},
+	{Opcode: OpI64TruncSatF64S, Name: "i64.trunc_sat_f64_s", Params: []ValType{F64}, Results: []ValType{I64}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI64TruncSatF64S"},
+	{Opcode: OpI64TruncSatF64U, Name: "i64.trunc_sat_f64_u", Params: []ValType{F64}, Results: []ValType{I64}, Features: 1 << NonTrappingFloatToIntFeature, goName: "wasm.OpI64TruncSatF64U"},
+	{Opcode: OpMemoryInit, Name: "memory.init", Immediates: []Immediate{DataImmediate, ZeroByteImmediate}, Params: []ValType{I32, I32, I32}, Features: 1 << BulkMemoryFeature, goName: "wasm.OpMemoryInit"},
+	{Opcode: OpDataDrop, Name: "data.drop", Immediates: []Immediate{DataImmediate}, Features: 1 << BulkMemoryFeature, goName: "wasm.OpDataDrop"},
+	{Opcode: OpMemoryCopy, Name: "memory.copy", Immediates: []Immediate{ZeroByteImmediate, ZeroByteImmediate}, Params: []ValType{I32, I32, I32}, Features: 1 << BulkMemoryFeature, goName: "wasm.OpMemoryCopy"},
+	{Opcode: OpMemoryFill, Name: "memory.fill", Immediates: []Immediate{ZeroByteImmediate}, Params: []ValType{I32, I32, I32}, Features: 1 << BulkMemoryFeature, goName: "wasm.OpMemoryFill"},
+	{Opcode: OpTableInit, Name: "table.init", Immediates: []Immediate{ElemImmediate, TableImmediate}, Params: []ValType{I32, I32, I32}, Features: 1 << BulkMemoryFeature, goName: "wasm.OpTableInit"},
+	{Opcode: OpElemDrop, Name: "elem.drop", Immediates: []Immediate{ElemImmediate}, Features: 1 << BulkMemoryFeature, goName: "wasm.OpElemDrop"},
+	{Opcode: OpTableCopy, Name: "table.copy", Immediates: []Immediate{TableImmediate, TableImmediate}, Params: []ValType{I32, I32, I32}, Features: 1 << BulkMemoryFeature, goName: "wasm.OpTableCopy"},
+	{Opcode: OpTableGrow, Name: "table.grow", Immediates: []Immediate{TableImmediate}, Dynamic: true, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpTableGrow"},
+	{Opcode: OpTableSize, Name: "table.size", Immediates: []Immediate{TableImmediate}, Results: []ValType{I32}, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpTableSize"},
+	{Opcode: OpTableFill, Name: "table.fill", Immediates: []Immediate{TableImmediate}, Dynamic: true, Features: 1 << ReferenceTypesFeature, goName: "wasm.OpTableFill"},
+	{Opcode: OpV128Load, Name: "v128.load", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 4, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load"},
+	{Opcode: OpV128Load8x8S, Name: "v128.load8x8_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load8x8S"},
+	{Opcode: OpV128Load8x8U, Name: "v128.load8x8_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load8x8U"},
+	{Opcode: OpV128Load16x4S, Name: "v128.load16x4_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load16x4S"},
+	{Opcode: OpV128Load16x4U, Name: "v128.load16x4_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load16x4U"},
+	{Opcode: OpV128Load32x2S, Name: "v128.load32x2_s", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load32x2S"},
+	{Opcode: OpV128Load32x2U, Name: "v128.load32x2_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load32x2U"},
+	{Opcode: OpV128Load8Splat, Name: "v128.load8_splat", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load8Splat"},
+	{Opcode: OpV128Load16Splat, Name: "v128.load16_splat", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 1, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load16Splat"},
+	{Opcode: OpV128Load32Splat, Name: "v128.load32_splat", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 2, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load32Splat"},
+	{Opcode: OpV128Load64Splat, Name: "v128.load64_splat", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load64Splat"},
+	{Opcode: OpV128Store, Name: "v128.store", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, V128}, NaturalAlign: 4, Features: 1 << SIMDFeature, goName: "wasm.OpV128Store"},
+	{Opcode: OpV128Const, Name: "v128.const", Immediates: []Immediate{V128Immediate}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Const"},
+	{Opcode: OpI8x16Shuffle, Name: "i8x16.shuffle", Immediates: []Immediate{ShuffleImmediate}, Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Shuffle"},
+	{Opcode: OpI8x16Swizzle, Name: "i8x16.swizzle", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Swizzle"},
+	{Opcode: OpI8x16Splat, Name: "i8x16.splat", Params: []ValType{I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Splat"},
+	{Opcode: OpI16x8Splat, Name: "i16x8.splat", Params: []ValType{I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Splat"},
+	{Opcode: OpI32x4Splat, Name: "i32x4.splat", Params: []ValType{I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Splat"},
+	{Opcode: OpI64x2Splat, Name: "i64x2.splat", Params: []ValType{I64}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Splat"},
+	{Opcode: OpF32x4Splat, Name: "f32x4.splat", Params: []ValType{F32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Splat"},
+	{Opcode: OpF64x2Splat, Name: "f64x2.splat", Params: []ValType{F64}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Splat"},
+	{Opcode: OpI8x16ExtractLaneS, Name: "i8x16.extract_lane_s", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16ExtractLaneS"},
+	{Opcode: OpI8x16ExtractLaneU, Name: "i8x16.extract_lane_u", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16ExtractLaneU"},
+	{Opcode: OpI8x16ReplaceLane, Name: "i8x16.replace_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16ReplaceLane"},
+	{Opcode: OpI16x8ExtractLaneS, Name: "i16x8.extract_lane_s", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtractLaneS"},
+	{Opcode: OpI16x8ExtractLaneU, Name: "i16x8.extract_lane_u", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtractLaneU"},
+	{Opcode: OpI16x8ReplaceLane, Name: "i16x8.replace_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ReplaceLane"},
+	{Opcode: OpI32x4ExtractLane, Name: "i32x4.extract_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtractLane"},
+	{Opcode: OpI32x4ReplaceLane, Name: "i32x4.replace_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ReplaceLane"},
+	{Opcode: OpI64x2ExtractLane, Name: "i64x2.extract_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{I64}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtractLane"},
+	{Opcode: OpI64x2ReplaceLane, Name: "i64x2.replace_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128, I64}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ReplaceLane"},
+	{Opcode: OpF32x4ExtractLane, Name: "f32x4.extract_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{F32}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4ExtractLane"},
+	{Opcode: OpF32x4ReplaceLane, Name: "f32x4.replace_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128, F32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4ReplaceLane"},
+	{Opcode: OpF64x2ExtractLane, Name: "f64x2.extract_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128}, Results: []ValType{F64}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2ExtractLane"},
+	{Opcode: OpF64x2ReplaceLane, Name: "f64x2.replace_lane", Immediates: []Immediate{LaneImmediate}, Params: []ValType{V128, F64}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2ReplaceLane"},
+	{Opcode: OpI8x16Eq, Name: "i8x16.eq", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Eq"},
+	{Opcode: OpI8x16Ne, Name: "i8x16.ne", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Ne"},
+	{Opcode: OpI8x16LtS, Name: "i8x16.lt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16LtS"},
+	{Opcode: OpI8x16LtU, Name: "i8x16.lt_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16LtU"},
+	{Opcode: OpI8x16GtS, Name: "i8x16.gt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16GtS"},
+	{Opcode: OpI8x16GtU, Name: "i8x16.gt_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16GtU"},
+	{Opcode: OpI8x16LeS, Name: "i8x16.le_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16LeS"},
+	{Opcode: OpI8x16LeU, Name: "i8x16.le_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16LeU"},
+	{Opcode: OpI8x16GeS, Name: "i8x16.ge_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16GeS"},
+	{Opcode: OpI8x16GeU, Name: "i8x16.ge_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16GeU"},
+	{Opcode: OpI16x8Eq, Name: "i16x8.eq", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Eq"},
+	{Opcode: OpI16x8Ne, Name: "i16x8.ne", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Ne"},
+	{Opcode: OpI16x8LtS, Name: "i16x8.lt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8LtS"},
+	{Opcode: OpI16x8LtU, Name: "i16x8.lt_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8LtU"},
+	{Opcode: OpI16x8GtS, Name: "i16x8.gt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8GtS"},
+	{Opcode: OpI16x8GtU, Name: "i16x8.gt_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8GtU"},
+	{Opcode: OpI16x8LeS, Name: "i16x8.le_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8LeS"},
+	{Opcode: OpI16x8LeU, Name: "i16x8.le_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8LeU"},
+	{Opcode: OpI16x8GeS, Name: "i16x8.ge_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8GeS"},
+	{Opcode: OpI16x8GeU, Name: "i16x8.ge_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8GeU"},
+	{Opcode: OpI32x4Eq, Name: "i32x4.eq", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Eq"},
+	{Opcode: OpI32x4Ne, Name: "i32x4.ne", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Ne"},
+	{Opcode: OpI32x4LtS, Name: "i32x4.lt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4LtS"},
+	{Opcode: OpI32x4LtU, Name: "i32x4.lt_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4LtU"},
+	{Opcode: OpI32x4GtS, Name: "i32x4.gt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4GtS"},
+	{Opcode: OpI32x4GtU, Name: "i32x4.gt_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4GtU"},
+	{Opcode: OpI32x4LeS, Name: "i32x4.le_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4LeS"},
+	{Opcode: OpI32x4LeU, Name: "i32x4.le_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4LeU"},
+	{Opcode: OpI32x4GeS, Name: "i32x4.ge_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4GeS"},
+	{Opcode: OpI32x4GeU, Name: "i32x4.ge_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4GeU"},
+	{Opcode: OpF32x4Eq, Name: "f32x4.eq", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Eq"},
+	{Opcode: OpF32x4Ne, Name: "f32x4.ne", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Ne"},
+	{Opcode: OpF32x4Lt, Name: "f32x4.lt", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Lt"},
+	{Opcode: OpF32x4Gt, Name: "f32x4.gt", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Gt"},
+	{Opcode: OpF32x4Le, Name: "f32x4.le", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Le"},
+	{Opcode: OpF32x4Ge, Name: "f32x4.ge", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Ge"},
+	{Opcode: OpF64x2Eq, Name: "f64x2.eq", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Eq"},
+	{Opcode: OpF64x2Ne, Name: "f64x2.ne", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Ne"},
+	{Opcode: OpF64x2Lt, Name: "f64x2.lt", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Lt"},
+	{Opcode: OpF64x2Gt, Name: "f64x2.gt", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Gt"},
+	{Opcode: OpF64x2Le, Name: "f64x2.le", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Le"},
+	{Opcode: OpF64x2Ge, Name: "f64x2.ge", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Ge"},
+	{Opcode: OpV128Not, Name: "v128.not", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Not"},
+	{Opcode: OpV128And, Name: "v128.and", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128And"},
+	{Opcode: OpV128Andnot, Name: "v128.andnot", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Andnot"},
+	{Opcode: OpV128Or, Name: "v128.or", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Or"},
+	{Opcode: OpV128Xor, Name: "v128.xor", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Xor"},
+	{Opcode: OpV128Bitselect, Name: "v128.bitselect", Params: []ValType{V128, V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Bitselect"},
+	{Opcode: OpV128AnyTrue, Name: "v128.any_true", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpV128AnyTrue"},
+	{Opcode: OpV128Load8Lane, Name: "v128.load8_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load8Lane"},
+	{Opcode: OpV128Load16Lane, Name: "v128.load16_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, Results: []ValType{V128}, NaturalAlign: 1, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load16Lane"},
+	{Opcode: OpV128Load32Lane, Name: "v128.load32_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, Results: []ValType{V128}, NaturalAlign: 2, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load32Lane"},
+	{Opcode: OpV128Load64Lane, Name: "v128.load64_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load64Lane"},
+	{Opcode: OpV128Store8Lane, Name: "v128.store8_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, Features: 1 << SIMDFeature, goName: "wasm.OpV128Store8Lane"},
+	{Opcode: OpV128Store16Lane, Name: "v128.store16_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, NaturalAlign: 1, Features: 1 << SIMDFeature, goName: "wasm.OpV128Store16Lane"},
+	{Opcode: OpV128Store32Lane, Name: "v128.store32_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, NaturalAlign: 2, Features: 1 << SIMDFeature, goName: "wasm.OpV128Store32Lane"},
+	{Opcode: OpV128Store64Lane, Name: "v128.store64_lane", Immediates: []Immediate{MemArgImmediate, LaneImmediate}, Params: []ValType{I32, V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Store64Lane"},
+	{Opcode: OpV128Load32Zero, Name: "v128.load32_zero", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 2, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load32Zero"},
+	{Opcode: OpV128Load64Zero, Name: "v128.load64_zero", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{V128}, NaturalAlign: 3, Features: 1 << SIMDFeature, goName: "wasm.OpV128Load64Zero"},
+	{Opcode: OpF32x4DemoteF64x2Zero, Name: "f32x4.demote_f64x2_zero", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4DemoteF64x2Zero"},
+	{Opcode: OpF64x2PromoteLowF32x4, Name: "f64x2.promote_low_f32x4", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2PromoteLowF32x4"},
+	{Opcode: OpI8x16Abs, Name: "i8x16.abs", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Abs"},
+	{Opcode: OpI8x16Neg, Name: "i8x16.neg", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Neg"},
+	{Opcode: OpI8x16Popcnt, Name: "i8x16.popcnt", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Popcnt"},
+	{Opcode: OpI8x16AllTrue, Name: "i8x16.all_true", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16AllTrue"},
+	{Opcode: OpI8x16Bitmask, Name: "i8x16.bitmask", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Bitmask"},
+	{Opcode: OpI8x16NarrowI16x8S, Name: "i8x16.narrow_i16x8_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16NarrowI16x8S"},
+	{Opcode: OpI8x16NarrowI16x8U, Name: "i8x16.narrow_i16x8_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16NarrowI16x8U"},
+	{Opcode: OpF32x4Ceil, Name: "f32x4.ceil", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Ceil"},
+	{Opcode: OpF32x4Floor, Name: "f32x4.floor", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Floor"},
+	{Opcode: OpF32x4Trunc, Name: "f32x4.trunc", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Trunc"},
+	{Opcode: OpF32x4Nearest, Name: "f32x4.nearest", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Nearest"},
+	{Opcode: OpI8x16Shl, Name: "i8x16.shl", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Shl"},
+	{Opcode: OpI8x16ShrS, Name: "i8x16.shr_s", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16ShrS"},
+	{Opcode: OpI8x16ShrU, Name: "i8x16.shr_u", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16ShrU"},
+	{Opcode: OpI8x16Add, Name: "i8x16.add", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Add"},
+	{Opcode: OpI8x16AddSatS, Name: "i8x16.add_sat_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16AddSatS"},
+	{Opcode: OpI8x16AddSatU, Name: "i8x16.add_sat_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16AddSatU"},
+	{Opcode: OpI8x16Sub, Name: "i8x16.sub", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16Sub"},
+	{Opcode: OpI8x16SubSatS, Name: "i8x16.sub_sat_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16SubSatS"},
+	{Opcode: OpI8x16SubSatU, Name: "i8x16.sub_sat_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16SubSatU"},
+	{Opcode: OpF64x2Ceil, Name: "f64x2.ceil", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Ceil"},
+	{Opcode: OpF64x2Floor, Name: "f64x2.floor", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Floor"},
+	{Opcode: OpI8x16MinS, Name: "i8x16.min_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16MinS"},
+	{Opcode: OpI8x16MinU, Name: "i8x16.min_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16MinU"},
+	{Opcode: OpI8x16MaxS, Name: "i8x16.max_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16MaxS"},
+	{Opcode: OpI8x16MaxU, Name: "i8x16.max_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16MaxU"},
+	{Opcode: OpF64x2Trunc, Name: "f64x2.trunc", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Trunc"},
+	{Opcode: OpI8x16AvgrU, Name: "i8x16.avgr_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI8x16AvgrU"},
+	{Opcode: OpI16x8ExtaddPairwiseI8x16S, Name: "i16x8.extadd_pairwise_i8x16_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtaddPairwiseI8x16S"},
+	{Opcode: OpI16x8ExtaddPairwiseI8x16U, Name: "i16x8.extadd_pairwise_i8x16_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtaddPairwiseI8x16U"},
+	{Opcode: OpI32x4ExtaddPairwiseI16x8S, Name: "i32x4.extadd_pairwise_i16x8_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtaddPairwiseI16x8S"},
+	{Opcode: OpI32x4ExtaddPairwiseI16x8U, Name: "i32x4.extadd_pairwise_i16x8_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtaddPairwiseI16x8U"},
+	{Opcode: OpI16x8Abs, Name: "i16x8.abs", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Abs"},
+	{Opcode: OpI16x8Neg, Name: "i16x8.neg", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Neg"},
+	{Opcode: OpI16x8Q15mulrSatS, Name: "i16x8.q15mulr_sat_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Q15mulrSatS"},
+	{Opcode: OpI16x8AllTrue, Name: "i16x8.all_true", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8AllTrue"},
+	{Opcode: OpI16x8Bitmask, Name: "i16x8.bitmask", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Bitmask"},
+	{Opcode: OpI16x8NarrowI32x4S, Name: "i16x8.narrow_i32x4_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8NarrowI32x4S"},
+	{Opcode: OpI16x8NarrowI32x4U, Name: "i16x8.narrow_i32x4_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8NarrowI32x4U"},
+	{Opcode: OpI16x8ExtendLowI8x16S, Name: "i16x8.extend_low_i8x16_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtendLowI8x16S"},
+	{Opcode: OpI16x8ExtendHighI8x16S, Name: "i16x8.extend_high_i8x16_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtendHighI8x16S"},
+	{Opcode: OpI16x8ExtendLowI8x16U, Name: "i16x8.extend_low_i8x16_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtendLowI8x16U"},
+	{Opcode: OpI16x8ExtendHighI8x16U, Name: "i16x8.extend_high_i8x16_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtendHighI8x16U"},
+	{Opcode: OpI16x8Shl, Name: "i16x8.shl", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Shl"},
+	{Opcode: OpI16x8ShrS, Name: "i16x8.shr_s", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ShrS"},
+	{Opcode: OpI16x8ShrU, Name: "i16x8.shr_u", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ShrU"},
+	{Opcode: OpI16x8Add, Name: "i16x8.add", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Add"},
+	{Opcode: OpI16x8AddSatS, Name: "i16x8.add_sat_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8AddSatS"},
+	{Opcode: OpI16x8AddSatU, Name: "i16x8.add_sat_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8AddSatU"},
+	{Opcode: OpI16x8Sub, Name: "i16x8.sub", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Sub"},
+	{Opcode: OpI16x8SubSatS, Name: "i16x8.sub_sat_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8SubSatS"},
+	{Opcode: OpI16x8SubSatU, Name: "i16x8.sub_sat_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8SubSatU"},
+	{Opcode: OpF64x2Nearest, Name: "f64x2.nearest", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Nearest"},
+	{Opcode: OpI16x8Mul, Name: "i16x8.mul", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8Mul"},
+	{Opcode: OpI16x8MinS, Name: "i16x8.min_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8MinS"},
+	{Opcode: OpI16x8MinU, Name: "i16x8.min_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8MinU"},
+	{Opcode: OpI16x8MaxS, Name: "i16x8.max_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8MaxS"},
+	{Opcode: OpI16x8MaxU, Name: "i16x8.max_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8MaxU"},
+	{Opcode: OpI16x8AvgrU, Name: "i16x8.avgr_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8AvgrU"},
+	{Opcode: OpI16x8ExtmulLowI8x16S, Name: "i16x8.extmul_low_i8x16_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtmulLowI8x16S"},
+	{Opcode: OpI16x8ExtmulHighI8x16S, Name: "i16x8.extmul_high_i8x16_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtmulHighI8x16S"},
+	{Opcode: OpI16x8ExtmulLowI8x16U, Name: "i16x8.extmul_low_i8x16_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtmulLowI8x16U"},
+	{Opcode: OpI16x8ExtmulHighI8x16U, Name: "i16x8.extmul_high_i8x16_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI16x8ExtmulHighI8x16U"},
+	{Opcode: OpI32x4Abs, Name: "i32x4.abs", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Abs"},
+	{Opcode: OpI32x4Neg, Name: "i32x4.neg", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Neg"},
+	{Opcode: OpI32x4AllTrue, Name: "i32x4.all_true", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4AllTrue"},
+	{Opcode: OpI32x4Bitmask, Name: "i32x4.bitmask", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Bitmask"},
+	{Opcode: OpI32x4ExtendLowI16x8S, Name: "i32x4.extend_low_i16x8_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtendLowI16x8S"},
+	{Opcode: OpI32x4ExtendHighI16x8S, Name: "i32x4.extend_high_i16x8_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtendHighI16x8S"},
+	{Opcode: OpI32x4ExtendLowI16x8U, Name: "i32x4.extend_low_i16x8_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtendLowI16x8U"},
+	{Opcode: OpI32x4ExtendHighI16x8U, Name: "i32x4.extend_high_i16x8_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtendHighI16x8U"},
+	{Opcode: OpI32x4Shl, Name: "i32x4.shl", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Shl"},
+	{Opcode: OpI32x4ShrS, Name: "i32x4.shr_s", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ShrS"},
+	{Opcode: OpI32x4ShrU, Name: "i32x4.shr_u", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ShrU"},
+	{Opcode: OpI32x4Add, Name: "i32x4.add", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Add"},
+	{Opcode: OpI32x4Sub, Name: "i32x4.sub", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Sub"},
+	{Opcode: OpI32x4Mul, Name: "i32x4.mul", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4Mul"},
+	{Opcode: OpI32x4MinS, Name: "i32x4.min_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4MinS"},
+	{Opcode: OpI32x4MinU, Name: "i32x4.min_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4MinU"},
+	{Opcode: OpI32x4MaxS, Name: "i32x4.max_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4MaxS"},
+	{Opcode: OpI32x4MaxU, Name: "i32x4.max_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4MaxU"},
+	{Opcode: OpI32x4DotI16x8S, Name: "i32x4.dot_i16x8_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4DotI16x8S"},
+	{Opcode: OpI32x4ExtmulLowI16x8S, Name: "i32x4.extmul_low_i16x8_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtmulLowI16x8S"},
+	{Opcode: OpI32x4ExtmulHighI16x8S, Name: "i32x4.extmul_high_i16x8_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtmulHighI16x8S"},
+	{Opcode: OpI32x4ExtmulLowI16x8U, Name: "i32x4.extmul_low_i16x8_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtmulLowI16x8U"},
+	{Opcode: OpI32x4ExtmulHighI16x8U, Name: "i32x4.extmul_high_i16x8_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4ExtmulHighI16x8U"},
+	{Opcode: OpI64x2Abs, Name: "i64x2.abs", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Abs"},
+	{Opcode: OpI64x2Neg, Name: "i64x2.neg", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Neg"},
+	{Opcode: OpI64x2AllTrue, Name: "i64x2.all_true", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2AllTrue"},
+	{Opcode: OpI64x2Bitmask, Name: "i64x2.bitmask", Params: []ValType{V128}, Results: []ValType{I32}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Bitmask"},
+	{Opcode: OpI64x2ExtendLowI32x4S, Name: "i64x2.extend_low_i32x4_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtendLowI32x4S"},
+	{Opcode: OpI64x2ExtendHighI32x4S, Name: "i64x2.extend_high_i32x4_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtendHighI32x4S"},
+	{Opcode: OpI64x2ExtendLowI32x4U, Name: "i64x2.extend_low_i32x4_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtendLowI32x4U"},
+	{Opcode: OpI64x2ExtendHighI32x4U, Name: "i64x2.extend_high_i32x4_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtendHighI32x4U"},
+	{Opcode: OpI64x2Shl, Name: "i64x2.shl", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Shl"},
+	{Opcode: OpI64x2ShrS, Name: "i64x2.shr_s", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ShrS"},
+	{Opcode: OpI64x2ShrU, Name: "i64x2.shr_u", Params: []ValType{V128, I32}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ShrU"},
+	{Opcode: OpI64x2Add, Name: "i64x2.add", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Add"},
+	{Opcode: OpI64x2Sub, Name: "i64x2.sub", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Sub"},
+	{Opcode: OpI64x2Mul, Name: "i64x2.mul", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Mul"},
+	{Opcode: OpI64x2Eq, Name: "i64x2.eq", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Eq"},
+	{Opcode: OpI64x2Ne, Name: "i64x2.ne", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2Ne"},
+	{Opcode: OpI64x2LtS, Name: "i64x2.lt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2LtS"},
+	{Opcode: OpI64x2GtS, Name: "i64x2.gt_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2GtS"},
+	{Opcode: OpI64x2LeS, Name: "i64x2.le_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2LeS"},
+	{Opcode: OpI64x2GeS, Name: "i64x2.ge_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2GeS"},
+	{Opcode: OpI64x2ExtmulLowI32x4S, Name: "i64x2.extmul_low_i32x4_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtmulLowI32x4S"},
+	{Opcode: OpI64x2ExtmulHighI32x4S, Name: "i64x2.extmul_high_i32x4_s", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtmulHighI32x4S"},
+	{Opcode: OpI64x2ExtmulLowI32x4U, Name: "i64x2.extmul_low_i32x4_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtmulLowI32x4U"},
+	{Opcode: OpI64x2ExtmulHighI32x4U, Name: "i64x2.extmul_high_i32x4_u", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI64x2ExtmulHighI32x4U"},
+	{Opcode: OpF32x4Abs, Name: "f32x4.abs", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Abs"},
+	{Opcode: OpF32x4Neg, Name: "f32x4.neg", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Neg"},
+	{Opcode: OpF32x4Sqrt, Name: "f32x4.sqrt", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Sqrt"},
+	{Opcode: OpF32x4Add, Name: "f32x4.add", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Add"},
+	{Opcode: OpF32x4Sub, Name: "f32x4.sub", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Sub"},
+	{Opcode: OpF32x4Mul, Name: "f32x4.mul", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Mul"},
+	{Opcode: OpF32x4Div, Name: "f32x4.div", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Div"},
+	{Opcode: OpF32x4Min, Name: "f32x4.min", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Min"},
+	{Opcode: OpF32x4Max, Name: "f32x4.max", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Max"},
+	{Opcode: OpF32x4Pmin, Name: "f32x4.pmin", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Pmin"},
+	{Opcode: OpF32x4Pmax, Name: "f32x4.pmax", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4Pmax"},
+	{Opcode: OpF64x2Abs, Name: "f64x2.abs", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Abs"},
+	{Opcode: OpF64x2Neg, Name: "f64x2.neg", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Neg"},
+	{Opcode: OpF64x2Sqrt, Name: "f64x2.sqrt", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Sqrt"},
+	{Opcode: OpF64x2Add, Name: "f64x2.add", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Add"},
+	{Opcode: OpF64x2Sub, Name: "f64x2.sub", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Sub"},
+	{Opcode: OpF64x2Mul, Name: "f64x2.mul", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Mul"},
+	{Opcode: OpF64x2Div, Name: "f64x2.div", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Div"},
+	{Opcode: OpF64x2Min, Name: "f64x2.min", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Min"},
+	{Opcode: OpF64x2Max, Name: "f64x2.max", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Max"},
+	{Opcode: OpF64x2Pmin, Name: "f64x2.pmin", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Pmin"},
+	{Opcode: OpF64x2Pmax, Name: "f64x2.pmax", Params: []ValType{V128, V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2Pmax"},
+	{Opcode: OpI32x4TruncSatF32x4S, Name: "i32x4.trunc_sat_f32x4_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4TruncSatF32x4S"},
+	{Opcode: OpI32x4TruncSatF32x4U, Name: "i32x4.trunc_sat_f32x4_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4TruncSatF32x4U"},
+	{Opcode: OpF32x4ConvertI32x4S, Name: "f32x4.convert_i32x4_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4ConvertI32x4S"},
+	{Opcode: OpF32x4ConvertI32x4U, Name: "f32x4.convert_i32x4_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF32x4ConvertI32x4U"},
+	{Opcode: OpI32x4TruncSatF64x2SZero, Name: "i32x4.trunc_sat_f64x2_s_zero", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4TruncSatF64x2SZero"},
+	{Opcode: OpI32x4TruncSatF64x2UZero, Name: "i32x4.trunc_sat_f64x2_u_zero", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpI32x4TruncSatF64x2UZero"},
+	{Opcode: OpF64x2ConvertLowI32x4S, Name: "f64x2.convert_low_i32x4_s", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2ConvertLowI32x4S"},
+	{Opcode: OpF64x2ConvertLowI32x4U, Name: "f64x2.convert_low_i32x4_u", Params: []ValType{V128}, Results: []ValType{V128}, Features: 1 << SIMDFeature, goName: "wasm.OpF64x2ConvertLowI32x4U"},
+	{Opcode: OpMemoryAtomicNotify, Name: "memory.atomic.notify", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpMemoryAtomicNotify"},
+	{Opcode: OpMemoryAtomicWait32, Name: "memory.atomic.wait32", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32, I64}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpMemoryAtomicWait32"},
+	{Opcode: OpMemoryAtomicWait64, Name: "memory.atomic.wait64", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64, I64}, Results: []ValType{I32}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpMemoryAtomicWait64"},
+	{Opcode: OpAtomicFence, Name: "atomic.fence", Immediates: []Immediate{ZeroByteImmediate}, Features: 1 << ThreadsFeature, goName: "wasm.OpAtomicFence"},
+	{Opcode: OpI32AtomicLoad, Name: "i32.atomic.load", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicLoad"},
+	{Opcode: OpI64AtomicLoad, Name: "i64.atomic.load", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicLoad"},
+	{Opcode: OpI32AtomicLoad8U, Name: "i32.atomic.load8_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicLoad8U"},
+	{Opcode: OpI32AtomicLoad16U, Name: "i32.atomic.load16_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicLoad16U"},
+	{Opcode: OpI64AtomicLoad8U, Name: "i64.atomic.load8_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicLoad8U"},
+	{Opcode: OpI64AtomicLoad16U, Name: "i64.atomic.load16_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicLoad16U"},
+	{Opcode: OpI64AtomicLoad32U, Name: "i64.atomic.load32_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicLoad32U"},
+	{Opcode: OpI32AtomicStore, Name: "i32.atomic.store", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicStore"},
+	{Opcode: OpI64AtomicStore, Name: "i64.atomic.store", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicStore"},
+	{Opcode: OpI32AtomicStore8, Name: "i32.atomic.store8", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicStore8"},
+	{Opcode: OpI32AtomicStore16, Name: "i32.atomic.store16", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicStore16"},
+	{Opcode: OpI64AtomicStore8, Name: "i64.atomic.store8", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicStore8"},
+	{Opcode: OpI64AtomicStore16, Name: "i64.atomic.store16", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicStore16"},
+	{Opcode: OpI64AtomicStore32, Name: "i64.atomic.store32", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicStore32"},
+	{Opcode: OpI32AtomicRmwAdd, Name: "i32.atomic.rmw.add", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmwAdd"},
+	{Opcode: OpI64AtomicRmwAdd, Name: "i64.atomic.rmw.add", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmwAdd"},
+	{Opcode: OpI32AtomicRmw8AddU, Name: "i32.atomic.rmw8.add_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw8AddU"},
+	{Opcode: OpI32AtomicRmw16AddU, Name: "i32.atomic.rmw16.add_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw16AddU"},
+	{Opcode: OpI64AtomicRmw8AddU, Name: "i64.atomic.rmw8.add_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw8AddU"},
+	{Opcode: OpI64AtomicRmw16AddU, Name: "i64.atomic.rmw16.add_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw16AddU"},
+	{Opcode: OpI64AtomicRmw32AddU, Name: "i64.atomic.rmw32.add_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw32AddU"},
+	{Opcode: OpI32AtomicRmwSub, Name: "i32.atomic.rmw.sub", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmwSub"},
+	{Opcode: OpI64AtomicRmwSub, Name: "i64.atomic.rmw.sub", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmwSub"},
+	{Opcode: OpI32AtomicRmw8SubU, Name: "i32.atomic.rmw8.sub_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw8SubU"},
+	{Opcode: OpI32AtomicRmw16SubU, Name: "i32.atomic.rmw16.sub_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw16SubU"},
+	{Opcode: OpI64AtomicRmw8SubU, Name: "i64.atomic.rmw8.sub_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw8SubU"},
+	{Opcode: OpI64AtomicRmw16SubU, Name: "i64.atomic.rmw16.sub_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw16SubU"},
+	{Opcode: OpI64AtomicRmw32SubU, Name: "i64.atomic.rmw32.sub_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw32SubU"},
+	{Opcode: OpI32AtomicRmwAnd, Name: "i32.atomic.rmw.and", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmwAnd"},
+	{Opcode: OpI64AtomicRmwAnd, Name: "i64.atomic.rmw.and", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmwAnd"},
+	{Opcode: OpI32AtomicRmw8AndU, Name: "i32.atomic.rmw8.and_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw8AndU"},
+	{Opcode: OpI32AtomicRmw16AndU, Name: "i32.atomic.rmw16.and_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw16AndU"},
+	{Opcode: OpI64AtomicRmw8AndU, Name: "i64.atomic.rmw8.and_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw8AndU"},
+	{Opcode: OpI64AtomicRmw16AndU, Name: "i64.atomic.rmw16.and_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw16AndU"},
+	{Opcode: OpI64AtomicRmw32AndU, Name: "i64.atomic.rmw32.and_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw32AndU"},
+	{Opcode: OpI32AtomicRmwOr, Name: "i32.atomic.rmw.or", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmwOr"},
+	{Opcode: OpI64AtomicRmwOr, Name: "i64.atomic.rmw.or", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmwOr"},
+	{Opcode: OpI32AtomicRmw8OrU, Name: "i32.atomic.rmw8.or_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw8OrU"},
+	{Opcode: OpI32AtomicRmw16OrU, Name: "i32.atomic.rmw16.or_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw16OrU"},
+	{Opcode: OpI64AtomicRmw8OrU, Name: "i64.atomic.rmw8.or_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw8OrU"},
+	{Opcode: OpI64AtomicRmw16OrU, Name: "i64.atomic.rmw16.or_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw16OrU"},
+	{Opcode: OpI64AtomicRmw32OrU, Name: "i64.atomic.rmw32.or_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw32OrU"},
+	{Opcode: OpI32AtomicRmwXor, Name: "i32.atomic.rmw.xor", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmwXor"},
+	{Opcode: OpI64AtomicRmwXor, Name: "i64.atomic.rmw.xor", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmwXor"},
+	{Opcode: OpI32AtomicRmw8XorU, Name: "i32.atomic.rmw8.xor_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw8XorU"},
+	{Opcode: OpI32AtomicRmw16XorU, Name: "i32.atomic.rmw16.xor_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw16XorU"},
+	{Opcode: OpI64AtomicRmw8XorU, Name: "i64.atomic.rmw8.xor_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw8XorU"},
+	{Opcode: OpI64AtomicRmw16XorU, Name: "i64.atomic.rmw16.xor_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw16XorU"},
+	{Opcode: OpI64AtomicRmw32XorU, Name: "i64.atomic.rmw32.xor_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw32XorU"},
+	{Opcode: OpI32AtomicRmwXchg, Name: "i32.atomic.rmw.xchg", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmwXchg"},
+	{Opcode: OpI64AtomicRmwXchg, Name: "i64.atomic.rmw.xchg", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmwXchg"},
+	{Opcode: OpI32AtomicRmw8XchgU, Name: "i32.atomic.rmw8.xchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw8XchgU"},
+	{Opcode: OpI32AtomicRmw16XchgU, Name: "i32.atomic.rmw16.xchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw16XchgU"},
+	{Opcode: OpI64AtomicRmw8XchgU, Name: "i64.atomic.rmw8.xchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw8XchgU"},
+	{Opcode: OpI64AtomicRmw16XchgU, Name: "i64.atomic.rmw16.xchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw16XchgU"},
+	{Opcode: OpI64AtomicRmw32XchgU, Name: "i64.atomic.rmw32.xchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw32XchgU"},
+	{Opcode: OpI32AtomicRmwCmpxchg, Name: "i32.atomic.rmw.cmpxchg", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32, I32}, Results: []ValType{I32}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmwCmpxchg"},
+	{Opcode: OpI64AtomicRmwCmpxchg, Name: "i64.atomic.rmw.cmpxchg", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64, I64}, Results: []ValType{I64}, NaturalAlign: 3, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmwCmpxchg"},
+	{Opcode: OpI32AtomicRmw8CmpxchgU, Name: "i32.atomic.rmw8.cmpxchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32, I32}, Results: []ValType{I32}, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw8CmpxchgU"},
+	{Opcode: OpI32AtomicRmw16CmpxchgU, Name: "i32.atomic.rmw16.cmpxchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I32, I32}, Results: []ValType{I32}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI32AtomicRmw16CmpxchgU"},
+	{Opcode: OpI64AtomicRmw8CmpxchgU, Name: "i64.atomic.rmw8.cmpxchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64, I64}, Results: []ValType{I64}, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw8CmpxchgU"},
+	{Opcode: OpI64AtomicRmw16CmpxchgU, Name: "i64.atomic.rmw16.cmpxchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64, I64}, Results: []ValType{I64}, NaturalAlign: 1, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw16CmpxchgU"},
+	{Opcode: OpI64AtomicRmw32CmpxchgU, Name: "i64.atomic.rmw32.cmpxchg_u", Immediates: []Immediate{MemArgImmediate}, Params: []ValType{I32, I64, I64}, Results: []ValType{I64}, NaturalAlign: 2, Features: 1 << ThreadsFeature, goName: "wasm.OpI64AtomicRmw32CmpxchgU"},
+}
+
+// opcodesByName holds the first opcode listed for each mnemonic.
+var opcodesByName = map[string]Opcode{
+	"unreachable":                   OpUnreachable,
+	"nop":                           OpNop,
+	"block":                         OpBlock,
+	"loop":                          OpLoop,
+	"if":                            OpIf,
+	"else":                          OpElse,
+	"end":                           OpEnd,
+	"br":                            OpBr,
+	"br_if":                         OpBrIf,
+	"br_table":                      OpBrTable,
+	"return":                        OpReturn,
+	"call":                          OpCall,
+	"call_indirect":                 OpCallIndirect,
+	"drop":                          OpDrop,
+	"select":                        OpSelect,
+	"local.get":                     OpLocalGet,
+	"local.set":                     OpLocalSet,
+	"local.tee":                     OpLocalTee,
+	"global.get":                    OpGlobalGet,
+	"global.set":                    OpGlobalSet,
+	"table.get":                     OpTableGet,
+	"table.set":                     OpTableSet,
+	"i32.load":                      OpI32Load,
+	"i64.load":                      OpI64Load,
+	"f32.load":                      OpF32Load,
+	"f64.load":                      OpF64Load,
+	"i32.load8_s":                   OpI32Load8S,
+	"i32.load8_u":                   OpI32Load8U,
+	"i32.load16_s":                  OpI32Load16S,
+	"i32.load16_u":                  OpI32Load16U,
+	"i64.load8_s":                   OpI64Load8S,
+	"i64.load8_u":                   OpI64Load8U,
+	"i64.load16_s":                  OpI64Load16S,
+	"i64.load16_u":                  OpI64Load16U,
+	"i64.load32_s":                  OpI64Load32S,
+	"i64.load32_u":                  OpI64Load32U,
+	"i32.store":                     OpI32Store,
+	"i64.store":                     OpI64Store,
+	"f32.store":                     OpF32Store,
+	"f64.store":                     OpF64Store,
+	"i32.store8":                    OpI32Store8,
+	"i32.store16":                   OpI32Store16,
+	"i64.store8":                    OpI64Store8,
+	"i64.store16":                   OpI64Store16,
+	"i64.store32":                   OpI64Store32,
+	"memory.size":                   OpMemorySize,
+	"memory.grow":                   OpMemoryGrow,
+	"i32.const":                     OpI32Const,
+	"i64.const":                     OpI64Const,
+	"f32.const":                     OpF32Const,
+	"f64.const":                     OpF64Const,
+	"i32.eqz":                       OpI32Eqz,
+	"i32.eq":                        OpI32Eq,
+	"i32.ne":                        OpI32Ne,
+	"i32.lt_s":                      OpI32LtS,
+	"i32.lt_u":                      OpI32LtU,
+	"i32.gt_s":                      OpI32GtS,
+	"i32.gt_u":                      OpI32GtU,
+	"i32.le_s":                      OpI32LeS,
+	"i32.le_u":                      OpI32LeU,
+	"i32.ge_s":                      OpI32GeS,
+	"i32.ge_u":                      OpI32GeU,
+	"i64.eqz":                       OpI64Eqz,
+	"i64.eq":                        OpI64Eq,
+	"i64.ne":                        OpI64Ne,
+	"i64.lt_s":                      OpI64LtS,
+	"i64.lt_u":                      OpI64LtU,
+	"i64.gt_s":                      OpI64GtS,
+	"i64.gt_u":                      OpI64GtU,
+	"i64.le_s":                      OpI64LeS,
+	"i64.le_u":                      OpI64LeU,
+	"i64.ge_s":                      OpI64GeS,
+	"i64.ge_u":                      OpI64GeU,
+	"f32.eq":                        OpF32Eq,
+	"f32.ne":                        OpF32Ne,
+	"f32.lt":                        OpF32Lt,
+	"f32.gt":                        OpF32Gt,
+	"f32.le":                        OpF32Le,
+	"f32.ge":                        OpF32Ge,
+	"f64.eq":                        OpF64Eq,
+	"f64.ne":                        OpF64Ne,
+	"f64.lt":                        OpF64Lt,
+	"f64.gt":                        OpF64Gt,
+	"f64.le":                        OpF64Le,
+	"f64.ge":                        OpF64Ge,
+	"i32.clz":                       OpI32Clz,
+	"i32.ctz":                       OpI32Ctz,
+	"i32.popcnt":                    OpI32Popcnt,
+	"i32.add":                       OpI32Add,
+	"i32.sub":                       OpI32Sub,
+	"i32.mul":                       OpI32Mul,
+	"i32.div_s":                     OpI32DivS,
+	"i32.div_u":                     OpI32DivU,
+	"i32.rem_s":                     OpI32RemS,
+	"i32.rem_u":                     OpI32RemU,
+	"i32.and":                       OpI32And,
+	"i32.or":                        OpI32Or,
+	"i32.xor":                       OpI32Xor,
+	"i32.shl":                       OpI32Shl,
+	"i32.shr_s":                     OpI32ShrS,
+	"i32.shr_u":                     OpI32ShrU,
+	"i32.rotl":                      OpI32Rotl,
+	"i32.rotr":                      OpI32Rotr,
+	"i64.clz":                       OpI64Clz,
+	"i64.ctz":                       OpI64Ctz,
+	"i64.popcnt":                    OpI64Popcnt,
+	"i64.add":                       OpI64Add,
+	"i64.sub":                       OpI64Sub,
+	"i64.mul":                       OpI64Mul,
+	"i64.div_s":                     OpI64DivS,
+	"i64.div_u":                     OpI64DivU,
+	"i64.rem_s":                     OpI64RemS,
+	"i64.rem_u":                     OpI64RemU,
+	"i64.and":                       OpI64And,
+	"i64.or":                        OpI64Or,
+	"i64.xor":                       OpI64Xor,
+	"i64.shl":                       OpI64Shl,
+	"i64.shr_s":                     OpI64ShrS,
+	"i64.shr_u":                     OpI64ShrU,
+	"i64.rotl":                      OpI64Rotl,
+	"i64.rotr":                      OpI64Rotr,
+	"f32.abs":                       OpF32Abs,
+	"f32.neg":                       OpF32Neg,
+	"f32.ceil":                      OpF32Ceil,
+	"f32.floor":                     OpF32Floor,
+	"f32.trunc":                     OpF32Trunc,
+	"f32.nearest":                   OpF32Nearest,
+	"f32.sqrt":                      OpF32Sqrt,
+	"f32.add":                       OpF32Add,
+	"f32.sub":                       OpF32Sub,
+	"f32.mul":                       OpF32Mul,
+	"f32.div":                       OpF32Div,
+	"f32.min":                       OpF32Min,
+	"f32.max":                       OpF32Max,
+	"f32.copysign":                  OpF32Copysign,
+	"f64.abs":                       OpF64Abs,
+	"f64.neg":                       OpF64Neg,
+	"f64.ceil":                      OpF64Ceil,
+	"f64.floor":                     OpF64Floor,
+	"f64.trunc":                     OpF64Trunc,
+	"f64.nearest":                   OpF64Nearest,
+	"f64.sqrt":                      OpF64Sqrt,
+	"f64.add":                       OpF64Add,
+	"f64.sub":                       OpF64Sub,
+	"f64.mul":                       OpF64Mul,
+	"f64.div":                       OpF64Div,
+	"f64.min":                       OpF64Min,
+	"f64.max":                       OpF64Max,
+	"f64.copysign":                  OpF64Copysign,
+	"i32.wrap_i64":                  OpI32WrapI64,
+	"i32.trunc_f32_s":               OpI32TruncF32S,
+	"i32.trunc_f32_u":               OpI32TruncF32U,
+	"i32.trunc_f64_s":               OpI32TruncF64S,
+	"i32.trunc_f64_u":               OpI32TruncF64U,
+	"i64.extend_i32_s":              OpI64ExtendI32S,
+	"i64.extend_i32_u":              OpI64ExtendI32U,
+	"i64.trunc_f32_s":               OpI64TruncF32S,
+	"i64.trunc_f32_u":               OpI64TruncF32U,
+	"i64.trunc_f64_s":               OpI64TruncF64S,
+	"i64.trunc_f64_u":               OpI64TruncF64U,
+	"f32.convert_i32_s":             OpF32ConvertI32S,
+	"f32.convert_i32_u":             OpF32ConvertI32U,
+	"f32.convert_i64_s":             OpF32ConvertI64S,
+	"f32.convert_i64_u":             OpF32ConvertI64U,
+	"f32.demote_f64":                OpF32DemoteF64,
+	"f64.convert_i32_s":             OpF64ConvertI32S,
+	"f64.convert_i32_u":             OpF64ConvertI32U,
+	"f64.convert_i64_s":             OpF64ConvertI64S,
+	"f64.convert_i64_u":             OpF64ConvertI64U,
+	"f64.promote_f32":               OpF64PromoteF32,
+	"i32.reinterpret_f32":           OpI32ReinterpretF32,
+	"i64.reinterpret_f64":           OpI64ReinterpretF64,
+	"f32.reinterpret_i32":           OpF32ReinterpretI32,
+	"f64.reinterpret_i64":           OpF64ReinterpretI64,
+	"i32.extend8_s":                 OpI32Extend8S,
+	"i32.extend16_s":                OpI32Extend16S,
+	"i64.extend8_s":                 OpI64Extend8S,
+	"i64.extend16_s":                OpI64Extend16S,
+	"i64.extend32_s":                OpI64Extend32S,
+	"ref.null":                      OpRefNull,
+	"ref.is_null":                   OpRefIsNull,
+	"ref.func":                      OpRefFunc,
+	"i32.trunc_sat_f32_s":           OpI32TruncSatF32S,
+	"i32.trunc_sat_f32_u":           OpI32TruncSatF32U,
+	"i32.trunc_sat_f64_s":           OpI32TruncSatF64S,
+	"i32.trunc_sat_f64_u":           OpI32TruncSatF64U,
+	"i64.trunc_sat_f32_s":           OpI64TruncSatF32S,
+	"i64.trunc_sat_f32_u":           OpI64TruncSatF32U,
+	"i64.trunc_sat_f64_s":           OpI64TruncSatF64S,
+	"i64.trunc_sat_f64_u":           OpI64TruncSatF64U,
+	"memory.init":                   OpMemoryInit,
+	"data.drop":                     OpDataDrop,
+	"memory.copy":                   OpMemoryCopy,
+	"memory.fill":                   OpMemoryFill,
+	"table.init":                    OpTableInit,
+	"elem.drop":                     OpElemDrop,
+	"table.copy":                    OpTableCopy,
+	"table.grow":                    OpTableGrow,
+	"table.size":                    OpTableSize,
+	"table.fill":                    OpTableFill,
+	"v128.load":                     OpV128Load,
+	"v128.load8x8_s":                OpV128Load8x8S,
+	"v128.load8x8_u":                OpV128Load8x8U,
+	"v128.load16x4_s":               OpV128Load16x4S,
+	"v128.load16x4_u":               OpV128Load16x4U,
+	"v128.load32x2_s":               OpV128Load32x2S,
+	"v128.load32x2_u":               OpV128Load32x2U,
+	"v128.load8_splat":              OpV128Load8Splat,
+	"v128.load16_splat":             OpV128Load16Splat,
+	"v128.load32_splat":             OpV128Load32Splat,
+	"v128.load64_splat":             OpV128Load64Splat,
+	"v128.store":                    OpV128Store,
+	"v128.const":                    OpV128Const,
+	"i8x16.shuffle":                 OpI8x16Shuffle,
+	"i8x16.swizzle":                 OpI8x16Swizzle,
+	"i8x16.splat":                   OpI8x16Splat,
+	"i16x8.splat":                   OpI16x8Splat,
+	"i32x4.splat":                   OpI32x4Splat,
+	"i64x2.splat":                   OpI64x2Splat,
+	"f32x4.splat":                   OpF32x4Splat,
+	"f64x2.splat":                   OpF64x2Splat,
+	"i8x16.extract_lane_s":          OpI8x16ExtractLaneS,
+	"i8x16.extract_lane_u":          OpI8x16ExtractLaneU,
+	"i8x16.replace_lane":            OpI8x16ReplaceLane,
+	"i16x8.extract_lane_s":          OpI16x8ExtractLaneS,
+	"i16x8.extract_lane_u":          OpI16x8ExtractLaneU,
+	"i16x8.replace_lane":            OpI16x8ReplaceLane,
+	"i32x4.extract_lane":            OpI32x4ExtractLane,
+	"i32x4.replace_lane":            OpI32x4ReplaceLane,
+	"i64x2.extract_lane":            OpI64x2ExtractLane,
+	"i64x2.replace_lane":            OpI64x2ReplaceLane,
+	"f32x4.extract_lane":            OpF32x4ExtractLane,
+	"f32x4.replace_lane":            OpF32x4ReplaceLane,
+	"f64x2.extract_lane":            OpF64x2ExtractLane,
+	"f64x2.replace_lane":            OpF64x2ReplaceLane,
+	"i8x16.eq":                      OpI8x16Eq,
+	"i8x16.ne":                      OpI8x16Ne,
+	"i8x16.lt_s":                    OpI8x16LtS,
+	"i8x16.lt_u":                    OpI8x16LtU,
+	"i8x16.gt_s":                    OpI8x16GtS,
+	"i8x16.gt_u":                    OpI8x16GtU,
+	"i8x16.le_s":                    OpI8x16LeS,
+	"i8x16.le_u":                    OpI8x16LeU,
+	"i8x16.ge_s":                    OpI8x16GeS,
+	"i8x16.ge_u":                    OpI8x16GeU,
+	"i16x8.eq":                      OpI16x8Eq,
+	"i16x8.ne":                      OpI16x8Ne,
+	"i16x8.lt_s":                    OpI16x8LtS,
+	"i16x8.lt_u":                    OpI16x8LtU,
+	"i16x8.gt_s":                    OpI16x8GtS,
+	"i16x8.gt_u":                    OpI16x8GtU,
+	"i16x8.le_s":                    OpI16x8LeS,
+	"i16x8.le_u":                    OpI16x8LeU,
+	"i16x8.ge_s":                    OpI16x8GeS,
+	"i16x8.ge_u":                    OpI16x8GeU,
+	"i32x4.eq":                      OpI32x4Eq,
+	"i32x4.ne":                      OpI32x4Ne,
+	"i32x4.lt_s":                    OpI32x4LtS,
+	"i32x4.lt_u":                    OpI32x4LtU,
+	"i32x4.gt_s":                    OpI32x4GtS,
+	"i32x4.gt_u":                    OpI32x4GtU,
+	"i32x4.le_s":                    OpI32x4LeS,
+	"i32x4.le_u":                    OpI32x4LeU,
+	"i32x4.ge_s":                    OpI32x4GeS,
+	"i32x4.ge_u":                    OpI32x4GeU,
+	"f32x4.eq":                      OpF32x4Eq,
+	"f32x4.ne":                      OpF32x4Ne,
+	"f32x4.lt":                      OpF32x4Lt,
+	"f32x4.gt":                      OpF32x4Gt,
+	"f32x4.le":                      OpF32x4Le,
+	"f32x4.ge":                      OpF32x4Ge,
+	"f64x2.eq":                      OpF64x2Eq,
+	"f64x2.ne":                      OpF64x2Ne,
+	"f64x2.lt":                      OpF64x2Lt,
+	"f64x2.gt":                      OpF64x2Gt,
+	"f64x2.le":                      OpF64x2Le,
+	"f64x2.ge":                      OpF64x2Ge,
+	"v128.not":                      OpV128Not,
+	"v128.and":                      OpV128And,
+	"v128.andnot":                   OpV128Andnot,
+	"v128.or":                       OpV128Or,
+	"v128.xor":                      OpV128Xor,
+	"v128.bitselect":                OpV128Bitselect,
+	"v128.any_true":                 OpV128AnyTrue,
+	"v128.load8_lane":               OpV128Load8Lane,
+	"v128.load16_lane":              OpV128Load16Lane,
+	"v128.load32_lane":              OpV128Load32Lane,
+	"v128.load64_lane":              OpV128Load64Lane,
+	"v128.store8_lane":              OpV128Store8Lane,
+	"v128.store16_lane":             OpV128Store16Lane,
+	"v128.store32_lane":             OpV128Store32Lane,
+	"v128.store64_lane":             OpV128Store64Lane,
+	"v128.load32_zero":              OpV128Load32Zero,
+	"v128.load64_zero":              OpV128Load64Zero,
+	"f32x4.demote_f64x2_zero":       OpF32x4DemoteF64x2Zero,
+	"f64x2.promote_low_f32x4":       OpF64x2PromoteLowF32x4,
+	"i8x16.abs":                     OpI8x16Abs,
+	"i8x16.neg":                     OpI8x16Neg,
+	"i8x16.popcnt":                  OpI8x16Popcnt,
+	"i8x16.all_true":                OpI8x16AllTrue,
+	"i8x16.bitmask":                 OpI8x16Bitmask,
+	"i8x16.narrow_i16x8_s":          OpI8x16NarrowI16x8S,
+	"i8x16.narrow_i16x8_u":          OpI8x16NarrowI16x8U,
+	"f32x4.ceil":                    OpF32x4Ceil,
+	"f32x4.floor":                   OpF32x4Floor,
+	"f32x4.trunc":                   OpF32x4Trunc,
+	"f32x4.nearest":                 OpF32x4Nearest,
+	"i8x16.shl":                     OpI8x16Shl,
+	"i8x16.shr_s":                   OpI8x16ShrS,
+	"i8x16.shr_u":                   OpI8x16ShrU,
+	"i8x16.add":                     OpI8x16Add,
+	"i8x16.add_sat_s":               OpI8x16AddSatS,
+	"i8x16.add_sat_u":               OpI8x16AddSatU,
+	"i8x16.sub":                     OpI8x16Sub,
+	"i8x16.sub_sat_s":               OpI8x16SubSatS,
+	"i8x16.sub_sat_u":               OpI8x16SubSatU,
+	"f64x2.ceil":                    OpF64x2Ceil,
+	"f64x2.floor":                   OpF64x2Floor,
+	"i8x16.min_s":                   OpI8x16MinS,
+	"i8x16.min_u":                   OpI8x16MinU,
+	"i8x16.max_s":                   OpI8x16MaxS,
+	"i8x16.max_u":                   OpI8x16MaxU,
+	"f64x2.trunc":                   OpF64x2Trunc,
+	"i8x16.avgr_u":                  OpI8x16AvgrU,
+	"i16x8.extadd_pairwise_i8x16_s": OpI16x8ExtaddPairwiseI8x16S,
+	"i16x8.extadd_pairwise_i8x16_u": OpI16x8ExtaddPairwiseI8x16U,
+	"i32x4.extadd_pairwise_i16x8_s": OpI32x4ExtaddPairwiseI16x8S,
+	"i32x4.extadd_pairwise_i16x8_u": OpI32x4ExtaddPairwiseI16x8U,
+	"i16x8.abs":                     OpI16x8Abs,
+	"i16x8.neg":                     OpI16x8Neg,
+	"i16x8.q15mulr_sat_s":           OpI16x8Q15mulrSatS,
+	"i16x8.all_true":                OpI16x8AllTrue,
+	"i16x8.bitmask":                 OpI16x8Bitmask,
+	"i16x8.narrow_i32x4_s":          OpI16x8NarrowI32x4S,
+	"i16x8.narrow_i32x4_u":          OpI16x8NarrowI32x4U,
+	"i16x8.extend_low_i8x16_s":      OpI16x8ExtendLowI8x16S,
+	"i16x8.extend_high_i8x16_s":     OpI16x8ExtendHighI8x16S,
+	"i16x8.extend_low_i8x16_u":      OpI16x8ExtendLowI8x16U,
+	"i16x8.extend_high_i8x16_u":     OpI16x8ExtendHighI8x16U,
+	"i16x8.shl":                     OpI16x8Shl,
+	"i16x8.shr_s":                   OpI16x8ShrS,
+	"i16x8.shr_u":                   OpI16x8ShrU,
+	"i16x8.add":                     OpI16x8Add,
+	"i16x8.add_sat_s":               OpI16x8AddSatS,
+	"i16x8.add_sat_u":               OpI16x8AddSatU,
+	"i16x8.sub":                     OpI16x8Sub,
+	"i16x8.sub_sat_s":               OpI16x8SubSatS,
+	"i16x8.sub_sat_u":               OpI16x8SubSatU,
+	"f64x2.nearest":                 OpF64x2Nearest,
+	"i16x8.mul":                     OpI16x8Mul,
+	"i16x8.min_s":                   OpI16x8MinS,
+	"i16x8.min_u":                   OpI16x8MinU,
+	"i16x8.max_s":                   OpI16x8MaxS,
+	"i16x8.max_u":                   OpI16x8MaxU,
+	"i16x8.avgr_u":                  OpI16x8AvgrU,
+	"i16x8.extmul_low_i8x16_s":      OpI16x8ExtmulLowI8x16S,
+	"i16x8.extmul_high_i8x16_s":     OpI16x8ExtmulHighI8x16S,
+	"i16x8.extmul_low_i8x16_u":      OpI16x8ExtmulLowI8x16U,
+	"i16x8.extmul_high_i8x16_u":     OpI16x8ExtmulHighI8x16U,
+	"i32x4.abs":                     OpI32x4Abs,
+	"i32x4.neg":                     OpI32x4Neg,
+	"i32x4.all_true":                OpI32x4AllTrue,
+	"i32x4.bitmask":                 OpI32x4Bitmask,
+	"i32x4.extend_low_i16x8_s":      OpI32x4ExtendLowI16x8S,
+	"i32x4.extend_high_i16x8_s":     OpI32x4ExtendHighI16x8S,
+	"i32x4.extend_low_i16x8_u":      OpI32x4ExtendLowI16x8U,
+	"i32x4.extend_high_i16x8_u":     OpI32x4ExtendHighI16x8U,
+	"i32x4.shl":                     OpI32x4Shl,
+	"i32x4.shr_s":                   OpI32x4ShrS,
+	"i32x4.shr_u":                   OpI32x4ShrU,
+	"i32x4.add":                     OpI32x4Add,
+	"i32x4.sub":                     OpI32x4Sub,
+	"i32x4.mul":                     OpI32x4Mul,
+	"i32x4.min_s":                   OpI32x4MinS,
+	"i32x4.min_u":                   OpI32x4MinU,
+	"i32x4.max_s":                   OpI32x4MaxS,
+	"i32x4.max_u":                   OpI32x4MaxU,
+	"i32x4.dot_i16x8_s":             OpI32x4DotI16x8S,
+	"i32x4.extmul_low_i16x8_s":      OpI32x4ExtmulLowI16x8S,
+	"i32x4.extmul_high_i16x8_s":     OpI32x4ExtmulHighI16x8S,
+	"i32x4.extmul_low_i16x8_u":      OpI32x4ExtmulLowI16x8U,
+	"i32x4.extmul_high_i16x8_u":     OpI32x4ExtmulHighI16x8U,
+	"i64x2.abs":                     OpI64x2Abs,
+	"i64x2.neg":                     OpI64x2Neg,
+	"i64x2.all_true":                OpI64x2AllTrue,
+	"i64x2.bitmask":                 OpI64x2Bitmask,
+	"i64x2.extend_low_i32x4_s":      OpI64x2ExtendLowI32x4S,
+	"i64x2.extend_high_i32x4_s":     OpI64x2ExtendHighI32x4S,
+	"i64x2.extend_low_i32x4_u":      OpI64x2ExtendLowI32x4U,
+	"i64x2.extend_high_i32x4_u":     OpI64x2ExtendHighI32x4U,
+	"i64x2.shl":                     OpI64x2Shl,
+	"i64x2.shr_s":                   OpI64x2ShrS,
+	"i64x2.shr_u":                   OpI64x2ShrU,
+	"i64x2.add":                     OpI64x2Add,
+	"i64x2.sub":                     OpI64x2Sub,
+	"i64x2.mul":                     OpI64x2Mul,
+	"i64x2.eq":                      OpI64x2Eq,
+	"i64x2.ne":                      OpI64x2Ne,
+	"i64x2.lt_s":                    OpI64x2LtS,
+	"i64x2.gt_s":                    OpI64x2GtS,
+	"i64x2.le_s":                    OpI64x2LeS,
+	"i64x2.ge_s":                    OpI64x2GeS,
+	"i64x2.extmul_low_i32x4_s":      OpI64x2ExtmulLowI32x4S,
+	"i64x2.extmul_high_i32x4_s":     OpI64x2ExtmulHighI32x4S,
+	"i64x2.extmul_low_i32x4_u":      OpI64x2ExtmulLowI32x4U,
+	"i64x2.extmul_high_i32x4_u":     OpI64x2ExtmulHighI32x4U,
+	"f32x4.abs":                     OpF32x4Abs,
+	"f32x4.neg":                     OpF32x4Neg,
+	"f32x4.sqrt":                    OpF32x4Sqrt,
+	"f32x4.add":                     OpF32x4Add,
+	"f32x4.sub":                     OpF32x4Sub,
+	"f32x4.mul":                     OpF32x4Mul,
+	"f32x4.div":                     OpF32x4Div,
+	"f32x4.min":                     OpF32x4Min,
+	"f32x4.max":                     OpF32x4Max,
+	"f32x4.pmin":                    OpF32x4Pmin,
+	"f32x4.pmax":                    OpF32x4Pmax,
+	"f64x2.abs":                     OpF64x2Abs,
+	"f64x2.neg":                     OpF64x2Neg,
+	"f64x2.sqrt":                    OpF64x2Sqrt,
+	"f64x2.add":                     OpF64x2Add,
+	"f64x2.sub":                     OpF64x2Sub,
+	"f64x2.mul":                     OpF64x2Mul,
+	"f64x2.div":                     OpF64x2Div,
+	"f64x2.min":                     OpF64x2Min,
+	"f64x2.max":                     OpF64x2Max,
+	"f64x2.pmin":                    OpF64x2Pmin,
+	"f64x2.pmax":                    OpF64x2Pmax,
+	"i32x4.trunc_sat_f32x4_s":       OpI32x4TruncSatF32x4S,
+	"i32x4.trunc_sat_f32x4_u":       OpI32x4TruncSatF32x4U,
+	"f32x4.convert_i32x4_s":         OpF32x4ConvertI32x4S,
+	"f32x4.convert_i32x4_u":         OpF32x4ConvertI32x4U,
+	"i32x4.trunc_sat_f64x2_s_zero":  OpI32x4TruncSatF64x2SZero,
+	"i32x4.trunc_sat_f64x2_u_zero":  OpI32x4TruncSatF64x2UZero,
+	"f64x2.convert_low_i32x4_s":     OpF64x2ConvertLowI32x4S,
+	"f64x2.convert_low_i32x4_u":     OpF64x2ConvertLowI32x4U,
+	"memory.atomic.notify":          OpMemoryAtomicNotify,
+	"memory.atomic.wait32":          OpMemoryAtomicWait32,
+	"memory.atomic.wait64":          OpMemoryAtomicWait64,
+	"atomic.fence":                  OpAtomicFence,
+	"i32.atomic.load":               OpI32AtomicLoad,
+	"i64.atomic.load":               OpI64AtomicLoad,
+	"i32.atomic.load8_u":            OpI32AtomicLoad8U,
+	"i32.atomic.load16_u":           OpI32AtomicLoad16U,
+	"i64.atomic.load8_u":            OpI64AtomicLoad8U,
+	"i64.atomic.load16_u":           OpI64AtomicLoad16U,
+	"i64.atomic.load32_u":           OpI64AtomicLoad32U,
+	"i32.atomic.store":              OpI32AtomicStore,
+	"i64.atomic.store":              OpI64AtomicStore,
+	"i32.atomic.store8":             OpI32AtomicStore8,
+	"i32.atomic.store16":            OpI32AtomicStore16,
+	"i64.atomic.store8":             OpI64AtomicStore8,
+	"i64.atomic.store16":            OpI64AtomicStore16,
+	"i64.atomic.store32":            OpI64AtomicStore32,
+	"i32.atomic.rmw.add":            OpI32AtomicRmwAdd,
+	"i64.atomic.rmw.add":            OpI64AtomicRmwAdd,
+	"i32.atomic.rmw8.add_u":         OpI32AtomicRmw8AddU,
+	"i32.atomic.rmw16.add_u":        OpI32AtomicRmw16AddU,
+	"i64.atomic.rmw8.add_u":         OpI64AtomicRmw8AddU,
+	"i64.atomic.rmw16.add_u":        OpI64AtomicRmw16AddU,
+	"i64.atomic.rmw32.add_u":        OpI64AtomicRmw32AddU,
+	"i32.atomic.rmw.sub":            OpI32AtomicRmwSub,
+	"i64.atomic.rmw.sub":            OpI64AtomicRmwSub,
+	"i32.atomic.rmw8.sub_u":         OpI32AtomicRmw8SubU,
+	"i32.atomic.rmw16.sub_u":        OpI32AtomicRmw16SubU,
+	"i64.atomic.rmw8.sub_u":         OpI64AtomicRmw8SubU,
+	"i64.atomic.rmw16.sub_u":        OpI64AtomicRmw16SubU,
+	"i64.atomic.rmw32.sub_u":        OpI64AtomicRmw32SubU,
+	"i32.atomic.rmw.and":            OpI32AtomicRmwAnd,
+	"i64.atomic.rmw.and":            OpI64AtomicRmwAnd,
+	"i32.atomic.rmw8.and_u":         OpI32AtomicRmw8AndU,
+	"i32.atomic.rmw16.and_u":        OpI32AtomicRmw16AndU,
+	"i64.atomic.rmw8.and_u":         OpI64AtomicRmw8AndU,
+	"i64.atomic.rmw16.and_u":        OpI64AtomicRmw16AndU,
+	"i64.atomic.rmw32.and_u":        OpI64AtomicRmw32AndU,
+	"i32.atomic.rmw.or":             OpI32AtomicRmwOr,
+	"i64.atomic.rmw.or":             OpI64AtomicRmwOr,
+	"i32.atomic.rmw8.or_u":          OpI32AtomicRmw8OrU,
+	"i32.atomic.rmw16.or_u":         OpI32AtomicRmw16OrU,
+	"i64.atomic.rmw8.or_u":          OpI64AtomicRmw8OrU,
+	"i64.atomic.rmw16.or_u":         OpI64AtomicRmw16OrU,
+	"i64.atomic.rmw32.or_u":         OpI64AtomicRmw32OrU,
+	"i32.atomic.rmw.xor":            OpI32AtomicRmwXor,
+	"i64.atomic.rmw.xor":            OpI64AtomicRmwXor,
+	"i32.atomic.rmw8.xor_u":         OpI32AtomicRmw8XorU,
+	"i32.atomic.rmw16.xor_u":        OpI32AtomicRmw16XorU,
+	"i64.atomic.rmw8.xor_u":         OpI64AtomicRmw8XorU,
+	"i64.atomic.rmw16.xor_u":        OpI64AtomicRmw16XorU,
+	"i64.atomic.rmw32.xor_u":        OpI64AtomicRmw32XorU,
+	"i32.atomic.rmw.xchg":           OpI32AtomicRmwXchg,
+	"i64.atomic.rmw.xchg":           OpI64AtomicRmwXchg,
+	"i32.atomic.rmw8.xchg_u":        OpI32AtomicRmw8XchgU,
+	"i32.atomic.rmw16.xchg_u":       OpI32AtomicRmw16XchgU,
+	"i64.atomic.rmw8.xchg_u":        OpI64AtomicRmw8XchgU,
+	"i64.atomic.rmw16.xchg_u":       OpI64AtomicRmw16XchgU,
+	"i64.atomic.rmw32.xchg_u":       OpI64AtomicRmw32XchgU,
+	"i32.atomic.rmw.cmpxchg":        OpI32AtomicRmwCmpxchg,
+	"i64.atomic.rmw.cmpxchg":        OpI64AtomicRmwCmpxchg,
+	"i32.atomic.rmw8.cmpxchg_u":     OpI32AtomicRmw8CmpxchgU,
+	"i32.atomic.rmw16.cmpxchg_u":    OpI32AtomicRmw16CmpxchgU,
+	"i64.atomic.rmw8.cmpxchg_u":     OpI64AtomicRmw8CmpxchgU,
+	"i64.atomic.rmw16.cmpxchg_u":    OpI64AtomicRmw16CmpxchgU,
+	"i64.atomic.rmw32.cmpxchg_u":    OpI64AtomicRmw32CmpxchgU,
+}
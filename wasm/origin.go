@@ -0,0 +1,19 @@
+package wasm
+
+import (
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// Origin records where an item of a Module came from: its byte offset
+// within a binary module, or the wat.Node it was lowered from.
+type Origin struct {
+	Offset uint64
+	Node   *wat.Node
+}
+
+func (origin Origin) Span() wat.Span {
+	if origin.Node == nil {
+		return wat.Span{}
+	}
+	return origin.Node.Span
+}
@@ -0,0 +1,157 @@
+package wasm
+
+import (
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+// ProducersSectionName is the name of the custom section that holds
+// Producers.
+const ProducersSectionName = "producers"
+
+// Producers records the languages and tools that produced a module.  It
+// is the content of the "producers" custom section of the WebAssembly tool
+// conventions.  Within each field, the names are distinct.
+type Producers struct {
+	Language    []ProducerValue
+	ProcessedBy []ProducerValue
+	SDK         []ProducerValue
+}
+
+// ProducerValue is a language or tool, with its version if known.
+type ProducerValue struct {
+	Name    string
+	Version string
+}
+
+// Producers field names.
+const (
+	languageField    = "language"
+	processedByField = "processed-by"
+	sdkField         = "sdk"
+)
+
+func (p *Producers) IsEmpty() bool {
+	return p == nil || (len(p.Language) == 0 && len(p.ProcessedBy) == 0 && len(p.SDK) == 0)
+}
+
+// Producers decodes the module's "producers" section, or returns nil if
+// it has none.
+func (module *Module) Producers() (*Producers, error) {
+	custom := module.Custom(ProducersSectionName)
+	if custom == nil {
+		return nil, nil
+	}
+	return DecodeProducers(custom.Data)
+}
+
+// SetProducers replaces the module's "producers" section, or adds one.
+func (module *Module) SetProducers(p *Producers) {
+	module.ReplaceCustom(ProducersSectionName, EncodeProducers(p))
+}
+
+// Merge adds the values of other to p, as when combining modules.  A value
+// whose name p already has in the same field is left out, so the version
+// in p wins.
+func (p *Producers) Merge(other *Producers) {
+	if other == nil {
+		return
+	}
+	p.Language = mergeProducerValues(p.Language, other.Language)
+	p.ProcessedBy = mergeProducerValues(p.ProcessedBy, other.ProcessedBy)
+	p.SDK = mergeProducerValues(p.SDK, other.SDK)
+}
+
+func mergeProducerValues(list []ProducerValue, other []ProducerValue) []ProducerValue {
+	for _, value := range other {
+		found := false
+		for _, existing := range list {
+			found = found || existing.Name == value.Name
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// EncodeProducers returns the payload of a "producers" custom section,
+// without the section name itself.  Empty fields are left out.
+func EncodeProducers(p *Producers) []byte {
+	if p == nil {
+		return []byte{0}
+	}
+	var numFields uint32
+	var fields []byte
+	field := func(name string, list []ProducerValue) {
+		if len(list) == 0 {
+			return
+		}
+		numFields++
+		fields = appendName(fields, name)
+		fields = leb128.AppendUint32(fields, uint32(len(list)))
+		for _, value := range list {
+			fields = appendName(fields, value.Name)
+			fields = appendName(fields, value.Version)
+		}
+	}
+	field(languageField, p.Language)
+	field(processedByField, p.ProcessedBy)
+	field(sdkField, p.SDK)
+	out := leb128.AppendUint32(nil, numFields)
+	return append(out, fields...)
+}
+
+// DecodeProducers parses the payload of a "producers" custom section,
+// without the section name itself.  Fields other than those of Producers
+// are skipped.  Errors are of type *DecodeError, with offsets relative to
+// the start of data.
+func DecodeProducers(data []byte) (*Producers, error) {
+	d := &decoder{
+		data:  data,
+		end:   len(data),
+		bound: "producers section",
+		where: "producers section",
+	}
+	p := &Producers{}
+	seen := make(map[string]bool, 3)
+	for n := d.vec(); n > 0 && d.err == nil; n-- {
+		offset := d.pos
+		name := d.name()
+		if d.err == nil && seen[name] {
+			d.failf(offset, "duplicate field %q", name)
+		}
+		seen[name] = true
+		list := d.producerValues()
+		switch name {
+		case languageField:
+			p.Language = list
+		case processedByField:
+			p.ProcessedBy = list
+		case sdkField:
+			p.SDK = list
+		}
+	}
+	if d.err == nil && d.pos != len(d.data) {
+		d.failf(d.pos, "producers section size mismatch: %d bytes left over", len(d.data)-d.pos)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return p, nil
+}
+
+func (d *decoder) producerValues() []ProducerValue {
+	n := d.vec()
+	list := make([]ProducerValue, 0, n)
+	seen := make(map[string]bool, n)
+	for ; n > 0 && d.err == nil; n-- {
+		offset := d.pos
+		value := ProducerValue{Name: d.name(), Version: d.name()}
+		if d.err == nil && seen[value.Name] {
+			d.failf(offset, "duplicate value %q", value.Name)
+		}
+		seen[value.Name] = true
+		list = append(list, value)
+	}
+	return list
+}
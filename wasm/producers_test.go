@@ -0,0 +1,97 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeProducers(t *testing.T) {
+	p := &Producers{
+		Language:    []ProducerValue{{Name: "Go", Version: "1.19"}},
+		ProcessedBy: []ProducerValue{{Name: "wat2wasm"}},
+	}
+	expect := []byte{
+		0x02,
+		0x08, 'l', 'a', 'n', 'g', 'u', 'a', 'g', 'e', 0x01,
+		0x02, 'G', 'o', 0x04, '1', '.', '1', '9',
+		0x0c, 'p', 'r', 'o', 'c', 'e', 's', 's', 'e', 'd', '-', 'b', 'y', 0x01,
+		0x08, 'w', 'a', 't', '2', 'w', 'a', 's', 'm', 0x00,
+	}
+	if actual := EncodeProducers(p); !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+}
+
+func TestDecodeProducers(t *testing.T) {
+	p := &Producers{
+		Language:    []ProducerValue{{Name: "C", Version: ""}, {Name: "Rust", Version: "1.70"}},
+		ProcessedBy: []ProducerValue{{Name: "clang", Version: "16.0.0"}},
+		SDK:         []ProducerValue{{Name: "Emscripten", Version: "3.1"}},
+	}
+	actual, err := DecodeProducers(EncodeProducers(p))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual, p) {
+		t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", p, actual)
+	}
+}
+
+func TestDecodeProducers_Errors(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Input []byte
+	}
+
+	testData := [...]testCase{
+		{"Truncated", []byte{0x01, 0x03, 's', 'd', 'k', 0x01, 0x01}},
+		{"DuplicateField", []byte{0x02, 0x03, 's', 'd', 'k', 0x00, 0x03, 's', 'd', 'k', 0x00}},
+		{"DuplicateValue", []byte{0x01, 0x03, 's', 'd', 'k', 0x02, 0x01, 'a', 0x00, 0x01, 'a', 0x00}},
+		{"LeftOver", []byte{0x00, 0x00}},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := DecodeProducers(row.Input)
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Errorf("expected *DecodeError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProducers_Merge(t *testing.T) {
+	module := &Module{}
+	module.SetProducers(&Producers{
+		Language:    []ProducerValue{{Name: "C", Version: "11"}},
+		ProcessedBy: []ProducerValue{{Name: "clang", Version: "16"}},
+	})
+
+	p, err := module.Producers()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Merge(&Producers{
+		Language:    []ProducerValue{{Name: "Rust", Version: "1.70"}, {Name: "C", Version: "17"}},
+		ProcessedBy: []ProducerValue{{Name: "wasm-ld", Version: "16"}},
+	})
+	module.SetProducers(p)
+
+	actual, err := module.Producers()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expect := &Producers{
+		Language:    []ProducerValue{{Name: "C", Version: "11"}, {Name: "Rust", Version: "1.70"}},
+		ProcessedBy: []ProducerValue{{Name: "clang", Version: "16"}, {Name: "wasm-ld", Version: "16"}},
+	}
+	if !reflect.DeepEqual(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", expect, actual)
+	}
+	if len(module.Customs) != 1 {
+		t.Errorf("expect 1 custom section, got %d", len(module.Customs))
+	}
+}
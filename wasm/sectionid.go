@@ -0,0 +1,106 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+type SectionID byte
+
+const (
+	CustomSection SectionID = iota
+	TypeSection
+	ImportSection
+	FunctionSection
+	TableSection
+	MemorySection
+	GlobalSection
+	ExportSection
+	StartSection
+	ElementSection
+	CodeSection
+	DataSection
+	DataCountSection
+)
+
+var sectionIDGoNames = [...]string{
+	"wasm.CustomSection",
+	"wasm.TypeSection",
+	"wasm.ImportSection",
+	"wasm.FunctionSection",
+	"wasm.TableSection",
+	"wasm.MemorySection",
+	"wasm.GlobalSection",
+	"wasm.ExportSection",
+	"wasm.StartSection",
+	"wasm.ElementSection",
+	"wasm.CodeSection",
+	"wasm.DataSection",
+	"wasm.DataCountSection",
+}
+
+var sectionIDNames = [...]string{
+	"custom",
+	"type",
+	"import",
+	"function",
+	"table",
+	"memory",
+	"global",
+	"export",
+	"start",
+	"element",
+	"code",
+	"data",
+	"data count",
+}
+
+// sectionOrder gives the position of each known section in a module, as
+// the data count section is out of numeric order.
+var sectionOrder = [...]byte{
+	CustomSection:    0,
+	TypeSection:      1,
+	ImportSection:    2,
+	FunctionSection:  3,
+	TableSection:     4,
+	MemorySection:    5,
+	GlobalSection:    6,
+	ExportSection:    7,
+	StartSection:     8,
+	ElementSection:   9,
+	DataCountSection: 10,
+	CodeSection:      11,
+	DataSection:      12,
+}
+
+func (enum SectionID) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum SectionID) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum SectionID) AppendTo(out []byte, verbose bool) []byte {
+	names := sectionIDNames
+	if verbose {
+		names = sectionIDGoNames
+	}
+	var str string
+	if enum < SectionID(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wasm.SectionID(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+func (enum SectionID) IsKnown() bool {
+	return enum < SectionID(len(sectionIDNames))
+}
+
+var (
+	_ fmt.GoStringer = SectionID(0)
+	_ fmt.Stringer   = SectionID(0)
+)
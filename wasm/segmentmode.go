@@ -0,0 +1,54 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+type SegmentMode byte
+
+const (
+	ActiveSegment SegmentMode = iota
+	PassiveSegment
+	DeclarativeSegment
+)
+
+var segmentModeGoNames = [...]string{
+	"wasm.ActiveSegment",
+	"wasm.PassiveSegment",
+	"wasm.DeclarativeSegment",
+}
+
+var segmentModeNames = [...]string{
+	"active",
+	"passive",
+	"declare",
+}
+
+func (enum SegmentMode) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum SegmentMode) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum SegmentMode) AppendTo(out []byte, verbose bool) []byte {
+	names := segmentModeNames
+	if verbose {
+		names = segmentModeGoNames
+	}
+	var str string
+	if enum < SegmentMode(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wasm.SegmentMode(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = SegmentMode(0)
+	_ fmt.Stringer   = SegmentMode(0)
+)
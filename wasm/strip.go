@@ -0,0 +1,93 @@
+package wasm
+
+import (
+	"bytes"
+	"path"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+// Stripper removes custom sections from modules.  The zero value, or a nil
+// *Stripper, removes nothing.
+type Stripper struct {
+	all      bool
+	patterns []string
+	keep     []string
+}
+
+// Stripped is a custom section that Strip removed.  Size is the number of
+// bytes that Encode would have written for it, header included.
+type Stripped struct {
+	Name string
+	Size int
+}
+
+// Category removes the custom sections in category.
+func (s *Stripper) Category(category StripCategory) *Stripper {
+	s.all = s.all || category == StripAll
+	s.patterns = append(s.patterns, category.Patterns()...)
+	return s
+}
+
+// Pattern removes the custom sections whose names match pattern, a glob
+// as in path.Match.
+func (s *Stripper) Pattern(pattern string) *Stripper {
+	s.patterns = append(s.patterns, pattern)
+	return s
+}
+
+// Keep keeps the custom sections whose names match pattern, a glob as in
+// path.Match, even if they are in a category to be removed.
+func (s *Stripper) Keep(pattern string) *Stripper {
+	s.keep = append(s.keep, pattern)
+	return s
+}
+
+// Strip removes custom sections from the module and lists them in order.
+// If it removes the "name" section, it also sets Names to nil.  The only
+// error is path.ErrBadPattern, in which case the module is left alone.
+func (s *Stripper) Strip(module *Module) ([]Stripped, error) {
+	if s == nil {
+		return nil, nil
+	}
+	for _, list := range [...][]string{s.patterns, s.keep} {
+		for _, pattern := range list {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	removed := module.removeCustoms(func(custom *Custom) bool {
+		return (s.all || matchAny(s.patterns, custom.Name)) && !matchAny(s.keep, custom.Name)
+	})
+	stripped := make([]Stripped, 0, len(removed))
+	for _, custom := range removed {
+		stripped = append(stripped, Stripped{Name: custom.Name, Size: customSectionSize(module, custom)})
+	}
+	return stripped, nil
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// customSectionSize is the size of a custom section, header included.  For
+// one that Decode found and that has not changed since, this is its size
+// in the decoded module, padding and all; otherwise it is the size with
+// minimal LEB128 encodings.
+func customSectionSize(module *Module, custom *Custom) int {
+	payload := appendName(nil, custom.Name)
+	payload = append(payload, custom.Data...)
+	for _, section := range module.Sections {
+		if section.custom == custom && bytes.Equal(payload, section.canonical) {
+			return int(section.PayloadOffset-section.Offset) + len(section.Payload)
+		}
+	}
+	return 1 + int(leb128.LenUint(uint32(len(payload)))) + len(payload)
+}
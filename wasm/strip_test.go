@@ -0,0 +1,121 @@
+package wasm
+
+import (
+	"bytes"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestStripper(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Stripper *Stripper
+		Expect   []string
+		Kept     []string
+	}
+
+	input := bin(
+		sec(TypeSection, 0x00),
+		sec(CustomSection, 0x0b, '.', 'd', 'e', 'b', 'u', 'g', '_', 'i', 'n', 'f', 'o', 0x01),
+		sec(CustomSection, 0x0b, '.', 'd', 'e', 'b', 'u', 'g', '_', 'l', 'i', 'n', 'e', 0x02),
+		sec(CustomSection, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x02, 0x01, 'm'),
+		sec(CustomSection, 0x09, 'p', 'r', 'o', 'd', 'u', 'c', 'e', 'r', 's', 0x00),
+		sec(CustomSection, 0x01, 'x'),
+		// A padded size, as from a fixed-width encoder.
+		[]byte{0x00, 0x8c, 0x80, 0x80, 0x80, 0x00, 0x0b, 'v', 'e', 'n', 'd', 'o', 'r', '/', 'm', 'e', 't', 'a'},
+	)
+
+	testData := [...]testCase{
+		{
+			Name: "Nil",
+			Kept: []string{".debug_info", ".debug_line", "name", "producers", "x", "vendor/meta"},
+		},
+		{
+			Name:     "Debug",
+			Stripper: new(Stripper).Category(StripDebug),
+			Expect:   []string{".debug_info", ".debug_line"},
+			Kept:     []string{"name", "producers", "x", "vendor/meta"},
+		},
+		{
+			Name:     "NamesAndProducers",
+			Stripper: new(Stripper).Category(StripNames).Category(StripProducers),
+			Expect:   []string{"name", "producers"},
+			Kept:     []string{".debug_info", ".debug_line", "x", "vendor/meta"},
+		},
+		{
+			Name:     "All",
+			Stripper: new(Stripper).Category(StripAll),
+			Expect:   []string{".debug_info", ".debug_line", "name", "producers", "x", "vendor/meta"},
+		},
+		{
+			Name:     "AllButKept",
+			Stripper: new(Stripper).Category(StripAll).Keep(".debug_line").Keep("x"),
+			Expect:   []string{".debug_info", "name", "producers", "vendor/meta"},
+			Kept:     []string{".debug_line", "x"},
+		},
+		{
+			Name:     "Pattern",
+			Stripper: new(Stripper).Pattern("p*"),
+			Expect:   []string{"producers"},
+			Kept:     []string{".debug_info", ".debug_line", "name", "x", "vendor/meta"},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			module, err := Decode(input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			before, _ := Encode(module)
+			stripped, err := row.Stripper.Strip(module)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			after, err := Encode(module)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var names []string
+			saved := 0
+			for _, s := range stripped {
+				names = append(names, s.Name)
+				saved += s.Size
+			}
+			if !reflect.DeepEqual(names, row.Expect) {
+				t.Errorf("wrong stripped sections:\n\texpect: %q\n\tactual: %q", row.Expect, names)
+			}
+			if actual := len(before) - len(after); actual != saved {
+				t.Errorf("wrong sizes: removed %d bytes, reported %d", actual, saved)
+			}
+
+			var kept []string
+			for _, custom := range module.Customs {
+				kept = append(kept, custom.Name)
+			}
+			if !reflect.DeepEqual(kept, row.Kept) {
+				t.Errorf("wrong kept sections:\n\texpect: %q\n\tactual: %q", row.Kept, kept)
+			}
+			if hasNames := module.Names != nil; hasNames != (module.Custom(NameSectionName) != nil) {
+				t.Errorf("Names and name section disagree: %+v", module.Names)
+			}
+		})
+	}
+}
+
+func TestStripper_BadPattern(t *testing.T) {
+	input := bin(sec(CustomSection, 0x01, 'x'))
+	module, err := Decode(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = new(Stripper).Category(StripAll).Keep("[").Strip(module)
+	if err != path.ErrBadPattern {
+		t.Errorf("expect path.ErrBadPattern, got %v", err)
+	}
+	if actual, _ := Encode(module); !bytes.Equal(actual, input) {
+		t.Errorf("module changed:\n\texpect: % x\n\tactual: % x", input, actual)
+	}
+}
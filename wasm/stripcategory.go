@@ -0,0 +1,93 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+// StripCategory is a group of custom sections that a Stripper removes
+// together.
+type StripCategory byte
+
+const (
+	// StripDebug is debug info: DWARF ".debug_*" sections and the
+	// "sourceMappingURL" and "external_debug_info" sections.
+	StripDebug StripCategory = iota
+
+	// StripNames is the "name" section.
+	StripNames
+
+	// StripProducers is the "producers" section.
+	StripProducers
+
+	// StripAll is every custom section.
+	StripAll
+)
+
+var stripCategoryGoNames = [...]string{
+	"wasm.StripDebug",
+	"wasm.StripNames",
+	"wasm.StripProducers",
+	"wasm.StripAll",
+}
+
+var stripCategoryNames = [...]string{
+	"debug",
+	"names",
+	"producers",
+	"all",
+}
+
+var stripCategoryPatterns = [...][]string{
+	{".debug_*", "sourceMappingURL", "external_debug_info"},
+	{NameSectionName},
+	{ProducersSectionName},
+	nil,
+}
+
+func StripCategoryByName(name string) (StripCategory, bool) {
+	for i, str := range stripCategoryNames {
+		if str == name {
+			return StripCategory(i), true
+		}
+	}
+	return 0, false
+}
+
+// Patterns returns the globs, as in path.Match, that match the names of
+// the custom sections in the category.  StripAll has none: it matches
+// every name, even one with a slash, which "*" does not.
+func (enum StripCategory) Patterns() []string {
+	if enum < StripCategory(len(stripCategoryPatterns)) {
+		return stripCategoryPatterns[enum]
+	}
+	return nil
+}
+
+func (enum StripCategory) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum StripCategory) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum StripCategory) AppendTo(out []byte, verbose bool) []byte {
+	names := stripCategoryNames
+	if verbose {
+		names = stripCategoryGoNames
+	}
+	var str string
+	if enum < StripCategory(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wasm.StripCategory(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = StripCategory(0)
+	_ fmt.Stringer   = StripCategory(0)
+)
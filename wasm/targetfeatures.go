@@ -0,0 +1,130 @@
+package wasm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/leb128"
+)
+
+// TargetFeaturesSectionName is the name of the custom section that holds
+// a list of TargetFeature.
+const TargetFeaturesSectionName = "target_features"
+
+// TargetFeature is an entry of the "target_features" custom section of the
+// WebAssembly tool conventions.  Name is as in Feature.TargetName, though
+// the section may also name features that Feature does not know.
+type TargetFeature struct {
+	Policy FeaturePolicy
+	Name   string
+}
+
+// EncodeTargetFeatures returns the payload of a "target_features" custom
+// section, without the section name itself.  It fails if a Policy is not
+// one of the FeaturePolicy constants.
+func EncodeTargetFeatures(list []TargetFeature) ([]byte, error) {
+	out := leb128.AppendUint32(nil, uint32(len(list)))
+	for i, tf := range list {
+		if tf.Policy >= FeaturePolicy(len(featurePolicyNames)) {
+			return nil, fmt.Errorf("wasm: cannot encode target feature %d: invalid policy %#v", i, tf.Policy)
+		}
+		out = append(out, featurePolicyNames[tf.Policy][0])
+		out = appendName(out, tf.Name)
+	}
+	return out, nil
+}
+
+// DecodeTargetFeatures parses the payload of a "target_features" custom
+// section, without the section name itself.  Errors are of type
+// *DecodeError, with offsets relative to the start of data.
+func DecodeTargetFeatures(data []byte) ([]TargetFeature, error) {
+	d := &decoder{
+		data:  data,
+		end:   len(data),
+		bound: "target_features section",
+		where: "target_features section",
+	}
+	n := d.vec()
+	list := make([]TargetFeature, 0, n)
+	for ; n > 0 && d.err == nil; n-- {
+		offset := d.pos
+		prefix := d.byte()
+		policy, ok := FeaturePolicyByPrefix(prefix)
+		if d.err == nil && !ok {
+			d.failf(offset, "unknown feature prefix %q", prefix)
+		}
+		list = append(list, TargetFeature{Policy: policy, Name: d.name()})
+	}
+	if d.err == nil && d.pos != len(d.data) {
+		d.failf(d.pos, "target_features section size mismatch: %d bytes left over", len(d.data)-d.pos)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return list, nil
+}
+
+// TargetFeaturesOf lists the features of set as used.
+func TargetFeaturesOf(set Features) []TargetFeature {
+	list := make([]TargetFeature, 0, numFeatures)
+	for _, feature := range set.List() {
+		list = append(list, TargetFeature{Policy: UsedFeature, Name: feature.TargetName()})
+	}
+	return list
+}
+
+// TargetFeatures decodes the module's "target_features" section, or
+// returns nil if it has none.
+func (module *Module) TargetFeatures() ([]TargetFeature, error) {
+	custom := module.Custom(TargetFeaturesSectionName)
+	if custom == nil {
+		return nil, nil
+	}
+	return DecodeTargetFeatures(custom.Data)
+}
+
+// SetTargetFeatures replaces the module's "target_features" section, or
+// adds one.  On error, the module is left alone.
+func (module *Module) SetTargetFeatures(list []TargetFeature) error {
+	data, err := EncodeTargetFeatures(list)
+	if err != nil {
+		return err
+	}
+	module.ReplaceCustom(TargetFeaturesSectionName, data)
+	return nil
+}
+
+// CheckTargetFeatures checks the features that the module declares in its
+// "target_features" section, if it has one, against enabled, the features
+// that a validator accepts.  Every feature that the module uses or requires
+// must be enabled, and the module must not depend on a feature that it
+// disallows.
+//
+// Features that Feature does not know are ignored.  Toolchains declare
+// features, such as bulk-memory-opt, that make no difference to what this
+// package decodes and validates; if one did, validation would catch it.
+func (module *Module) CheckTargetFeatures(enabled Features) error {
+	list, err := module.TargetFeatures()
+	if err != nil || list == nil {
+		return err
+	}
+
+	var problems []string
+	required := module.RequiredFeatures()
+	for _, tf := range list {
+		feature, known := FeatureByTargetName(tf.Name)
+		switch {
+		case tf.Policy == DisallowedFeature:
+			if known && required.Has(feature) {
+				problems = append(problems, fmt.Sprintf("%s is disallowed but used", tf.Name))
+			}
+		case !known:
+		case !enabled.Has(feature):
+			problems = append(problems, fmt.Sprintf("%s is not enabled", tf.Name))
+		}
+	}
+	if problems != nil {
+		return fmt.Errorf("wasm: target features: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeTargetFeatures(t *testing.T) {
+	list := []TargetFeature{
+		{Policy: UsedFeature, Name: "simd128"},
+		{Policy: DisallowedFeature, Name: "atomics"},
+	}
+	expect := []byte{
+		0x02,
+		'+', 0x07, 's', 'i', 'm', 'd', '1', '2', '8',
+		'-', 0x07, 'a', 't', 'o', 'm', 'i', 'c', 's',
+	}
+	actual, err := EncodeTargetFeatures(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: % x\n\tactual: % x", expect, actual)
+	}
+
+	decoded, err := DecodeTargetFeatures(actual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, list) {
+		t.Errorf("wrong round trip:\n\texpect: %+v\n\tactual: %+v", list, decoded)
+	}
+}
+
+func TestEncodeTargetFeatures_BadPolicy(t *testing.T) {
+	module := &Module{}
+	err := module.SetTargetFeatures([]TargetFeature{{Policy: FeaturePolicy(3), Name: "simd128"}})
+	expect := "wasm: cannot encode target feature 0: invalid policy wasm.FeaturePolicy(3)"
+	if err == nil || err.Error() != expect {
+		t.Errorf("wrong error:\n\texpect: %s\n\tactual: %v", expect, err)
+	}
+	if len(module.Customs) != 0 {
+		t.Errorf("expect no custom sections, got %d", len(module.Customs))
+	}
+}
+
+func TestDecodeTargetFeatures_Errors(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Input []byte
+	}
+
+	testData := [...]testCase{
+		{"BadPrefix", []byte{0x01, '*', 0x01, 'x'}},
+		{"Truncated", []byte{0x01, '+', 0x05, 'x'}},
+		{"LeftOver", []byte{0x00, '+'}},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := DecodeTargetFeatures(row.Input)
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Errorf("expected *DecodeError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestModule_CheckTargetFeatures(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Features []TargetFeature
+		Enabled  Features
+		Expect   string
+	}
+
+	// The module uses SIMD.
+	module := &Module{Types: []*FuncType{{Params: []ValType{V128}}}}
+
+	testData := [...]testCase{
+		{"None", nil, 0, ""},
+		{"Enabled", TargetFeaturesOf(Features(0).With(SIMDFeature)), DefaultFeatures, ""},
+		{"NotEnabled", []TargetFeature{{RequiredFeature, "simd128"}, {UsedFeature, "atomics"}}, DefaultFeatures, "wasm: target features: atomics is not enabled"},
+		{"Unknown", []TargetFeature{{UsedFeature, "bulk-memory-opt"}, {RequiredFeature, "call-indirect-overlong"}}, DefaultFeatures, ""},
+		{"Disallowed", []TargetFeature{{DisallowedFeature, "simd128"}, {DisallowedFeature, "atomics"}}, AllFeatures, "wasm: target features: simd128 is disallowed but used"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			m := *module
+			m.Customs = nil
+			if row.Features != nil {
+				if err := m.SetTargetFeatures(row.Features); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			var actual string
+			if err := m.CheckTargetFeatures(row.Enabled); err != nil {
+				actual = err.Error()
+			}
+			if actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package text
+
+import (
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// constExpr lowers a constant expression, written with plain or folded
+// instructions, to its binary encoding.  The instructions of the
+// extended-const proposal are allowed.
+func (lw *lowerer) constExpr(parent *wat.Node, list []*wat.Node) wasm.Expr {
+	instrs := lw.body(lw.cursor(parent, list), nil)
+	for _, instr := range instrs {
+		if !instr.Opcode.IsConstant() {
+			lw.errorf(instr.Origin.Node, "instruction %q is not allowed in a constant expression", instr.Opcode.String())
+		}
+	}
+	instrs = append(instrs, wasm.Instr{Opcode: wasm.OpEnd, Origin: origin(parent)})
+	return wasm.EncodeExpr(instrs)
+}
@@ -0,0 +1,185 @@
+package text
+
+import (
+	"strconv"
+
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// cursor walks the significant children of an ExprNode, skipping over
+// spaces, comments and annotations.
+type cursor struct {
+	lw   *lowerer
+	node *wat.Node
+	list []*wat.Node
+}
+
+func (lw *lowerer) cursor(node *wat.Node, list []*wat.Node) *cursor {
+	return &cursor{lw: lw, node: node, list: list}
+}
+
+// open returns a cursor positioned just after the keyword that begins
+// the ExprNode, or nil if the node is not such an ExprNode.
+func (lw *lowerer) open(node *wat.Node) *cursor {
+	list := items(node)
+	if head(node) == "" {
+		return nil
+	}
+	return lw.cursor(node, list[1:])
+}
+
+func (c *cursor) done() bool {
+	return len(c.list) == 0
+}
+
+func (c *cursor) peek() *wat.Node {
+	if len(c.list) == 0 {
+		return nil
+	}
+	return c.list[0]
+}
+
+func (c *cursor) next() *wat.Node {
+	if len(c.list) == 0 {
+		return nil
+	}
+	node := c.list[0]
+	c.list = c.list[1:]
+	return node
+}
+
+func (c *cursor) rest() []*wat.Node {
+	list := c.list
+	c.list = nil
+	return list
+}
+
+func (c *cursor) peekHead() string {
+	return head(c.peek())
+}
+
+func (c *cursor) peekKeyword() string {
+	node := c.peek()
+	if node == nil || node.Type != wat.KeywordNode {
+		return ""
+	}
+	return node.Value.(string)
+}
+
+func (c *cursor) optKeyword(keyword string) bool {
+	if c.peekKeyword() == keyword {
+		c.next()
+		return true
+	}
+	return false
+}
+
+func (c *cursor) optID() (string, *wat.Node) {
+	node := c.peek()
+	if node == nil || node.Type != wat.IdentifierNode {
+		return "", nil
+	}
+	c.next()
+	return node.Value.(string)[1:], node
+}
+
+func (c *cursor) optExpr(keyword string) *wat.Node {
+	if c.peekHead() == keyword {
+		return c.next()
+	}
+	return nil
+}
+
+func (c *cursor) expectExpr(keyword string) *wat.Node {
+	if node := c.optExpr(keyword); node != nil {
+		return node
+	}
+	c.missing("(" + keyword + " ...)")
+	return nil
+}
+
+func (c *cursor) expectString() (string, bool) {
+	node := c.peek()
+	if node == nil || node.Type != wat.StringNode {
+		c.missing("string")
+		return "", false
+	}
+	c.next()
+	return node.Value.(string), true
+}
+
+func (c *cursor) expectEnd() {
+	if node := c.peek(); node != nil {
+		c.lw.unexpected(node)
+		c.list = nil
+	}
+}
+
+// missing reports that the cursor's next node, or the end of the
+// enclosing expression, is not what was expected.
+func (c *cursor) missing(expect string) {
+	if node := c.peek(); node != nil {
+		c.lw.unexpected(node, expect)
+		c.list = nil
+		return
+	}
+	c.lw.errorf(c.node, "expect %s before ')'", expect)
+}
+
+func items(node *wat.Node) []*wat.Node {
+	if node == nil || node.Type != wat.ExprNode {
+		return nil
+	}
+	all := node.Value.([]*wat.Node)
+	list := make([]*wat.Node, 0, len(all))
+	for _, child := range all {
+		switch child.Type {
+		case wat.SpaceNode:
+		case wat.LineCommentNode:
+		case wat.BlockCommentNode:
+		case wat.AnnotationNode:
+		default:
+			list = append(list, child)
+		}
+	}
+	return list
+}
+
+func head(node *wat.Node) string {
+	if node == nil || node.Type != wat.ExprNode {
+		return ""
+	}
+	for _, child := range node.Value.([]*wat.Node) {
+		switch child.Type {
+		case wat.SpaceNode:
+		case wat.LineCommentNode:
+		case wat.BlockCommentNode:
+		case wat.AnnotationNode:
+		case wat.KeywordNode:
+			return child.Value.(string)
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+func describe(node *wat.Node) string {
+	switch node.Type {
+	case wat.ExprNode:
+		if kw := head(node); kw != "" {
+			return "(" + kw + " ...)"
+		}
+		return "expression"
+	case wat.KeywordNode:
+		return "keyword " + strconv.Quote(node.Value.(string))
+	case wat.IdentifierNode:
+		return "identifier " + node.Value.(string)
+	case wat.StringNode:
+		return "string " + strconv.Quote(node.Value.(string))
+	case wat.NumberNode:
+		return "number " + node.Value.(wat.Num).String()
+	default:
+		return node.Type.String()
+	}
+}
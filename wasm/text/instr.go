@@ -0,0 +1,485 @@
+package text
+
+import (
+	"math/bits"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// body lowers every instruction that remains in the cursor, reporting any
+// "end" or "else" that does not close a block.
+func (lw *lowerer) body(c *cursor, out []wasm.Instr) []wasm.Instr {
+	for {
+		out = lw.instrs(c, out)
+		if c.done() {
+			return out
+		}
+		lw.unexpected(c.next(), "instruction")
+	}
+}
+
+// instrs lowers plain and folded instructions until the end of the cursor
+// or until an "end" or "else", which is left for the caller.
+func (lw *lowerer) instrs(c *cursor, out []wasm.Instr) []wasm.Instr {
+	for !c.done() {
+		node := c.peek()
+		switch kw := c.peekKeyword(); {
+		case kw == "end" || kw == "else":
+			return out
+		case kw != "":
+			c.next()
+			out = lw.plainInstr(node, kw, c, out)
+		case head(node) != "":
+			c.next()
+			out = lw.foldedInstr(node, out)
+		default:
+			c.next()
+			lw.unexpected(node, "instruction")
+		}
+	}
+	return out
+}
+
+func (lw *lowerer) plainInstr(node *wat.Node, kw string, c *cursor, out []wasm.Instr) []wasm.Instr {
+	switch kw {
+	case "block", "loop", "if":
+		op, _ := wasm.OpcodeByName(kw)
+		label := lw.pushLabel(c)
+		out = append(out, wasm.Instr{Opcode: op, BlockType: lw.blockType(node, c), Origin: origin(node)})
+		out = lw.instrs(c, out)
+		if kw == "if" && c.peekKeyword() == "else" {
+			elseNode := c.next()
+			lw.closeLabel(c, label)
+			out = append(out, wasm.Instr{Opcode: wasm.OpElse, Origin: origin(elseNode)})
+			out = lw.instrs(c, out)
+		}
+		lw.popLabel()
+		if c.peekKeyword() != "end" {
+			c.missing(`"end"`)
+			return out
+		}
+		endNode := c.next()
+		lw.closeLabel(c, label)
+		return append(out, wasm.Instr{Opcode: wasm.OpEnd, Origin: origin(endNode)})
+	}
+
+	if instr, ok := lw.instr(node, kw, c); ok {
+		out = append(out, instr)
+	}
+	return out
+}
+
+// foldedInstr lowers an instruction written as an S-expression, whose
+// operands are lowered before it.
+func (lw *lowerer) foldedInstr(node *wat.Node, out []wasm.Instr) []wasm.Instr {
+	c := lw.open(node)
+	switch kw := head(node); kw {
+	case "block", "loop":
+		op, _ := wasm.OpcodeByName(kw)
+		lw.pushLabel(c)
+		out = append(out, wasm.Instr{Opcode: op, BlockType: lw.blockType(node, c), Origin: origin(node)})
+		out = lw.body(c, out)
+		lw.popLabel()
+		return append(out, wasm.Instr{Opcode: wasm.OpEnd, Origin: origin(node)})
+
+	case "if":
+		// The condition is outside of the block, so the label is not
+		// in scope until (then ...).
+		id, _ := c.optID()
+		instr := wasm.Instr{Opcode: wasm.OpIf, BlockType: lw.blockType(node, c), Origin: origin(node)}
+		for !c.done() && c.peekHead() != "then" {
+			out = lw.operand(c.next(), out)
+		}
+		lw.enterLabel(id)
+		out = append(out, instr)
+		if then := c.expectExpr("then"); then != nil {
+			out = lw.body(lw.open(then), out)
+		}
+		if els := c.optExpr("else"); els != nil {
+			out = append(out, wasm.Instr{Opcode: wasm.OpElse, Origin: origin(els)})
+			out = lw.body(lw.open(els), out)
+		}
+		c.expectEnd()
+		lw.popLabel()
+		return append(out, wasm.Instr{Opcode: wasm.OpEnd, Origin: origin(node)})
+	}
+
+	instr, ok := lw.instr(node, head(node), c)
+	for !c.done() {
+		out = lw.operand(c.next(), out)
+	}
+	if ok {
+		out = append(out, instr)
+	}
+	return out
+}
+
+func (lw *lowerer) operand(node *wat.Node, out []wasm.Instr) []wasm.Instr {
+	if head(node) == "" {
+		lw.unexpected(node, "folded instruction")
+		return out
+	}
+	return lw.foldedInstr(node, out)
+}
+
+// instr lowers an instruction other than block, loop and if, along with
+// its immediates.
+func (lw *lowerer) instr(node *wat.Node, kw string, c *cursor) (wasm.Instr, bool) {
+	op, ok := wasm.OpcodeByName(kw)
+	switch {
+	case !ok:
+		lw.errorf(node, "unknown instruction %q", kw)
+		for isImmediate(c.peek()) {
+			c.next()
+		}
+		return wasm.Instr{}, false
+	case op == wasm.OpElse || op == wasm.OpEnd:
+		lw.unexpected(node, "instruction")
+		return wasm.Instr{}, false
+	}
+
+	instr := wasm.Instr{Opcode: op, Origin: origin(node)}
+	info := op.Info()
+	switch op {
+	case wasm.OpMemoryInit, wasm.OpDataDrop:
+		lw.usesDataCount = true
+
+	case wasm.OpSelect:
+		lw.results(c, &instr.Types)
+		if instr.Types != nil {
+			instr.Opcode = wasm.OpSelectT
+		}
+		return instr, true
+
+	case wasm.OpCallIndirect:
+		if isIndex(c.peek()) {
+			instr.Index2, _ = lw.index(c, tableSpace)
+		}
+		var names []string
+		instr.Index, names = lw.typeUse(c)
+		lw.anonymous(node, names)
+		return instr, true
+
+	case wasm.OpTableInit:
+		if len(c.list) >= 2 && isIndex(c.list[0]) && isIndex(c.list[1]) {
+			instr.Index2, _ = lw.index(c, tableSpace)
+		}
+		instr.Index, _ = lw.index(c, elemSpace)
+		return instr, true
+
+	case wasm.OpTableCopy:
+		if isIndex(c.peek()) {
+			instr.Index, _ = lw.index(c, tableSpace)
+			instr.Index2, _ = lw.index(c, tableSpace)
+		}
+		return instr, true
+	}
+
+	for _, imm := range info.Immediates {
+		switch imm {
+		case wasm.LabelImmediate:
+			instr.Index = lw.label(c)
+		case wasm.LabelVecImmediate:
+			for isIndex(c.peek()) {
+				instr.Labels = append(instr.Labels, lw.label(c))
+			}
+			if instr.Labels == nil {
+				c.missing("label index")
+			}
+		case wasm.FuncImmediate:
+			instr.Index, _ = lw.index(c, funcSpace)
+		case wasm.TableImmediate:
+			if isIndex(c.peek()) {
+				instr.Index, _ = lw.index(c, tableSpace)
+			}
+		case wasm.LocalImmediate:
+			instr.Index = lw.local(c)
+		case wasm.GlobalImmediate:
+			instr.Index, _ = lw.index(c, globalSpace)
+		case wasm.ElemImmediate:
+			instr.Index, _ = lw.index(c, elemSpace)
+		case wasm.DataImmediate:
+			instr.Index, _ = lw.index(c, dataSpace)
+		case wasm.MemArgImmediate:
+			instr.MemArg = lw.memArg(c, info.NaturalAlign)
+		case wasm.LaneImmediate:
+			instr.Lane = lw.lane(c)
+		case wasm.ShuffleImmediate:
+			for i := range instr.V128 {
+				instr.V128[i] = lw.lane(c)
+			}
+		case wasm.I32Immediate, wasm.I64Immediate, wasm.F32Immediate, wasm.F64Immediate:
+			instr.Value = lw.constValue(c, imm)
+		case wasm.V128Immediate:
+			lw.v128(c, &instr)
+		case wasm.HeapTypeImmediate:
+			instr.HeapType = lw.heapType(c)
+		}
+	}
+	return instr, true
+}
+
+// blockType lowers the type of a block, which is written like the type
+// use of a function but may also be a single result type.
+func (lw *lowerer) blockType(node *wat.Node, c *cursor) wasm.BlockType {
+	if c.peekHead() == "type" {
+		index, names := lw.typeUse(c)
+		lw.anonymous(node, names)
+		return wasm.TypeIndexBlock(index)
+	}
+
+	ft := &wasm.FuncType{}
+	var names []string
+	lw.params(c, &ft.Params, &names)
+	lw.results(c, &ft.Results)
+	lw.anonymous(node, names)
+	switch {
+	case len(ft.Params) == 0 && len(ft.Results) == 0:
+		return wasm.EmptyBlock
+	case len(ft.Params) == 0 && len(ft.Results) == 1:
+		return wasm.ValTypeBlock(ft.Results[0])
+	default:
+		return wasm.TypeIndexBlock(lw.internType(ft))
+	}
+}
+
+// anonymous reports parameter identifiers where they are not allowed.
+func (lw *lowerer) anonymous(node *wat.Node, names []string) {
+	for _, name := range names {
+		if name != "" {
+			lw.errorf(node, "parameter $%s of %s must not have an identifier", name, head(node))
+			return
+		}
+	}
+}
+
+func (lw *lowerer) pushLabel(c *cursor) string {
+	id, _ := c.optID()
+	lw.enterLabel(id)
+	return id
+}
+
+// enterLabel brings the label of a block into scope, just before the
+// block instruction itself is lowered.
+func (lw *lowerer) enterLabel(id string) {
+	lw.labels = append(lw.labels, id)
+	if id != "" {
+		if lw.labelNames == nil {
+			lw.labelNames = make(wasm.NameMap, 4)
+		}
+		lw.labelNames[lw.numLabels] = id
+	}
+	lw.numLabels++
+}
+
+func (lw *lowerer) popLabel() {
+	lw.labels = lw.labels[:len(lw.labels)-1]
+}
+
+// closeLabel checks the optional identifier after "else" or "end".
+func (lw *lowerer) closeLabel(c *cursor, label string) {
+	if id, idNode := c.optID(); id != "" && id != label {
+		lw.errorf(idNode, "mismatching label $%s", id)
+	}
+}
+
+// label lowers a reference to an enclosing block, by identifier or by
+// depth, to its depth.
+func (lw *lowerer) label(c *cursor) uint32 {
+	node := c.peek()
+	if node != nil && node.Type == wat.IdentifierNode {
+		c.next()
+		id := node.Value.(string)[1:]
+		for i := len(lw.labels) - 1; i >= 0; i-- {
+			if lw.labels[i] == id {
+				return uint32(len(lw.labels) - 1 - i)
+			}
+		}
+		lw.errorf(node, "unknown label $%s", id)
+		return 0
+	}
+	if !isNumber(node) {
+		c.missing("label index")
+		return 0
+	}
+	depth, _ := lw.u32(c)
+	return depth
+}
+
+func (lw *lowerer) local(c *cursor) uint32 {
+	node := c.peek()
+	if node != nil && node.Type == wat.IdentifierNode {
+		c.next()
+		id := node.Value.(string)[1:]
+		if index, found := lw.locals[id]; found {
+			return index
+		}
+		lw.errorf(node, "unknown local $%s", id)
+		return 0
+	}
+	if !isNumber(node) {
+		c.missing("local index")
+		return 0
+	}
+	index, _ := lw.u32(c)
+	return index
+}
+
+func (lw *lowerer) memArg(c *cursor, naturalAlign uint32) wasm.MemArg {
+	memArg := wasm.MemArg{Align: naturalAlign}
+	if kw := c.peekKeyword(); strings.HasPrefix(kw, "offset=") {
+		memArg.Offset, _ = lw.keywordU32(c.next(), kw[len("offset="):])
+	}
+	if kw := c.peekKeyword(); strings.HasPrefix(kw, "align=") {
+		node := c.next()
+		if align, ok := lw.keywordU32(node, kw[len("align="):]); ok {
+			if align == 0 || (align&(align-1)) != 0 {
+				lw.errorf(node, "alignment %d is not a power of two", align)
+			} else {
+				memArg.Align = uint32(bits.TrailingZeros32(align))
+			}
+		}
+	}
+	return memArg
+}
+
+// keywordU32 parses the number after the '=' of a keyword like
+// "offset=0x10".
+func (lw *lowerer) keywordU32(node *wat.Node, str string) (uint32, bool) {
+	lexer := wat.NewLexer([]byte(str))
+	lexer.HasNext()
+	token := lexer.Next()
+	num, ok := token.Value.(wat.Num)
+	if !ok || token.Span.End.ByteOffset != uint64(len(str)) || num.Flags.HasAny(wat.FlagSign|wat.FlagFloat) {
+		lw.errorf(node, "expect u32 in %q", node.Value.(string))
+		return 0, false
+	}
+	u32, err := num.Uint32()
+	if err != nil {
+		lw.wrapf(node, err, "invalid u32")
+		return 0, false
+	}
+	return u32, true
+}
+
+func (lw *lowerer) lane(c *cursor) byte {
+	node := c.peek()
+	u32, ok := lw.u32(c)
+	if ok && u32 > 0xff {
+		lw.errorf(node, "lane index %d is out of range", u32)
+	}
+	return byte(u32)
+}
+
+func (lw *lowerer) constValue(c *cursor, imm wasm.Immediate) uint64 {
+	num, numNode := lw.num(c)
+	if numNode == nil {
+		return 0
+	}
+	var value uint64
+	var err error
+	switch imm {
+	case wasm.I32Immediate:
+		value, err = num.ToInteger(32, false)
+	case wasm.I64Immediate:
+		value, err = num.ToInteger(64, false)
+	case wasm.F32Immediate:
+		var u32 uint32
+		u32, err = num.Float32Bits()
+		value = uint64(u32)
+	case wasm.F64Immediate:
+		value, err = num.Float64Bits()
+	}
+	if err != nil {
+		lw.wrapf(numNode, err, "invalid %s", imm.String())
+	}
+	return value
+}
+
+// v128 lowers the shape and lanes of v128.const.
+func (lw *lowerer) v128(c *cursor, instr *wasm.Instr) {
+	shape := c.peekKeyword()
+	var laneBits uint
+	switch shape {
+	case "i8x16":
+		laneBits = 8
+	case "i16x8":
+		laneBits = 16
+	case "i32x4", "f32x4":
+		laneBits = 32
+	case "i64x2", "f64x2":
+		laneBits = 64
+	default:
+		c.missing("vector shape")
+		return
+	}
+	c.next()
+
+	laneBytes := int(laneBits / 8)
+	for i := 0; i < 16; i += laneBytes {
+		num, numNode := lw.num(c)
+		if numNode == nil {
+			return
+		}
+		var value uint64
+		var err error
+		switch shape {
+		case "f32x4":
+			var u32 uint32
+			u32, err = num.Float32Bits()
+			value = uint64(u32)
+		case "f64x2":
+			value, err = num.Float64Bits()
+		default:
+			value, err = num.ToInteger(laneBits, false)
+		}
+		if err != nil {
+			lw.wrapf(numNode, err, "invalid %s lane", shape)
+		}
+		for j := 0; j < laneBytes; j++ {
+			instr.V128[i+j] = byte(value >> (8 * j))
+		}
+	}
+}
+
+func (lw *lowerer) heapType(c *cursor) wasm.ValType {
+	switch c.peekKeyword() {
+	case "func":
+		c.next()
+		return wasm.FuncRef
+	case "extern":
+		c.next()
+		return wasm.ExternRef
+	default:
+		c.missing("heap type")
+		return wasm.FuncRef
+	}
+}
+
+func (lw *lowerer) num(c *cursor) (wat.Num, *wat.Node) {
+	node := c.peek()
+	if !isNumber(node) {
+		c.missing("number")
+		return wat.Num{}, nil
+	}
+	c.next()
+	return node.Value.(wat.Num), node
+}
+
+// encode returns the encoding of a list of instructions, along with the
+// Origin of each, whose Offset is where the instruction begins.
+func encode(list []wasm.Instr) (wasm.Expr, []wasm.Origin) {
+	out := make(wasm.Expr, 0, 4*len(list))
+	origins := make([]wasm.Origin, len(list))
+	for i := range list {
+		origins[i] = wasm.Origin{Offset: uint64(len(out)), Node: list[i].Origin.Node}
+		out = list[i].AppendBinary(out)
+	}
+	return out, origins
+}
+
+func isImmediate(node *wat.Node) bool {
+	return node != nil && (node.Type == wat.NumberNode || node.Type == wat.IdentifierNode || node.Type == wat.StringNode)
+}
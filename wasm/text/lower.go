@@ -0,0 +1,936 @@
+package text
+
+import (
+	"fmt"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+type space byte
+
+const (
+	typeSpace space = iota
+	funcSpace
+	tableSpace
+	memorySpace
+	globalSpace
+	elemSpace
+	dataSpace
+	numSpaces
+)
+
+var spaceNames = [...]string{
+	"type",
+	"func",
+	"table",
+	"memory",
+	"global",
+	"elem",
+	"data",
+}
+
+var externSpaces = [...]space{
+	wasm.FuncExtern:   funcSpace,
+	wasm.TableExtern:  tableSpace,
+	wasm.MemoryExtern: memorySpace,
+	wasm.GlobalExtern: globalSpace,
+}
+
+type lowerer struct {
+	module        *wasm.Module
+	names         *wasm.Names
+	errs          wat.SyntaxErrors
+	ids           [numSpaces]map[string]uint32
+	counts        [numSpaces]uint32
+	sawDefinition bool
+
+	// locals and labels hold the identifiers in scope within the
+	// function body being lowered, with the innermost label last.
+	locals map[string]uint32
+	labels []string
+
+	// numLabels counts the blocks of the function body so far, which is
+	// how the name section numbers labels, and labelNames holds the
+	// identifiers of those that have one.
+	numLabels  uint32
+	labelNames wasm.NameMap
+
+	// usesDataCount is set by memory.init and data.drop, which need the
+	// data count section in the binary format.
+	usesDataCount bool
+}
+
+// Lower interprets a tree produced by wat.Parser as a WebAssembly module.
+// The tree may be either a document holding a single (module ...), or a
+// document holding the module fields directly.
+//
+// Identifiers are resolved to indices, and the identifiers themselves are
+// kept in the module's Names, except for those that Raise synthesizes for
+// unnamed items, such as "$f12" on function 12.  Each item of the module
+// records the node it was lowered from in its Origin.  If there are
+// problems, the error is a wat.SyntaxErrors; the returned module is then
+// incomplete, but still usable for diagnostics.
+//
+// A caching wat.Parser shares leaf nodes between identical tokens, so
+// errors reported against them carry the Span of the first occurrence;
+// parse with DisableCaching(true) when precise positions matter.
+func Lower(root *wat.Node) (*wasm.Module, error) {
+	lw := &lowerer{
+		module: &wasm.Module{},
+		names:  &wasm.Names{},
+	}
+
+	fields := lw.moduleFields(root)
+
+	// Identifiers may be used before their definitions, so every index
+	// space is populated before any field is lowered.  Type definitions
+	// are lowered in the first pass, as implicit type uses must be able
+	// to find them.
+	for _, field := range fields {
+		lw.declare(field)
+	}
+	lw.counts = [numSpaces]uint32{}
+	for _, field := range fields {
+		lw.define(field)
+	}
+
+	if lw.usesDataCount {
+		count := uint32(len(lw.module.Datas))
+		lw.module.DataCount = &count
+	}
+	if !lw.names.IsEmpty() {
+		lw.module.Names = lw.names
+	}
+	return lw.module, lw.errs.Err()
+}
+
+func (lw *lowerer) moduleFields(root *wat.Node) []*wat.Node {
+	list := items(root)
+	node := root
+	if head(root) != "module" {
+		if len(list) != 1 || head(list[0]) != "module" {
+			lw.module.Origin = wasm.Origin{Node: root}
+			return list
+		}
+		node = list[0]
+	}
+
+	lw.module.Origin = wasm.Origin{Node: node}
+	c := lw.open(node)
+	if id, _ := c.optID(); id != "" {
+		lw.names.Module = id
+	}
+	if kw := c.peekKeyword(); kw == "binary" || kw == "quote" {
+		lw.errorf(c.peek(), "%s modules are only allowed in scripts", kw)
+		return nil
+	}
+	return c.rest()
+}
+
+func (lw *lowerer) declare(field *wat.Node) {
+	c := lw.open(field)
+	if c == nil {
+		lw.unexpected(field, "module field")
+		return
+	}
+
+	switch kw := head(field); kw {
+	case "type":
+		id, idNode := c.optID()
+		lw.bind(typeSpace, id, idNode)
+		lw.typeField(field, c)
+
+	case "import":
+		c.next()
+		c.next()
+		desc := c.peek()
+		kind, ok := externKindByName(head(desc))
+		if !ok {
+			return
+		}
+		if lw.sawDefinition {
+			lw.errorf(field, "imports must occur before all definitions")
+		}
+		id, idNode := lw.open(desc).optID()
+		lw.bind(externSpaces[kind], id, idNode)
+
+	case "func", "table", "memory", "global":
+		kind, _ := externKindByName(kw)
+		id, idNode := c.optID()
+		for c.optExpr("export") != nil {
+		}
+		if c.peekHead() == "import" {
+			if lw.sawDefinition {
+				lw.errorf(field, "imports must occur before all definitions")
+			}
+		} else {
+			lw.sawDefinition = true
+			switch {
+			case kw == "table" && hasInlineSegment(c, "elem"):
+				lw.bind(elemSpace, "", nil)
+			case kw == "memory" && hasInlineSegment(c, "data"):
+				lw.bind(dataSpace, "", nil)
+			}
+		}
+		lw.bind(externSpaces[kind], id, idNode)
+
+	case "elem":
+		id, idNode := c.optID()
+		lw.bind(elemSpace, id, idNode)
+
+	case "data":
+		id, idNode := c.optID()
+		lw.bind(dataSpace, id, idNode)
+
+	case "export", "start":
+		// nothing to declare
+
+	default:
+		lw.errorf(field, "unknown module field %q", kw)
+	}
+}
+
+func (lw *lowerer) define(field *wat.Node) {
+	c := lw.open(field)
+	if c == nil {
+		return
+	}
+
+	switch head(field) {
+	case "import":
+		lw.importField(field, c)
+	case "func":
+		lw.funcField(field, c)
+	case "table":
+		lw.tableField(field, c)
+	case "memory":
+		lw.memoryField(field, c)
+	case "global":
+		lw.globalField(field, c)
+	case "export":
+		lw.exportField(field, c)
+	case "start":
+		lw.startField(field, c)
+	case "elem":
+		lw.elemField(field, c)
+	case "data":
+		lw.dataField(field, c)
+	}
+}
+
+func (lw *lowerer) bind(s space, id string, idNode *wat.Node) uint32 {
+	index := lw.counts[s]
+	lw.counts[s]++
+	if id == "" {
+		return index
+	}
+
+	if lw.ids[s] == nil {
+		lw.ids[s] = make(map[string]uint32, 16)
+	}
+	if _, found := lw.ids[s][id]; found {
+		lw.errorf(idNode, "duplicate %s identifier $%s", spaceNames[s], id)
+		return index
+	}
+	lw.ids[s][id] = index
+	if isSynthesized(id, synthesizedID(s, index)) {
+		return index
+	}
+
+	var names *wasm.NameMap
+	switch s {
+	case typeSpace:
+		names = &lw.names.Types
+	case funcSpace:
+		names = &lw.names.Funcs
+	case tableSpace:
+		names = &lw.names.Tables
+	case memorySpace:
+		names = &lw.names.Memories
+	case globalSpace:
+		names = &lw.names.Globals
+	case elemSpace:
+		names = &lw.names.Elems
+	case dataSpace:
+		names = &lw.names.Datas
+	}
+	if *names == nil {
+		*names = make(wasm.NameMap, 16)
+	}
+	(*names)[index] = id
+	return index
+}
+
+// alloc returns the index of the next definition in the given space, in
+// the same order as the declare pass bound them.
+func (lw *lowerer) alloc(s space) uint32 {
+	index := lw.counts[s]
+	lw.counts[s]++
+	return index
+}
+
+func (lw *lowerer) typeField(field *wat.Node, c *cursor) {
+	ft := &wasm.FuncType{Origin: origin(field)}
+	lw.module.Types = append(lw.module.Types, ft)
+	if fn := c.expectExpr("func"); fn != nil {
+		fc := lw.open(fn)
+		lw.params(fc, &ft.Params, nil)
+		lw.results(fc, &ft.Results)
+		fc.expectEnd()
+	}
+	c.expectEnd()
+}
+
+func (lw *lowerer) importField(field *wat.Node, c *cursor) {
+	imp := &wasm.Import{Origin: origin(field)}
+	imp.Module, _ = c.expectString()
+	imp.Name, _ = c.expectString()
+
+	desc := c.next()
+	kind, ok := externKindByName(head(desc))
+	if !ok {
+		if desc == nil {
+			lw.errorf(field, "expect import description before ')'")
+		} else {
+			lw.unexpected(desc, "import description")
+		}
+		return
+	}
+	c.expectEnd()
+
+	dc := lw.open(desc)
+	dc.optID()
+	index := lw.alloc(externSpaces[kind])
+	lw.importDesc(imp, kind, index, dc)
+	lw.module.Imports = append(lw.module.Imports, imp)
+}
+
+func (lw *lowerer) importDesc(imp *wasm.Import, kind wasm.ExternKind, index uint32, c *cursor) {
+	imp.Kind = kind
+	switch kind {
+	case wasm.FuncExtern:
+		var paramNames []string
+		imp.Type, paramNames = lw.typeUse(c)
+		lw.localNames(index, len(paramNames), paramNames)
+	case wasm.TableExtern:
+		imp.Table = lw.tableType(c)
+	case wasm.MemoryExtern:
+		imp.Memory = lw.memoryType(c)
+	case wasm.GlobalExtern:
+		imp.Global = lw.globalType(c)
+	}
+	c.expectEnd()
+}
+
+func (lw *lowerer) inlineImport(c *cursor) *wasm.Import {
+	node := c.optExpr("import")
+	if node == nil {
+		return nil
+	}
+	imp := &wasm.Import{Origin: origin(node)}
+	ic := lw.open(node)
+	imp.Module, _ = ic.expectString()
+	imp.Name, _ = ic.expectString()
+	ic.expectEnd()
+	return imp
+}
+
+func (lw *lowerer) inlineExports(c *cursor, kind wasm.ExternKind, index uint32) {
+	for {
+		node := c.optExpr("export")
+		if node == nil {
+			return
+		}
+		ec := lw.open(node)
+		name, _ := ec.expectString()
+		ec.expectEnd()
+		lw.module.Exports = append(lw.module.Exports, &wasm.Export{
+			Name:   name,
+			Kind:   kind,
+			Index:  index,
+			Origin: origin(node),
+		})
+	}
+}
+
+func (lw *lowerer) funcField(field *wat.Node, c *cursor) {
+	c.optID()
+	index := lw.alloc(funcSpace)
+	lw.inlineExports(c, wasm.FuncExtern, index)
+
+	if imp := lw.inlineImport(c); imp != nil {
+		imp.Origin = origin(field)
+		lw.importDesc(imp, wasm.FuncExtern, index, c)
+		lw.module.Imports = append(lw.module.Imports, imp)
+		return
+	}
+
+	fn := &wasm.Func{Origin: origin(field)}
+	var localNames []string
+	fn.Type, localNames = lw.typeUse(c)
+	if ft := lw.typeAt(fn.Type); ft != nil && localNames == nil {
+		localNames = make([]string, len(ft.Params))
+	}
+
+	var types []wasm.ValType
+	for c.peekHead() == "local" {
+		lw.valTypes(lw.open(c.next()), &types, &localNames)
+	}
+	for _, vt := range types {
+		if n := len(fn.Locals); n > 0 && fn.Locals[n-1].Type == vt {
+			fn.Locals[n-1].Count++
+			continue
+		}
+		fn.Locals = append(fn.Locals, wasm.Local{Count: 1, Type: vt})
+	}
+	lw.localNames(index, len(localNames)-len(types), localNames)
+
+	lw.locals = make(map[string]uint32, len(localNames))
+	for i, name := range localNames {
+		if name != "" {
+			lw.locals[name] = uint32(i)
+		}
+	}
+	lw.numLabels, lw.labelNames = 0, nil
+	instrs := lw.body(c, nil)
+	instrs = append(instrs, wasm.Instr{Opcode: wasm.OpEnd, Origin: origin(field)})
+	fn.Body, fn.InstrOrigins = encode(instrs)
+	if lw.labelNames != nil {
+		if lw.names.Labels == nil {
+			lw.names.Labels = make(wasm.IndirectNameMap, 16)
+		}
+		lw.names.Labels[index] = lw.labelNames
+	}
+	lw.locals, lw.labelNames = nil, nil
+	lw.module.Funcs = append(lw.module.Funcs, fn)
+}
+
+func (lw *lowerer) localNames(funcIndex uint32, numParams int, localNames []string) {
+	var names wasm.NameMap
+	for i, name := range localNames {
+		if name == "" || isSynthesized(name, synthesizedLocalID(uint32(i), numParams)) {
+			continue
+		}
+		if names == nil {
+			names = make(wasm.NameMap, len(localNames))
+		}
+		names[uint32(i)] = name
+	}
+	if names == nil {
+		return
+	}
+	if lw.names.Locals == nil {
+		lw.names.Locals = make(wasm.IndirectNameMap, 16)
+	}
+	lw.names.Locals[funcIndex] = names
+}
+
+func (lw *lowerer) tableField(field *wat.Node, c *cursor) {
+	c.optID()
+	index := lw.alloc(tableSpace)
+	lw.inlineExports(c, wasm.TableExtern, index)
+
+	if imp := lw.inlineImport(c); imp != nil {
+		imp.Origin = origin(field)
+		lw.importDesc(imp, wasm.TableExtern, index, c)
+		lw.module.Imports = append(lw.module.Imports, imp)
+		return
+	}
+
+	table := &wasm.Table{Origin: origin(field)}
+	lw.module.Tables = append(lw.module.Tables, table)
+
+	if hasInlineSegment(c, "elem") {
+		elem := &wasm.Elem{
+			Mode:   wasm.ActiveSegment,
+			Table:  index,
+			Offset: wasm.Expr{0x41, 0x00, 0x0b},
+			Origin: origin(field),
+		}
+		elem.Type = lw.refType(c.next())
+		ec := lw.open(c.next())
+		if elem.Type == wasm.FuncRef && !isElemExpr(ec.peek()) {
+			elem.Funcs = lw.indices(ec, funcSpace)
+		} else {
+			elem.Exprs = lw.elemExprs(ec)
+		}
+		c.expectEnd()
+
+		count := uint32(len(elem.Funcs) + len(elem.Exprs))
+		table.Type.Elem = elem.Type
+		table.Type.Limits = wasm.Limits{Min: count, Max: count, HasMax: true}
+		lw.alloc(elemSpace)
+		lw.module.Elems = append(lw.module.Elems, elem)
+		return
+	}
+
+	table.Type = lw.tableType(c)
+	c.expectEnd()
+}
+
+func (lw *lowerer) memoryField(field *wat.Node, c *cursor) {
+	c.optID()
+	index := lw.alloc(memorySpace)
+	lw.inlineExports(c, wasm.MemoryExtern, index)
+
+	if imp := lw.inlineImport(c); imp != nil {
+		imp.Origin = origin(field)
+		lw.importDesc(imp, wasm.MemoryExtern, index, c)
+		lw.module.Imports = append(lw.module.Imports, imp)
+		return
+	}
+
+	memory := &wasm.Memory{Origin: origin(field)}
+	lw.module.Memories = append(lw.module.Memories, memory)
+
+	if node := c.optExpr("data"); node != nil {
+		data := &wasm.Data{
+			Mode:   wasm.ActiveSegment,
+			Memory: index,
+			Offset: wasm.Expr{0x41, 0x00, 0x0b},
+			Origin: origin(field),
+		}
+		data.Init = lw.dataStrings(lw.open(node))
+		c.expectEnd()
+
+		const pageSize = 65536
+		pages := uint32((uint64(len(data.Init)) + pageSize - 1) / pageSize)
+		memory.Type.Limits = wasm.Limits{Min: pages, Max: pages, HasMax: true}
+		lw.alloc(dataSpace)
+		lw.module.Datas = append(lw.module.Datas, data)
+		return
+	}
+
+	memory.Type = lw.memoryType(c)
+	c.expectEnd()
+}
+
+func (lw *lowerer) globalField(field *wat.Node, c *cursor) {
+	c.optID()
+	index := lw.alloc(globalSpace)
+	lw.inlineExports(c, wasm.GlobalExtern, index)
+
+	if imp := lw.inlineImport(c); imp != nil {
+		imp.Origin = origin(field)
+		lw.importDesc(imp, wasm.GlobalExtern, index, c)
+		lw.module.Imports = append(lw.module.Imports, imp)
+		return
+	}
+
+	global := &wasm.Global{Origin: origin(field)}
+	global.Type = lw.globalType(c)
+	global.Init = lw.constExpr(field, c.rest())
+	lw.module.Globals = append(lw.module.Globals, global)
+}
+
+func (lw *lowerer) exportField(field *wat.Node, c *cursor) {
+	export := &wasm.Export{Origin: origin(field)}
+	export.Name, _ = c.expectString()
+
+	desc := c.next()
+	kind, ok := externKindByName(head(desc))
+	if !ok {
+		if desc == nil {
+			lw.errorf(field, "expect export description before ')'")
+		} else {
+			lw.unexpected(desc, "export description")
+		}
+		return
+	}
+	c.expectEnd()
+
+	dc := lw.open(desc)
+	export.Kind = kind
+	export.Index, _ = lw.index(dc, externSpaces[kind])
+	dc.expectEnd()
+	lw.module.Exports = append(lw.module.Exports, export)
+}
+
+func (lw *lowerer) startField(field *wat.Node, c *cursor) {
+	if lw.module.Start != nil {
+		lw.errorf(field, "multiple start fields")
+	}
+	start := &wasm.Start{Origin: origin(field)}
+	start.Func, _ = lw.index(c, funcSpace)
+	c.expectEnd()
+	lw.module.Start = start
+}
+
+func (lw *lowerer) elemField(field *wat.Node, c *cursor) {
+	c.optID()
+	lw.alloc(elemSpace)
+	elem := &wasm.Elem{Origin: origin(field)}
+
+	explicitType := true
+	switch {
+	case c.optKeyword("declare"):
+		elem.Mode = wasm.DeclarativeSegment
+
+	case c.peek() != nil && c.peek().Type == wat.ExprNode:
+		elem.Mode = wasm.ActiveSegment
+		if node := c.optExpr("table"); node != nil {
+			tc := lw.open(node)
+			elem.Table, _ = lw.index(tc, tableSpace)
+			tc.expectEnd()
+		}
+		elem.Offset = lw.offset(field, c)
+
+		// The legacy abbreviation omits "func" before the indices.
+		explicitType = !isIndex(c.peek()) && !c.done()
+
+	default:
+		elem.Mode = wasm.PassiveSegment
+	}
+
+	switch {
+	case !explicitType || c.optKeyword("func"):
+		elem.Type = wasm.FuncRef
+		elem.Funcs = lw.indices(c, funcSpace)
+	default:
+		elem.Type = lw.refType(c.next())
+		elem.Exprs = lw.elemExprs(c)
+	}
+	c.expectEnd()
+	lw.module.Elems = append(lw.module.Elems, elem)
+}
+
+func (lw *lowerer) dataField(field *wat.Node, c *cursor) {
+	c.optID()
+	lw.alloc(dataSpace)
+	data := &wasm.Data{Origin: origin(field)}
+
+	data.Mode = wasm.PassiveSegment
+	if node := c.peek(); node != nil && node.Type == wat.ExprNode {
+		data.Mode = wasm.ActiveSegment
+		if node := c.optExpr("memory"); node != nil {
+			mc := lw.open(node)
+			data.Memory, _ = lw.index(mc, memorySpace)
+			mc.expectEnd()
+		}
+		data.Offset = lw.offset(field, c)
+	}
+	data.Init = lw.dataStrings(c)
+	lw.module.Datas = append(lw.module.Datas, data)
+}
+
+func (lw *lowerer) offset(field *wat.Node, c *cursor) wasm.Expr {
+	if node := c.optExpr("offset"); node != nil {
+		oc := lw.open(node)
+		return lw.constExpr(node, oc.rest())
+	}
+	node := c.next()
+	if node == nil || node.Type != wat.ExprNode {
+		lw.errorf(field, "expect (offset ...)")
+		return nil
+	}
+	return lw.constExpr(node, []*wat.Node{node})
+}
+
+func (lw *lowerer) elemExprs(c *cursor) []wasm.Expr {
+	exprs := make([]wasm.Expr, 0, len(c.list))
+	for !c.done() {
+		node := c.next()
+		if node.Type != wat.ExprNode {
+			lw.unexpected(node, "element expression")
+			continue
+		}
+		if head(node) == "item" {
+			ic := lw.open(node)
+			exprs = append(exprs, lw.constExpr(node, ic.rest()))
+			continue
+		}
+		exprs = append(exprs, lw.constExpr(node, []*wat.Node{node}))
+	}
+	return exprs
+}
+
+func (lw *lowerer) dataStrings(c *cursor) []byte {
+	var data []byte
+	for !c.done() {
+		str, ok := c.expectString()
+		if !ok {
+			break
+		}
+		data = append(data, str...)
+	}
+	return data
+}
+
+// typeUse lowers an optional (type x) followed by optional inline
+// (param ...) and (result ...) declarations, returning the type index
+// along with the parameter identifiers.  Without (type x), the index is
+// that of the first matching type, which is appended if there is none.
+func (lw *lowerer) typeUse(c *cursor) (uint32, []string) {
+	var index uint32
+	var explicit *wat.Node
+	if node := c.optExpr("type"); node != nil {
+		tc := lw.open(node)
+		index, _ = lw.index(tc, typeSpace)
+		tc.expectEnd()
+		explicit = node
+	}
+
+	inline := c.peekHead() == "param" || c.peekHead() == "result"
+	ft := &wasm.FuncType{}
+	var names []string
+	lw.params(c, &ft.Params, &names)
+	lw.results(c, &ft.Results)
+
+	if explicit == nil {
+		return lw.internType(ft), names
+	}
+	if inline {
+		if existing := lw.typeAt(index); existing != nil && !existing.Equals(ft) {
+			lw.errorf(explicit, "inline function type %s does not match type %d %s", ft.String(), index, existing.String())
+		}
+		return index, names
+	}
+	return index, nil
+}
+
+func (lw *lowerer) typeAt(index uint32) *wasm.FuncType {
+	if index < uint32(len(lw.module.Types)) {
+		return lw.module.Types[index]
+	}
+	return nil
+}
+
+func (lw *lowerer) internType(ft *wasm.FuncType) uint32 {
+	for index, existing := range lw.module.Types {
+		if existing.Equals(ft) {
+			return uint32(index)
+		}
+	}
+	lw.module.Types = append(lw.module.Types, ft)
+	return uint32(len(lw.module.Types) - 1)
+}
+
+func (lw *lowerer) params(c *cursor, out *[]wasm.ValType, names *[]string) {
+	for c.peekHead() == "param" {
+		lw.valTypes(lw.open(c.next()), out, names)
+	}
+}
+
+func (lw *lowerer) results(c *cursor, out *[]wasm.ValType) {
+	for c.peekHead() == "result" {
+		rc := lw.open(c.next())
+		for !rc.done() {
+			*out = append(*out, lw.valType(rc.next()))
+		}
+	}
+}
+
+// valTypes lowers the contents of a (param ...) or (local ...), which is
+// either one identifier and one type, or any number of types.
+func (lw *lowerer) valTypes(c *cursor, out *[]wasm.ValType, names *[]string) {
+	if id, idNode := c.optID(); id != "" {
+		if names != nil {
+			for _, existing := range *names {
+				if existing == id {
+					lw.errorf(idNode, "duplicate local identifier $%s", id)
+					id = ""
+					break
+				}
+			}
+		}
+		if c.done() {
+			c.missing("value type")
+			return
+		}
+		*out = append(*out, lw.valType(c.next()))
+		if names != nil {
+			*names = append(*names, id)
+		}
+		c.expectEnd()
+		return
+	}
+	for !c.done() {
+		*out = append(*out, lw.valType(c.next()))
+		if names != nil {
+			*names = append(*names, "")
+		}
+	}
+}
+
+func (lw *lowerer) valType(node *wat.Node) wasm.ValType {
+	if node != nil && node.Type == wat.KeywordNode {
+		if vt, ok := wasm.ValTypeByName(node.Value.(string)); ok {
+			return vt
+		}
+	}
+	lw.unexpected(node, "value type")
+	return wasm.I32
+}
+
+func (lw *lowerer) refType(node *wat.Node) wasm.ValType {
+	if node != nil && node.Type == wat.KeywordNode {
+		if vt, ok := wasm.ValTypeByName(node.Value.(string)); ok && vt.IsRef() {
+			return vt
+		}
+	}
+	lw.unexpected(node, "reference type")
+	return wasm.FuncRef
+}
+
+func (lw *lowerer) limits(c *cursor) wasm.Limits {
+	var limits wasm.Limits
+	var ok bool
+	if limits.Min, ok = lw.u32(c); !ok {
+		return limits
+	}
+	if isNumber(c.peek()) {
+		limits.Max, _ = lw.u32(c)
+		limits.HasMax = true
+	}
+	return limits
+}
+
+func (lw *lowerer) tableType(c *cursor) wasm.TableType {
+	var tt wasm.TableType
+	tt.Limits = lw.limits(c)
+	tt.Elem = lw.refType(c.next())
+	return tt
+}
+
+func (lw *lowerer) memoryType(c *cursor) wasm.MemoryType {
+	var mt wasm.MemoryType
+	mt.Limits = lw.limits(c)
+	mt.Limits.Shared = c.optKeyword("shared")
+	return mt
+}
+
+func (lw *lowerer) globalType(c *cursor) wasm.GlobalType {
+	var gt wasm.GlobalType
+	if node := c.optExpr("mut"); node != nil {
+		mc := lw.open(node)
+		gt.Type = lw.valType(mc.next())
+		gt.Mutable = true
+		mc.expectEnd()
+		return gt
+	}
+	gt.Type = lw.valType(c.next())
+	return gt
+}
+
+func (lw *lowerer) u32(c *cursor) (uint32, bool) {
+	node := c.peek()
+	if !isNumber(node) {
+		c.missing("u32")
+		return 0, false
+	}
+	c.next()
+	num := node.Value.(wat.Num)
+	if num.Flags.HasAny(wat.FlagSign | wat.FlagFloat) {
+		lw.errorf(node, "expect u32, got %s", num.String())
+		return 0, false
+	}
+	u32, err := num.Uint32()
+	if err != nil {
+		lw.wrapf(node, err, "invalid u32")
+		return 0, false
+	}
+	return u32, true
+}
+
+// index lowers a reference to an item in the given index space, either
+// numeric or by identifier.
+func (lw *lowerer) index(c *cursor, s space) (uint32, bool) {
+	node := c.peek()
+	if node != nil && node.Type == wat.IdentifierNode {
+		c.next()
+		id := node.Value.(string)[1:]
+		if index, found := lw.ids[s][id]; found {
+			return index, true
+		}
+		lw.errorf(node, "unknown %s $%s", spaceNames[s], id)
+		return 0, false
+	}
+	if !isNumber(node) {
+		c.missing(spaceNames[s] + " index")
+		return 0, false
+	}
+	return lw.u32(c)
+}
+
+func (lw *lowerer) indices(c *cursor, s space) []uint32 {
+	list := make([]uint32, 0, len(c.list))
+	for !c.done() {
+		if !isIndex(c.peek()) {
+			c.missing(spaceNames[s] + " index")
+			break
+		}
+		index, _ := lw.index(c, s)
+		list = append(list, index)
+	}
+	return list
+}
+
+func (lw *lowerer) errorf(node *wat.Node, format string, args ...any) {
+	var span wat.Span
+	if node != nil {
+		span = node.Span
+	}
+	lw.errs = append(lw.errs, &wat.SyntaxError{Code: wat.SemanticError, Span: span, Detail: fmt.Sprintf(format, args...)})
+}
+
+func (lw *lowerer) wrapf(node *wat.Node, err error, format string, args ...any) {
+	lw.errs = append(lw.errs, &wat.SyntaxError{Code: wat.SemanticError, Span: node.Span, Detail: fmt.Sprintf(format, args...), Err: err})
+}
+
+func (lw *lowerer) unexpected(node *wat.Node, expect ...string) {
+	if node == nil {
+		lw.errorf(lw.module.Origin.Node, "unexpected end of module")
+		return
+	}
+	if node.Type == wat.ErrorNode {
+		err, _ := node.Value.(error)
+		lw.wrapf(node, err, "syntax error")
+		return
+	}
+	if len(expect) > 0 {
+		lw.errorf(node, "unexpected %s: expect %s", describe(node), expect[0])
+		return
+	}
+	lw.errorf(node, "unexpected %s", describe(node))
+}
+
+func origin(node *wat.Node) wasm.Origin {
+	return wasm.Origin{Node: node}
+}
+
+func externKindByName(name string) (wasm.ExternKind, bool) {
+	switch name {
+	case "func":
+		return wasm.FuncExtern, true
+	case "table":
+		return wasm.TableExtern, true
+	case "memory":
+		return wasm.MemoryExtern, true
+	case "global":
+		return wasm.GlobalExtern, true
+	default:
+		return 0, false
+	}
+}
+
+// hasInlineSegment reports whether a table or memory definition is the
+// abbreviated form which includes its own element or data segment.
+func hasInlineSegment(c *cursor, keyword string) bool {
+	if keyword == "data" {
+		return c.peekHead() == "data"
+	}
+	return len(c.list) >= 2 && c.list[0].Type == wat.KeywordNode && head(c.list[1]) == keyword
+}
+
+func isNumber(node *wat.Node) bool {
+	return node != nil && node.Type == wat.NumberNode
+}
+
+func isIndex(node *wat.Node) bool {
+	return node != nil && (node.Type == wat.NumberNode || node.Type == wat.IdentifierNode)
+}
+
+func isElemExpr(node *wat.Node) bool {
+	return node != nil && node.Type == wat.ExprNode
+}
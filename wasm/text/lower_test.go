@@ -0,0 +1,350 @@
+package text
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+func lower(t *testing.T, src string) (*wasm.Module, error) {
+	t.Helper()
+	var parser wat.Parser
+	parser.DisableCaching(true)
+	root, err := parser.Parse(wat.NewLexer([]byte(src)))
+	if err != nil {
+		t.Fatalf("Parse: unexpected error: %v", err)
+	}
+	return Lower(root)
+}
+
+// stripOrigins clears every Origin, so that modules can be compared
+// without regard to the nodes they came from.
+func stripOrigins(module *wasm.Module) {
+	module.Origin = wasm.Origin{}
+	for _, x := range module.Types {
+		x.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Imports {
+		x.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Funcs {
+		x.InstrOrigins = nil
+		x.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Tables {
+		x.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Memories {
+		x.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Globals {
+		x.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Exports {
+		x.Origin = wasm.Origin{}
+	}
+	if module.Start != nil {
+		module.Start.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Elems {
+		x.Origin = wasm.Origin{}
+	}
+	for _, x := range module.Datas {
+		x.Origin = wasm.Origin{}
+	}
+}
+
+func TestLower(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect *wasm.Module
+	}
+
+	i32 := []wasm.ValType{wasm.I32}
+	empty := wasm.Expr{0x0b}
+	zero := wasm.Expr{0x41, 0x00, 0x0b}
+
+	testData := [...]testCase{
+		{
+			Name:   "Empty",
+			Input:  "(module)",
+			Expect: &wasm.Module{},
+		},
+		{
+			Name:  "BareFields",
+			Input: "(memory 1) (export \"m\" (memory 0))",
+			Expect: &wasm.Module{
+				Memories: []*wasm.Memory{{Type: wasm.MemoryType{Limits: wasm.Limits{Min: 1}}}},
+				Exports:  []*wasm.Export{{Name: "m", Kind: wasm.MemoryExtern, Index: 0}},
+			},
+		},
+		{
+			Name: "Types",
+			Input: `(module $m
+				(func $f (param $x i32) (local $y i64) (local f32 f32))
+				(type $t (func (param i32)))
+				(func (type $t) (param i32))
+				(func (result i32 i32))
+				(start $f))`,
+			Expect: &wasm.Module{
+				Types: []*wasm.FuncType{
+					{Params: i32},
+					{Results: []wasm.ValType{wasm.I32, wasm.I32}},
+				},
+				Funcs: []*wasm.Func{
+					{Type: 0, Locals: []wasm.Local{{Count: 1, Type: wasm.I64}, {Count: 2, Type: wasm.F32}}, Body: empty},
+					{Type: 0, Body: empty},
+					{Type: 1, Body: empty},
+				},
+				Start: &wasm.Start{Func: 0},
+				Names: &wasm.Names{
+					Module: "m",
+					Funcs:  wasm.NameMap{0: "f"},
+					Locals: wasm.IndirectNameMap{0: {0: "x", 1: "y"}},
+					Types:  wasm.NameMap{0: "t"},
+				},
+			},
+		},
+		{
+			Name: "SynthesizedNames",
+			Input: `(module
+				(type $t0 (func (param i32)))
+				(func $f0 (type $t0) (param $p0 i32) (local $l1 i32) (local $x i32))
+				(func $f1.1 (type $t0) (param $l0 i32))
+				(global $g0 i32 i32.const 0))`,
+			Expect: &wasm.Module{
+				Types: []*wasm.FuncType{{Params: i32}},
+				Funcs: []*wasm.Func{
+					{Type: 0, Locals: []wasm.Local{{Count: 2, Type: wasm.I32}}, Body: empty},
+					{Type: 0, Body: empty},
+				},
+				Globals: []*wasm.Global{{Type: wasm.GlobalType{Type: wasm.I32}, Init: zero}},
+				Names: &wasm.Names{
+					Locals: wasm.IndirectNameMap{0: {2: "x"}, 1: {0: "l0"}},
+				},
+			},
+		},
+		{
+			Name: "Imports",
+			Input: `(module
+				(import "env" "f" (func $f (param i32)))
+				(func $g (export "g") (import "env" "g"))
+				(import "env" "t" (table 1 funcref))
+				(memory (import "env" "m") 1 2 shared)
+				(global $g (import "env" "g") (mut i64))
+				(func (export "h"))
+				(export "f" (func $f)))`,
+			Expect: &wasm.Module{
+				Types: []*wasm.FuncType{{Params: i32}, {}},
+				Imports: []*wasm.Import{
+					{Module: "env", Name: "f", Kind: wasm.FuncExtern, Type: 0},
+					{Module: "env", Name: "g", Kind: wasm.FuncExtern, Type: 1},
+					{Module: "env", Name: "t", Kind: wasm.TableExtern, Table: wasm.TableType{Elem: wasm.FuncRef, Limits: wasm.Limits{Min: 1}}},
+					{Module: "env", Name: "m", Kind: wasm.MemoryExtern, Memory: wasm.MemoryType{Limits: wasm.Limits{Min: 1, Max: 2, HasMax: true, Shared: true}}},
+					{Module: "env", Name: "g", Kind: wasm.GlobalExtern, Global: wasm.GlobalType{Type: wasm.I64, Mutable: true}},
+				},
+				Funcs: []*wasm.Func{{Type: 1, Body: empty}},
+				Exports: []*wasm.Export{
+					{Name: "g", Kind: wasm.FuncExtern, Index: 1},
+					{Name: "h", Kind: wasm.FuncExtern, Index: 2},
+					{Name: "f", Kind: wasm.FuncExtern, Index: 0},
+				},
+				Names: &wasm.Names{
+					Funcs:   wasm.NameMap{0: "f", 1: "g"},
+					Globals: wasm.NameMap{0: "g"},
+				},
+			},
+		},
+		{
+			Name: "Globals",
+			Input: `(module
+				(global $a i32 (i32.const -1))
+				(global i64 i64.const 0x7fff_ffff_ffff_ffff)
+				(global f32 (f32.const 1.5))
+				(global f64 (f64.const -inf))
+				(global (mut funcref) (ref.null func))
+				(global funcref (ref.func $f))
+				(global i32 (i32.add (global.get $a) (i32.const 64)))
+				(func $f))`,
+			Expect: &wasm.Module{
+				Types: []*wasm.FuncType{{}},
+				Funcs: []*wasm.Func{{Body: empty}},
+				Globals: []*wasm.Global{
+					{Type: wasm.GlobalType{Type: wasm.I32}, Init: wasm.Expr{0x41, 0x7f, 0x0b}},
+					{Type: wasm.GlobalType{Type: wasm.I64}, Init: wasm.Expr{0x42, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x0b}},
+					{Type: wasm.GlobalType{Type: wasm.F32}, Init: wasm.Expr{0x43, 0x00, 0x00, 0xc0, 0x3f, 0x0b}},
+					{Type: wasm.GlobalType{Type: wasm.F64}, Init: wasm.Expr{0x44, 0, 0, 0, 0, 0, 0, 0xf0, 0xff, 0x0b}},
+					{Type: wasm.GlobalType{Type: wasm.FuncRef, Mutable: true}, Init: wasm.Expr{0xd0, 0x70, 0x0b}},
+					{Type: wasm.GlobalType{Type: wasm.FuncRef}, Init: wasm.Expr{0xd2, 0x00, 0x0b}},
+					{Type: wasm.GlobalType{Type: wasm.I32}, Init: wasm.Expr{0x23, 0x00, 0x41, 0xc0, 0x00, 0x6a, 0x0b}},
+				},
+				Names: &wasm.Names{
+					Funcs:   wasm.NameMap{0: "f"},
+					Globals: wasm.NameMap{0: "a"},
+				},
+			},
+		},
+		{
+			Name: "Segments",
+			Input: `(module
+				(table $t 2 funcref)
+				(table funcref (elem $f $f))
+				(memory $m (data "hi" "!"))
+				(memory 1)
+				(elem (i32.const 1) $f)
+				(elem $e (table $t) (offset i32.const 0) func $f)
+				(elem funcref (item ref.func $f) (ref.null func))
+				(elem declare func 0)
+				(data (i32.const 8) "\00\ff")
+				(data $d (memory 1) (offset (i32.const 0)))
+				(data "passive")
+				(func $f))`,
+			Expect: &wasm.Module{
+				Types: []*wasm.FuncType{{}},
+				Funcs: []*wasm.Func{{Body: empty}},
+				Tables: []*wasm.Table{
+					{Type: wasm.TableType{Elem: wasm.FuncRef, Limits: wasm.Limits{Min: 2}}},
+					{Type: wasm.TableType{Elem: wasm.FuncRef, Limits: wasm.Limits{Min: 2, Max: 2, HasMax: true}}},
+				},
+				Memories: []*wasm.Memory{
+					{Type: wasm.MemoryType{Limits: wasm.Limits{Min: 1, Max: 1, HasMax: true}}},
+					{Type: wasm.MemoryType{Limits: wasm.Limits{Min: 1}}},
+				},
+				Elems: []*wasm.Elem{
+					{Mode: wasm.ActiveSegment, Table: 1, Offset: zero, Type: wasm.FuncRef, Funcs: []uint32{0, 0}},
+					{Mode: wasm.ActiveSegment, Table: 0, Offset: wasm.Expr{0x41, 0x01, 0x0b}, Type: wasm.FuncRef, Funcs: []uint32{0}},
+					{Mode: wasm.ActiveSegment, Table: 0, Offset: zero, Type: wasm.FuncRef, Funcs: []uint32{0}},
+					{Mode: wasm.PassiveSegment, Type: wasm.FuncRef, Exprs: []wasm.Expr{{0xd2, 0x00, 0x0b}, {0xd0, 0x70, 0x0b}}},
+					{Mode: wasm.DeclarativeSegment, Type: wasm.FuncRef, Funcs: []uint32{0}},
+				},
+				Datas: []*wasm.Data{
+					{Mode: wasm.ActiveSegment, Memory: 0, Offset: zero, Init: []byte("hi!")},
+					{Mode: wasm.ActiveSegment, Memory: 0, Offset: wasm.Expr{0x41, 0x08, 0x0b}, Init: []byte{0x00, 0xff}},
+					{Mode: wasm.ActiveSegment, Memory: 1, Offset: zero},
+					{Mode: wasm.PassiveSegment, Init: []byte("passive")},
+				},
+				Names: &wasm.Names{
+					Funcs:    wasm.NameMap{0: "f"},
+					Tables:   wasm.NameMap{0: "t"},
+					Memories: wasm.NameMap{0: "m"},
+					Elems:    wasm.NameMap{2: "e"},
+					Datas:    wasm.NameMap{2: "d"},
+				},
+			},
+		},
+		{
+			Name: "Bodies",
+			Input: `(module
+				(table 1 funcref)
+				(memory 1)
+				(func $f (param $x i32) (result i32) (local $y i32)
+					block $out
+						local.get $x
+						br_if $out
+						(local.set $y (i32.load offset=4 align=2 (local.get $x)))
+					end $out
+					(if (result i32) (local.get $y)
+						(then (i32.const 1))
+						(else (i32.const 2)))
+					i32.add)
+				(func
+					(loop $l (br_table $l 0 (i32.const 0)))
+					(drop (call_indirect (type 0) (i32.const 7) (i32.const 0)))
+					(drop (select (result i64) (i64.const 1) (i64.const 2) (i32.const 0)))))`,
+			Expect: &wasm.Module{
+				Types: []*wasm.FuncType{{Params: i32, Results: i32}, {}},
+				Funcs: []*wasm.Func{
+					{Type: 0, Locals: []wasm.Local{{Count: 1, Type: wasm.I32}}, Body: wasm.Expr{
+						0x02, 0x40, 0x20, 0x00, 0x0d, 0x00, 0x20, 0x00, 0x28, 0x01, 0x04, 0x21, 0x01, 0x0b,
+						0x20, 0x01, 0x04, 0x7f, 0x41, 0x01, 0x05, 0x41, 0x02, 0x0b,
+						0x6a, 0x0b,
+					}},
+					{Type: 1, Body: wasm.Expr{
+						0x03, 0x40, 0x41, 0x00, 0x0e, 0x01, 0x00, 0x00, 0x0b,
+						0x41, 0x07, 0x41, 0x00, 0x11, 0x00, 0x00, 0x1a,
+						0x42, 0x01, 0x42, 0x02, 0x41, 0x00, 0x1c, 0x01, 0x7e, 0x1a, 0x0b,
+					}},
+				},
+				Tables:   []*wasm.Table{{Type: wasm.TableType{Elem: wasm.FuncRef, Limits: wasm.Limits{Min: 1}}}},
+				Memories: []*wasm.Memory{{Type: wasm.MemoryType{Limits: wasm.Limits{Min: 1}}}},
+				Names: &wasm.Names{
+					Funcs:  wasm.NameMap{0: "f"},
+					Locals: wasm.IndirectNameMap{0: {0: "x", 1: "y"}},
+					Labels: wasm.IndirectNameMap{0: {0: "out"}, 1: {0: "l"}},
+				},
+			},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			module, err := lower(t, row.Input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			stripOrigins(module)
+			if !reflect.DeepEqual(module, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", row.Expect, module)
+			}
+		})
+	}
+}
+
+func TestLower_Origin(t *testing.T) {
+	module, err := lower(t, "(module\n  (func $f)\n  (export \"f\" (func $f)))")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	span := module.Exports[0].Origin.Span()
+	if span.Begin.Line != 2 || span.Begin.Column != 2 || span.Begin.ByteOffset != 22 {
+		t.Errorf("wrong span for export: %v", span)
+	}
+}
+
+func TestLower_Errors(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{"UnknownFunc", `(module (start $nope))`, `L:1 C:16 @ 15: unknown func $nope`},
+		{"DuplicateID", `(module (func $f) (func $f))`, `L:1 C:25 @ 24: duplicate func identifier $f`},
+		{"DuplicateLocal", `(module (func (param $x i32) (local $x i32)))`, `L:1 C:37 @ 36: duplicate local identifier $x`},
+		{"ImportOrder", `(module (func) (import "a" "b" (func)))`, `L:1 C:16 @ 15: imports must occur before all definitions`},
+		{"TypeMismatch", `(module (type (func)) (func (type 0) (param i32)))`, `L:1 C:29 @ 28: inline function type (func (param i32)) does not match type 0 (func)`},
+		{"UnknownField", `(module (funk))`, `L:1 C:9 @ 8: unknown module field "funk"`},
+		{"BadValType", `(module (func (param i33)))`, `L:1 C:22 @ 21: unexpected keyword "i33": expect value type`},
+		{"MissingString", `(module (export (func 0)))`, `L:1 C:17 @ 16: unexpected (func ...): expect string`},
+		{"Overflow", `(module (global i32 (i32.const 0x1_0000_0000)))`, `L:1 C:32 @ 31: invalid i32: wat.Num.ToInteger: converting "0x100000000": value out of range`},
+		{"NotConst", `(module (global i32 (i32.load)))`, `L:1 C:21 @ 20: instruction "i32.load" is not allowed in a constant expression`},
+		{"UnknownInstr", `(module (func i32.frob))`, `L:1 C:15 @ 14: unknown instruction "i32.frob"`},
+		{"UnknownLabel", `(module (func br $nope))`, `L:1 C:18 @ 17: unknown label $nope`},
+		{"UnknownLocal", `(module (func local.get $x))`, `L:1 C:25 @ 24: unknown local $x`},
+		{"MismatchedLabel", `(module (func block $a end $b))`, `L:1 C:28 @ 27: mismatching label $b`},
+		{"MissingEnd", `(module (func block))`, `L:1 C:9 @ 8: expect "end" before ')'`},
+		{"StrayEnd", `(module (func end))`, `L:1 C:15 @ 14: unexpected keyword "end": expect instruction`},
+		{"BadAlign", `(module (memory 1) (func (i32.load align=3 (i32.const 0))))`, `L:1 C:36 @ 35: alignment 3 is not a power of two`},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := lower(t, row.Input)
+			var errs wat.SyntaxErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expect wat.SyntaxErrors, got %#v", err)
+			}
+			if code := errs[0].Code; code != wat.SemanticError {
+				t.Errorf("wrong code: expect %v, got %v", wat.SemanticError, code)
+			}
+			if actual := errs[0].Error(); actual != row.Expect {
+				t.Errorf("wrong error:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,846 @@
+package text
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// idPrefixes gives the prefix of the identifiers synthesized for items
+// that have no name, as in "$f12" for function 12.
+var idPrefixes = [...]string{
+	typeSpace:   "t",
+	funcSpace:   "f",
+	tableSpace:  "T",
+	memorySpace: "M",
+	globalSpace: "g",
+	elemSpace:   "e",
+	dataSpace:   "d",
+}
+
+// Raiser converts modules back into wat.Node trees, the inverse of Lower.
+// The zero value, or a nil *Raiser, writes flat instructions and separate
+// export fields.
+type Raiser struct {
+	folded        bool
+	inlineExports bool
+}
+
+// Folded selects the folded s-expression style for instructions, as in
+// (i32.add (i32.const 1) (i32.const 2)).
+func (r *Raiser) Folded(value bool) *Raiser {
+	r.folded = value
+	return r
+}
+
+// InlineExports writes each export inside the definition or import that
+// it exports, rather than as a separate (export ...) field.
+func (r *Raiser) InlineExports(value bool) *Raiser {
+	r.inlineExports = value
+	return r
+}
+
+// Raise converts a module into a tree holding a single (module ...), ready
+// for wat.Formatter.  Every item is referred to by identifier: names are
+// taken from the module's Names where possible, and otherwise are
+// synthesized from the kind and index of the item, as in "$f12".  Lower
+// recognizes synthesized identifiers and does not record them as names.
+func (r *Raiser) Raise(module *wasm.Module) (*wat.Node, error) {
+	rs := &raiser{module: module}
+	if r != nil {
+		rs.folded = r.folded
+		rs.inlineExports = r.inlineExports
+	}
+	rs.assignIDs()
+	if rs.inlineExports {
+		rs.exports = make(map[exportKey][]*wasm.Export, len(module.Exports))
+		for _, export := range module.Exports {
+			key := exportKey{export.Kind, export.Index}
+			rs.exports[key] = append(rs.exports[key], export)
+		}
+	}
+
+	list := []*wat.Node{keywordNode("module")}
+	if module.Names != nil && module.Names.Module != "" {
+		list = append(list, idNode(sanitizeID(module.Names.Module)))
+	}
+	for _, field := range rs.fields() {
+		list = append(list, newlineNode(), field)
+	}
+	if rs.err != nil {
+		return nil, rs.err
+	}
+	return exprNode(exprNode(list...)), nil
+}
+
+type exportKey struct {
+	kind  wasm.ExternKind
+	index uint32
+}
+
+type raiser struct {
+	module        *wasm.Module
+	folded        bool
+	inlineExports bool
+	ids           [numSpaces][]string
+	exports       map[exportKey][]*wasm.Export
+	locals        []string
+	err           error
+
+	// labelNames holds the names of the labels of the function being
+	// raised, numLabels counts its blocks so far, and labels holds the
+	// identifiers of the enclosing blocks, innermost last, or "" for
+	// those without a name.
+	labelNames wasm.NameMap
+	numLabels  uint32
+	labels     []string
+}
+
+func (rs *raiser) assignIDs() {
+	module := rs.module
+	names := module.Names
+	if names == nil {
+		names = &wasm.Names{}
+	}
+	counts := [numSpaces]int{
+		typeSpace:   len(module.Types),
+		funcSpace:   int(module.NumImported(wasm.FuncExtern)) + len(module.Funcs),
+		tableSpace:  int(module.NumImported(wasm.TableExtern)) + len(module.Tables),
+		memorySpace: int(module.NumImported(wasm.MemoryExtern)) + len(module.Memories),
+		globalSpace: int(module.NumImported(wasm.GlobalExtern)) + len(module.Globals),
+		elemSpace:   len(module.Elems),
+		dataSpace:   len(module.Datas),
+	}
+	nameMaps := [numSpaces]wasm.NameMap{
+		typeSpace:   names.Types,
+		funcSpace:   names.Funcs,
+		tableSpace:  names.Tables,
+		memorySpace: names.Memories,
+		globalSpace: names.Globals,
+		elemSpace:   names.Elems,
+		dataSpace:   names.Datas,
+	}
+	for s := space(0); s < numSpaces; s++ {
+		rs.ids[s] = assignIDs(counts[s], nameMaps[s], func(i int) string {
+			return synthesizedID(s, uint32(i))
+		})
+	}
+}
+
+// localIDs names the parameters and locals of a function, as "$p0" and
+// "$l1" when they have no name.
+func (rs *raiser) localIDs(funcIndex uint32, numParams int, numLocals int) []string {
+	var names wasm.NameMap
+	if rs.module.Names != nil {
+		names = rs.module.Names.Locals[funcIndex]
+	}
+	return assignIDs(numParams+numLocals, names, func(i int) string {
+		return synthesizedLocalID(uint32(i), numParams)
+	})
+}
+
+// synthesizedID returns the identifier that Raise gives item index of
+// space s when it has no name.
+func synthesizedID(s space, index uint32) string {
+	return idPrefixes[s] + strconv.FormatUint(uint64(index), 10)
+}
+
+// synthesizedLocalID is like synthesizedID, but for parameter or local
+// index of a function with numParams parameters.
+func synthesizedLocalID(index uint32, numParams int) string {
+	if uint64(index) < uint64(numParams) {
+		return "p" + strconv.FormatUint(uint64(index), 10)
+	}
+	return "l" + strconv.FormatUint(uint64(index), 10)
+}
+
+// isSynthesized reports whether id has the form that Raise synthesizes
+// for an item whose synthesized identifier is base: base itself, or base
+// with the ".N" suffix that assignIDs adds to keep it distinct.  Lower
+// leaves such identifiers out of the name section.
+func isSynthesized(id string, base string) bool {
+	if !strings.HasPrefix(id, base) {
+		return false
+	}
+	suffix := id[len(base):]
+	if suffix == "" {
+		return true
+	}
+	if len(suffix) < 2 || suffix[0] != '.' {
+		return false
+	}
+	for _, ch := range suffix[1:] {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// assignIDs gives each of count items a distinct identifier, preferring
+// its name and falling back to synthesize(i).
+func assignIDs(count int, names wasm.NameMap, synthesize func(i int) string) []string {
+	ids := make([]string, count)
+	used := make(map[string]bool, count)
+	for index, name := range names {
+		if uint64(index) >= uint64(count) {
+			continue
+		}
+		ids[index] = sanitizeID(name)
+	}
+	for i, id := range ids {
+		switch {
+		case id == "":
+		case used[id]:
+			ids[i] = ""
+		default:
+			used[id] = true
+		}
+	}
+	for i, id := range ids {
+		if id != "" {
+			continue
+		}
+		id = synthesize(i)
+		for n := 1; used[id]; n++ {
+			id = synthesize(i) + "." + strconv.Itoa(n)
+		}
+		ids[i] = id
+		used[id] = true
+	}
+	return ids
+}
+
+// sanitizeID replaces the characters of name that cannot appear in an
+// identifier.
+func sanitizeID(name string) string {
+	return strings.Map(func(ch rune) rune {
+		if wat.IsIdentifierRune(ch) {
+			return ch
+		}
+		return '_'
+	}, name)
+}
+
+func (rs *raiser) fields() []*wat.Node {
+	module := rs.module
+	var fields []*wat.Node
+
+	for i, ft := range module.Types {
+		fields = append(fields, exprNode(
+			keywordNode("type"),
+			idNode(rs.ids[typeSpace][i]),
+			exprNode(append([]*wat.Node{keywordNode("func")}, rs.signature(ft, nil)...)...)))
+	}
+
+	var counts [4]uint32
+	for _, imp := range module.Imports {
+		if imp.Kind > wasm.GlobalExtern {
+			rs.failf("cannot raise import %q %q: invalid kind %#v", imp.Module, imp.Name, imp.Kind)
+			continue
+		}
+		index := counts[imp.Kind]
+		counts[imp.Kind]++
+		fields = append(fields, rs.importField(imp, index))
+	}
+
+	numImportedFuncs := counts[wasm.FuncExtern]
+	for i, fn := range module.Funcs {
+		fields = append(fields, rs.funcField(fn, numImportedFuncs+uint32(i)))
+	}
+	for i, table := range module.Tables {
+		index := counts[wasm.TableExtern] + uint32(i)
+		list := rs.head("table", tableSpace, wasm.TableExtern, index)
+		list = append(list, rs.tableType(table.Type)...)
+		fields = append(fields, exprNode(list...))
+	}
+	for i, memory := range module.Memories {
+		index := counts[wasm.MemoryExtern] + uint32(i)
+		list := rs.head("memory", memorySpace, wasm.MemoryExtern, index)
+		list = append(list, rs.limits(memory.Type.Limits)...)
+		fields = append(fields, exprNode(list...))
+	}
+	for i, global := range module.Globals {
+		index := counts[wasm.GlobalExtern] + uint32(i)
+		list := rs.head("global", globalSpace, wasm.GlobalExtern, index)
+		list = append(list, rs.globalType(global.Type))
+		list = append(list, rs.constExpr(global.Init)...)
+		fields = append(fields, exprNode(list...))
+	}
+
+	if !rs.inlineExports {
+		for _, export := range module.Exports {
+			if export.Kind > wasm.GlobalExtern {
+				rs.failf("cannot raise export %q: invalid kind %#v", export.Name, export.Kind)
+				continue
+			}
+			fields = append(fields, exprNode(
+				keywordNode("export"),
+				stringNode(export.Name),
+				exprNode(keywordNode(export.Kind.String()), rs.ref(externSpaces[export.Kind], export.Index))))
+		}
+	}
+
+	if module.Start != nil {
+		fields = append(fields, exprNode(keywordNode("start"), rs.ref(funcSpace, module.Start.Func)))
+	}
+	for i, elem := range module.Elems {
+		fields = append(fields, rs.elemField(elem, i))
+	}
+	for i, data := range module.Datas {
+		fields = append(fields, rs.dataField(data, i))
+	}
+	return fields
+}
+
+// head begins a definition with its keyword, identifier and, if exports
+// are inline, its exports.
+func (rs *raiser) head(kw string, s space, kind wasm.ExternKind, index uint32) []*wat.Node {
+	list := []*wat.Node{keywordNode(kw), idNode(rs.ids[s][index])}
+	for _, export := range rs.exports[exportKey{kind, index}] {
+		list = append(list, exprNode(keywordNode("export"), stringNode(export.Name)))
+	}
+	return list
+}
+
+func (rs *raiser) importField(imp *wasm.Import, index uint32) *wat.Node {
+	s := externSpaces[imp.Kind]
+	var desc []*wat.Node
+	switch imp.Kind {
+	case wasm.FuncExtern:
+		desc = rs.typeUse(imp.Type, index, 0)
+	case wasm.TableExtern:
+		desc = rs.tableType(imp.Table)
+	case wasm.MemoryExtern:
+		desc = rs.limits(imp.Memory.Limits)
+	default:
+		desc = []*wat.Node{rs.globalType(imp.Global)}
+	}
+
+	names := []*wat.Node{stringNode(imp.Module), stringNode(imp.Name)}
+	if len(rs.exports[exportKey{imp.Kind, index}]) != 0 {
+		list := rs.head(imp.Kind.String(), s, imp.Kind, index)
+		list = append(list, exprNode(append([]*wat.Node{keywordNode("import")}, names...)...))
+		return exprNode(append(list, desc...)...)
+	}
+
+	list := []*wat.Node{keywordNode(imp.Kind.String()), idNode(rs.ids[s][index])}
+	list = append(list, desc...)
+	return exprNode(append(append([]*wat.Node{keywordNode("import")}, names...), exprNode(list...))...)
+}
+
+func (rs *raiser) funcField(fn *wasm.Func, index uint32) *wat.Node {
+	list := rs.head("func", funcSpace, wasm.FuncExtern, index)
+	numLocals := 0
+	for _, local := range fn.Locals {
+		numLocals += int(local.Count)
+	}
+	list = append(list, rs.typeUse(fn.Type, index, numLocals)...)
+
+	numParams := 0
+	if ft := rs.funcType(fn.Type); ft != nil {
+		numParams = len(ft.Params)
+	}
+	rs.locals = rs.localIDs(index, numParams, numLocals)
+	if rs.module.Names != nil {
+		rs.labelNames = rs.module.Names.Labels[index]
+	}
+	rs.numLabels, rs.labels = 0, nil
+	i := numParams
+	for _, local := range fn.Locals {
+		for n := uint32(0); n < local.Count; n++ {
+			list = append(list, exprNode(keywordNode("local"), idNode(rs.locals[i]), keywordNode(local.Type.String())))
+			i++
+		}
+	}
+
+	instrs, err := wasm.DecodeExpr(fn.Body)
+	if err != nil {
+		rs.failf("cannot raise func %d: %v", index, err)
+	}
+	if n := len(instrs); n > 0 && instrs[n-1].Opcode == wasm.OpEnd {
+		instrs = instrs[:n-1]
+	}
+	if rs.folded {
+		var pos int
+		list = append(list, lines(rs.foldedInstrs(instrs, &pos))...)
+		if pos < len(instrs) {
+			rs.failf("cannot raise func %d: unexpected %q at offset %d", index, instrs[pos].Opcode.String(), instrs[pos].Origin.Offset)
+		}
+	} else {
+		for i := range instrs {
+			list = append(list, newlineNode())
+			list = append(list, rs.instr(&instrs[i])...)
+		}
+	}
+	rs.locals, rs.labelNames, rs.labels = nil, nil, nil
+	return exprNode(list...)
+}
+
+// typeUse writes a reference to a function's type, followed by its
+// parameters, with identifiers, and its results.
+func (rs *raiser) typeUse(typeIndex uint32, funcIndex uint32, numLocals int) []*wat.Node {
+	list := []*wat.Node{exprNode(keywordNode("type"), rs.ref(typeSpace, typeIndex))}
+	ft := rs.funcType(typeIndex)
+	if ft == nil {
+		return list
+	}
+	ids := rs.localIDs(funcIndex, len(ft.Params), numLocals)
+	return append(list, rs.signature(ft, ids)...)
+}
+
+// signature writes the params and results of a function type, giving each
+// param its own identifier if ids is not nil.
+func (rs *raiser) signature(ft *wasm.FuncType, ids []string) []*wat.Node {
+	var list []*wat.Node
+	switch {
+	case len(ft.Params) == 0:
+	case ids != nil:
+		for i, vt := range ft.Params {
+			list = append(list, exprNode(keywordNode("param"), idNode(ids[i]), keywordNode(vt.String())))
+		}
+	default:
+		list = append(list, exprNode(valTypeNodes("param", ft.Params)...))
+	}
+	if len(ft.Results) != 0 {
+		list = append(list, exprNode(valTypeNodes("result", ft.Results)...))
+	}
+	return list
+}
+
+func (rs *raiser) funcType(typeIndex uint32) *wasm.FuncType {
+	if uint64(typeIndex) < uint64(len(rs.module.Types)) {
+		return rs.module.Types[typeIndex]
+	}
+	return nil
+}
+
+func (rs *raiser) tableType(tt wasm.TableType) []*wat.Node {
+	return append(rs.limits(tt.Limits), keywordNode(tt.Elem.String()))
+}
+
+func (rs *raiser) limits(limits wasm.Limits) []*wat.Node {
+	list := []*wat.Node{numberNode(wat.NumFromUint64(uint64(limits.Min)))}
+	if limits.HasMax || limits.Shared {
+		list = append(list, numberNode(wat.NumFromUint64(uint64(limits.Max))))
+	}
+	if limits.Shared {
+		list = append(list, keywordNode("shared"))
+	}
+	return list
+}
+
+func (rs *raiser) globalType(gt wasm.GlobalType) *wat.Node {
+	if gt.Mutable {
+		return exprNode(keywordNode("mut"), keywordNode(gt.Type.String()))
+	}
+	return keywordNode(gt.Type.String())
+}
+
+func (rs *raiser) elemField(elem *wasm.Elem, index int) *wat.Node {
+	list := []*wat.Node{keywordNode("elem"), idNode(rs.ids[elemSpace][index])}
+	switch elem.Mode {
+	case wasm.ActiveSegment:
+		if elem.Table != 0 {
+			list = append(list, exprNode(keywordNode("table"), rs.ref(tableSpace, elem.Table)))
+		}
+		list = append(list, rs.offset(elem.Offset))
+	case wasm.DeclarativeSegment:
+		list = append(list, keywordNode("declare"))
+	}
+
+	if elem.Funcs != nil || elem.Exprs == nil {
+		list = append(list, keywordNode("func"))
+		for _, funcIndex := range elem.Funcs {
+			list = append(list, rs.ref(funcSpace, funcIndex))
+		}
+		return exprNode(list...)
+	}
+
+	list = append(list, keywordNode(elem.Type.String()))
+	for _, expr := range elem.Exprs {
+		instrs := rs.constExpr(expr)
+		if rs.folded && len(instrs) == 1 {
+			list = append(list, instrs[0])
+			continue
+		}
+		list = append(list, exprNode(append([]*wat.Node{keywordNode("item")}, instrs...)...))
+	}
+	return exprNode(list...)
+}
+
+func (rs *raiser) dataField(data *wasm.Data, index int) *wat.Node {
+	list := []*wat.Node{keywordNode("data"), idNode(rs.ids[dataSpace][index])}
+	if data.Mode == wasm.ActiveSegment {
+		if data.Memory != 0 {
+			list = append(list, exprNode(keywordNode("memory"), rs.ref(memorySpace, data.Memory)))
+		}
+		list = append(list, rs.offset(data.Offset))
+	}
+	if len(data.Init) != 0 {
+		list = append(list, stringNode(string(data.Init)))
+	}
+	return exprNode(list...)
+}
+
+// offset writes the offset of an active segment, as a bare folded
+// instruction where possible.
+func (rs *raiser) offset(expr wasm.Expr) *wat.Node {
+	instrs := rs.constExpr(expr)
+	if rs.folded && len(instrs) == 1 {
+		return instrs[0]
+	}
+	return exprNode(append([]*wat.Node{keywordNode("offset")}, instrs...)...)
+}
+
+// constExpr writes the instructions of a constant expression, without its
+// final "end".
+func (rs *raiser) constExpr(expr wasm.Expr) []*wat.Node {
+	list, err := wasm.DecodeExpr(expr)
+	if err != nil {
+		rs.failf("cannot raise constant expression % x: %v", []byte(expr), err)
+		return nil
+	}
+	if n := len(list); n > 0 && list[n-1].Opcode == wasm.OpEnd {
+		list = list[:n-1]
+	}
+	if rs.folded {
+		var pos int
+		return rs.foldedInstrs(list, &pos)
+	}
+	var nodes []*wat.Node
+	for i := range list {
+		nodes = append(nodes, rs.instr(&list[i])...)
+	}
+	return nodes
+}
+
+// foldedInstrs writes instructions as S-expressions until the end of the
+// list or until an "end" or "else", which is left at list[*pos].  The nodes
+// before an instruction become its operands only if each of them pushes a
+// single value, so that nothing is evaluated out of order.
+func (rs *raiser) foldedInstrs(list []wasm.Instr, pos *int) []*wat.Node {
+	var nodes []*wat.Node
+	var values int
+	for *pos < len(list) {
+		instr := &list[*pos]
+		if instr.Opcode == wasm.OpEnd || instr.Opcode == wasm.OpElse {
+			return nodes
+		}
+		*pos++
+
+		children := rs.instr(instr)
+		params, results, known := rs.arity(instr)
+		isBlock := instr.Opcode == wasm.OpBlock || instr.Opcode == wasm.OpLoop
+		if known && !isBlock && params <= values {
+			children = append(children, nodes[len(nodes)-params:]...)
+			nodes = nodes[:len(nodes)-params]
+			values -= params
+		} else {
+			values = 0
+		}
+
+		switch instr.Opcode {
+		case wasm.OpBlock, wasm.OpLoop:
+			children = append(children, lines(rs.foldedInstrs(list, pos))...)
+		case wasm.OpIf:
+			then := append([]*wat.Node{keywordNode("then")}, lines(rs.foldedInstrs(list, pos))...)
+			children = append(children, exprNode(then...))
+			if *pos < len(list) && list[*pos].Opcode == wasm.OpElse {
+				*pos++
+				els := append([]*wat.Node{keywordNode("else")}, lines(rs.foldedInstrs(list, pos))...)
+				children = append(children, exprNode(els...))
+			}
+		}
+		if isBlock || instr.Opcode == wasm.OpIf {
+			if *pos < len(list) && list[*pos].Opcode == wasm.OpEnd {
+				*pos++
+				rs.closeLabel()
+			}
+		}
+		nodes = append(nodes, exprNode(children...))
+
+		if known && results == 1 {
+			values++
+		} else {
+			values = 0
+		}
+	}
+	return nodes
+}
+
+// arity returns the number of operands that an instruction pops and the
+// number of values that it pushes, if they are known without validating.
+func (rs *raiser) arity(instr *wasm.Instr) (params int, results int, known bool) {
+	switch instr.Opcode {
+	case wasm.OpBlock, wasm.OpLoop, wasm.OpIf:
+		if index, ok := instr.BlockType.TypeIndex(); ok {
+			ft := rs.funcType(index)
+			if ft == nil {
+				return 0, 0, false
+			}
+			params, results = len(ft.Params), len(ft.Results)
+		} else if _, ok := instr.BlockType.ValType(); ok {
+			results = 1
+		}
+		if instr.Opcode == wasm.OpIf {
+			params++
+		}
+		return params, results, true
+	case wasm.OpCall:
+		if ft := rs.module.FuncType(instr.Index); ft != nil {
+			return len(ft.Params), len(ft.Results), true
+		}
+		return 0, 0, false
+	case wasm.OpCallIndirect:
+		if ft := rs.funcType(instr.Index); ft != nil {
+			return len(ft.Params) + 1, len(ft.Results), true
+		}
+		return 0, 0, false
+	case wasm.OpLocalGet, wasm.OpGlobalGet, wasm.OpRefNull:
+		return 0, 1, true
+	case wasm.OpLocalSet, wasm.OpGlobalSet, wasm.OpDrop:
+		return 1, 0, true
+	case wasm.OpLocalTee, wasm.OpTableGet, wasm.OpRefIsNull:
+		return 1, 1, true
+	case wasm.OpTableSet:
+		return 2, 0, true
+	case wasm.OpTableGrow:
+		return 2, 1, true
+	case wasm.OpTableFill:
+		return 3, 0, true
+	case wasm.OpSelect, wasm.OpSelectT:
+		return 3, 1, true
+	}
+	info := instr.Opcode.Info()
+	if info == nil || info.Dynamic {
+		return 0, 0, false
+	}
+	return len(info.Params), len(info.Results), true
+}
+
+// instr writes an instruction and its immediates, in the order of the text
+// format.
+func (rs *raiser) instr(instr *wasm.Instr) []*wat.Node {
+	nodes := []*wat.Node{keywordNode(instr.Opcode.String())}
+	switch instr.Opcode {
+	case wasm.OpCallIndirect:
+		if instr.Index2 != 0 {
+			nodes = append(nodes, rs.ref(tableSpace, instr.Index2))
+		}
+		return append(nodes, exprNode(keywordNode("type"), rs.ref(typeSpace, instr.Index)))
+
+	case wasm.OpTableInit:
+		if instr.Index2 != 0 {
+			nodes = append(nodes, rs.ref(tableSpace, instr.Index2))
+		}
+		return append(nodes, rs.ref(elemSpace, instr.Index))
+
+	case wasm.OpTableCopy:
+		if instr.Index != 0 || instr.Index2 != 0 {
+			nodes = append(nodes, rs.ref(tableSpace, instr.Index), rs.ref(tableSpace, instr.Index2))
+		}
+		return nodes
+
+	case wasm.OpBlock, wasm.OpLoop, wasm.OpIf:
+		if id := rs.openLabel(); id != "" {
+			nodes = append(nodes, idNode(id))
+		}
+
+	case wasm.OpEnd:
+		rs.closeLabel()
+	}
+
+	info := instr.Opcode.Info()
+	if info == nil {
+		rs.failf("cannot raise instruction %#v", instr.Opcode)
+		return nodes
+	}
+	for _, imm := range info.Immediates {
+		switch imm {
+		case wasm.BlockTypeImmediate:
+			if vt, ok := instr.BlockType.ValType(); ok {
+				nodes = append(nodes, exprNode(keywordNode("result"), keywordNode(vt.String())))
+			} else if index, ok := instr.BlockType.TypeIndex(); ok {
+				nodes = append(nodes, exprNode(keywordNode("type"), rs.ref(typeSpace, index)))
+			}
+		case wasm.LabelImmediate:
+			nodes = append(nodes, rs.labelRef(instr.Index))
+		case wasm.LabelVecImmediate:
+			for _, label := range instr.Labels {
+				nodes = append(nodes, rs.labelRef(label))
+			}
+		case wasm.FuncImmediate:
+			nodes = append(nodes, rs.ref(funcSpace, instr.Index))
+		case wasm.TableImmediate:
+			if instr.Index != 0 {
+				nodes = append(nodes, rs.ref(tableSpace, instr.Index))
+			}
+		case wasm.LocalImmediate:
+			if uint64(instr.Index) < uint64(len(rs.locals)) {
+				nodes = append(nodes, idNode(rs.locals[instr.Index]))
+			} else {
+				nodes = append(nodes, numberNode(wat.NumFromUint64(uint64(instr.Index))))
+			}
+		case wasm.GlobalImmediate:
+			nodes = append(nodes, rs.ref(globalSpace, instr.Index))
+		case wasm.ElemImmediate:
+			nodes = append(nodes, rs.ref(elemSpace, instr.Index))
+		case wasm.DataImmediate:
+			nodes = append(nodes, rs.ref(dataSpace, instr.Index))
+		case wasm.MemArgImmediate:
+			nodes = append(nodes, rs.memArg(instr.MemArg, info.NaturalAlign)...)
+		case wasm.LaneImmediate:
+			nodes = append(nodes, numberNode(wat.NumFromUint64(uint64(instr.Lane))))
+		case wasm.ShuffleImmediate:
+			for _, lane := range instr.V128 {
+				nodes = append(nodes, numberNode(wat.NumFromUint64(uint64(lane))))
+			}
+		case wasm.I32Immediate:
+			nodes = append(nodes, numberNode(wat.NumFromInt64(int64(int32(uint32(instr.Value))))))
+		case wasm.I64Immediate:
+			nodes = append(nodes, numberNode(wat.NumFromInt64(int64(instr.Value))))
+		case wasm.F32Immediate:
+			nodes = append(nodes, numberNode(wat.NumFromFloat32Bits(uint32(instr.Value), wat.ShortestFloat)))
+		case wasm.F64Immediate:
+			nodes = append(nodes, numberNode(wat.NumFromFloat64Bits(instr.Value, wat.ShortestFloat)))
+		case wasm.V128Immediate:
+			// Written as four i32 lanes, which keeps every bit.
+			nodes = append(nodes, keywordNode("i32x4"))
+			for i := 0; i < 16; i += 4 {
+				nodes = append(nodes, numberNode(hexNum(binary.LittleEndian.Uint32(instr.V128[i:]))))
+			}
+		case wasm.HeapTypeImmediate:
+			if instr.HeapType == wasm.ExternRef {
+				nodes = append(nodes, keywordNode("extern"))
+			} else {
+				nodes = append(nodes, keywordNode("func"))
+			}
+		case wasm.ValTypeVecImmediate:
+			nodes = append(nodes, exprNode(valTypeNodes("result", instr.Types)...))
+		}
+	}
+	return nodes
+}
+
+// openLabel enters the next block of the function, and returns the
+// identifier of its label, or "" if it has no name.
+func (rs *raiser) openLabel() string {
+	var id string
+	if name, found := rs.labelNames[rs.numLabels]; found {
+		id = sanitizeID(name)
+	}
+	rs.numLabels++
+	rs.labels = append(rs.labels, id)
+	return id
+}
+
+func (rs *raiser) closeLabel() {
+	if n := len(rs.labels); n > 0 {
+		rs.labels = rs.labels[:n-1]
+	}
+}
+
+// labelRef writes a reference to an enclosing block by identifier, unless
+// it has none or a nearer block shadows it, and otherwise by depth.
+func (rs *raiser) labelRef(depth uint32) *wat.Node {
+	n := uint64(len(rs.labels))
+	if uint64(depth) < n {
+		target := n - 1 - uint64(depth)
+		id := rs.labels[target]
+		shadowed := false
+		for _, inner := range rs.labels[target+1:] {
+			shadowed = shadowed || inner == id
+		}
+		if id != "" && !shadowed {
+			return idNode(id)
+		}
+	}
+	return numberNode(wat.NumFromUint64(uint64(depth)))
+}
+
+// memArg writes the offset and alignment of a memory access, leaving out
+// the defaults.
+func (rs *raiser) memArg(memArg wasm.MemArg, naturalAlign uint32) []*wat.Node {
+	var nodes []*wat.Node
+	if memArg.Offset != 0 {
+		nodes = append(nodes, keywordNode("offset="+strconv.FormatUint(uint64(memArg.Offset), 10)))
+	}
+	if memArg.Align != naturalAlign {
+		if memArg.Align >= 32 {
+			rs.failf("cannot raise alignment 2**%d", memArg.Align)
+			return nodes
+		}
+		nodes = append(nodes, keywordNode("align="+strconv.FormatUint(1<<memArg.Align, 10)))
+	}
+	return nodes
+}
+
+// lines puts each node on a line of its own.
+func lines(nodes []*wat.Node) []*wat.Node {
+	out := make([]*wat.Node, 0, 2*len(nodes))
+	for _, node := range nodes {
+		out = append(out, newlineNode(), node)
+	}
+	return out
+}
+
+func hexNum(u32 uint32) wat.Num {
+	var num wat.Num
+	num.Integer = strconv.FormatUint(uint64(u32), 16)
+	num.Flags = wat.FlagHex
+	return num
+}
+
+// ref refers to an item by its identifier, or by number if it does not
+// exist.
+func (rs *raiser) ref(s space, index uint32) *wat.Node {
+	if uint64(index) < uint64(len(rs.ids[s])) {
+		return idNode(rs.ids[s][index])
+	}
+	return numberNode(wat.NumFromUint64(uint64(index)))
+}
+
+func (rs *raiser) failf(format string, args ...any) {
+	if rs.err == nil {
+		rs.err = fmt.Errorf(format, args...)
+	}
+}
+
+func valTypeNodes(kw string, list []wasm.ValType) []*wat.Node {
+	nodes := make([]*wat.Node, 0, 1+len(list))
+	nodes = append(nodes, keywordNode(kw))
+	for _, vt := range list {
+		nodes = append(nodes, keywordNode(vt.String()))
+	}
+	return nodes
+}
+
+// newlineNode asks wat.Formatter to begin a new line.
+func newlineNode() *wat.Node {
+	return &wat.Node{Type: wat.SpaceNode, Value: wat.Space{Type: wat.LF, Count: 1}}
+}
+
+func exprNode(list ...*wat.Node) *wat.Node {
+	return &wat.Node{Type: wat.ExprNode, Value: list}
+}
+
+func keywordNode(kw string) *wat.Node {
+	return &wat.Node{Type: wat.KeywordNode, Value: kw}
+}
+
+func idNode(id string) *wat.Node {
+	return &wat.Node{Type: wat.IdentifierNode, Value: "$" + id}
+}
+
+func stringNode(str string) *wat.Node {
+	return &wat.Node{Type: wat.StringNode, Value: str}
+}
+
+func numberNode(num wat.Num) *wat.Node {
+	return &wat.Node{Type: wat.NumberNode, Value: num}
+}
@@ -0,0 +1,144 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+func TestRaise(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Raiser *Raiser
+		Module *wasm.Module
+		Expect string
+	}
+
+	module := &wasm.Module{
+		Types: []*wasm.FuncType{{Params: []wasm.ValType{wasm.I32}, Results: []wasm.ValType{wasm.I32}}},
+		Imports: []*wasm.Import{
+			{Module: "env", Name: "g", Kind: wasm.GlobalExtern, Global: wasm.GlobalType{Type: wasm.I32}},
+		},
+		Funcs: []*wasm.Func{
+			{Type: 0, Locals: []wasm.Local{{Count: 2, Type: wasm.I64}}, Body: wasm.Expr{
+				0x02, 0x40, 0x20, 0x00, 0x0d, 0x00, 0x0b,
+				0x20, 0x00, 0x04, 0x7f, 0x41, 0x01, 0x05, 0x41, 0x02, 0x0b,
+				0x20, 0x00, 0x28, 0x02, 0x04, 0x6a, 0x0b,
+			}},
+		},
+		Memories: []*wasm.Memory{{Type: wasm.MemoryType{Limits: wasm.Limits{Min: 1}}}},
+		Globals: []*wasm.Global{
+			{Type: wasm.GlobalType{Type: wasm.I32, Mutable: true}, Init: wasm.Expr{0x41, 0x6a, 0x23, 0x00, 0x6a, 0x0b}},
+		},
+		Exports: []*wasm.Export{{Name: "main", Kind: wasm.FuncExtern, Index: 0}},
+		Elems: []*wasm.Elem{
+			{Mode: wasm.PassiveSegment, Type: wasm.FuncRef, Exprs: []wasm.Expr{{0xd2, 0x00, 0x0b}}},
+		},
+		Datas: []*wasm.Data{
+			{Mode: wasm.ActiveSegment, Offset: wasm.Expr{0x41, 0x08, 0x0b}, Init: []byte("hi")},
+		},
+	}
+	named := *module
+	named.Names = &wasm.Names{
+		Module: "my module",
+		Funcs:  wasm.NameMap{0: "main"},
+		Locals: wasm.IndirectNameMap{0: {0: "x", 2: "x"}},
+		Labels: wasm.IndirectNameMap{0: {0: "done"}},
+		Types:  wasm.NameMap{0: "l1"},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Synthesized",
+			Module: module,
+			Expect: `(module
+  (type $t0 (func (param i32) (result i32)))
+  (import "env" "g" (global $g0 i32))
+  (func $f0 (type $t0) (param $p0 i32) (result i32) (local $l1 i64)
+    (local $l2 i64)
+    block
+      local.get $p0
+      br_if 0
+    end
+    local.get $p0
+    if (result i32)
+      i32.const 1
+    else
+      i32.const 2
+    end
+    local.get $p0
+    i32.load offset=4
+    i32.add)
+  (memory $M0 1)
+  (global $g1 (mut i32) i32.const -22 global.get $g0 i32.add)
+  (export "main" (func $f0))
+  (elem $e0 funcref (item ref.func $f0))
+  (data $d0 (offset i32.const 8) "hi"))
+`,
+		},
+		{
+			Name:   "FoldedInline",
+			Raiser: new(Raiser).Folded(true).InlineExports(true),
+			Module: &named,
+			Expect: `(module $my_module
+  (type $l1 (func (param i32) (result i32)))
+  (import "env" "g" (global $g0 i32))
+  (func $main (export "main") (type $l1) (param $x i32) (result i32)
+    (local $l1 i64) (local $l2 i64)
+    (block $done (local.get $x) (br_if $done))
+    (i32.add
+      (if (result i32) (local.get $x) (then (i32.const 1)) (else (i32.const 2)))
+      (i32.load offset=4 (local.get $x))))
+  (memory $M0 1)
+  (global $g1 (mut i32) (i32.add (i32.const -22) (global.get $g0)))
+  (elem $e0 funcref (ref.func $main))
+  (data $d0 (i32.const 8) "hi"))
+`,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			root, err := row.Raiser.Raise(row.Module)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := string(new(wat.Formatter).Append(nil, root)); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+
+			// Lowering the result must give back the same module.
+			lowered, err := lower(t, row.Expect)
+			if err != nil {
+				t.Fatalf("Lower: unexpected error: %v", err)
+			}
+			stripOrigins(lowered)
+			if row.Module.Names == nil && lowered.Names != nil {
+				t.Errorf("lowering recorded synthesized names: %+v", lowered.Names)
+			}
+			lowered.Names = nil
+			expect := *row.Module
+			expect.Names = nil
+			if row.Raiser != nil && row.Raiser.inlineExports {
+				expect.Exports = lowered.Exports
+			}
+			if !reflect.DeepEqual(lowered, &expect) {
+				t.Errorf("wrong round trip:\n\texpect: %+v\n\tactual: %+v", &expect, lowered)
+			}
+		})
+	}
+}
+
+func TestRaise_Errors(t *testing.T) {
+	module := &wasm.Module{
+		Types: []*wasm.FuncType{{}},
+		Funcs: []*wasm.Func{{Type: 0, Body: wasm.Expr{0x01, 0xff, 0x0b}}},
+	}
+	_, err := (*Raiser)(nil).Raise(module)
+	expect := "cannot raise func 0: wasm: offset 0x1: expression: illegal opcode 0xff"
+	if err == nil || err.Error() != expect {
+		t.Errorf("wrong error:\n\texpect: %s\n\tactual: %v", expect, err)
+	}
+}
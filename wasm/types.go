@@ -0,0 +1,70 @@
+package wasm
+
+type FuncType struct {
+	Params  []ValType
+	Results []ValType
+	Origin  Origin
+}
+
+func (ft *FuncType) Equals(other *FuncType) bool {
+	return equalValTypes(ft.Params, other.Params) && equalValTypes(ft.Results, other.Results)
+}
+
+func (ft *FuncType) String() string {
+	var scratch [64]byte
+	return string(ft.AppendTo(scratch[:0]))
+}
+
+func (ft *FuncType) AppendTo(out []byte) []byte {
+	out = append(out, "(func"...)
+	if len(ft.Params) > 0 {
+		out = append(out, " (param"...)
+		for _, vt := range ft.Params {
+			out = append(out, ' ')
+			out = vt.AppendTo(out, false)
+		}
+		out = append(out, ')')
+	}
+	if len(ft.Results) > 0 {
+		out = append(out, " (result"...)
+		for _, vt := range ft.Results {
+			out = append(out, ' ')
+			out = vt.AppendTo(out, false)
+		}
+		out = append(out, ')')
+	}
+	return append(out, ')')
+}
+
+type Limits struct {
+	Min    uint32
+	Max    uint32
+	HasMax bool
+	Shared bool
+}
+
+type TableType struct {
+	Elem   ValType
+	Limits Limits
+}
+
+type MemoryType struct {
+	Limits Limits
+}
+
+type GlobalType struct {
+	Type    ValType
+	Mutable bool
+}
+
+func equalValTypes(a, b []ValType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,822 @@
+package wasm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxPages is the largest size of a 32-bit memory, in 64KiB pages.
+const maxPages = 65536
+
+// Validate checks the module against the validation rules of the
+// specification, allowing every feature that this package knows of.  As
+// the specification allows, imports may repeat a module and name, but
+// export names must be unique.
+//
+// The error, if any, is a ValidationErrors.  At most one error is reported
+// for each function body and constant expression, as the type of the
+// operand stack is unknown after the first.
+func (module *Module) Validate() error {
+	v := &validator{module: module}
+	v.validate()
+	return v.errs.Err()
+}
+
+type validator struct {
+	module   *Module
+	funcs    []uint32
+	tables   []TableType
+	memories []MemoryType
+	globals  []GlobalType
+
+	// refs holds the functions that ref.func may refer to within
+	// function bodies: those that appear elsewhere in the module.
+	refs map[uint32]bool
+
+	errs ValidationErrors
+}
+
+func (v *validator) errorf(where string, origin Origin, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{
+		Where:  where,
+		Origin: origin,
+		Detail: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate() {
+	module := v.module
+
+	for i, ft := range module.Types {
+		where := fmt.Sprintf("type %d", i)
+		v.valTypes(where, ft.Origin, ft.Params)
+		v.valTypes(where, ft.Origin, ft.Results)
+	}
+
+	for _, imp := range module.Imports {
+		where := fmt.Sprintf("import %q %q", imp.Module, imp.Name)
+		switch imp.Kind {
+		case FuncExtern:
+			if uint64(imp.Type) >= uint64(len(module.Types)) {
+				v.errorf(where, imp.Origin, "unknown type %d", imp.Type)
+			}
+			v.funcs = append(v.funcs, imp.Type)
+		case TableExtern:
+			v.tableType(where, imp.Origin, imp.Table)
+			v.tables = append(v.tables, imp.Table)
+		case MemoryExtern:
+			v.memoryType(where, imp.Origin, imp.Memory)
+			v.memories = append(v.memories, imp.Memory)
+		case GlobalExtern:
+			v.valTypes(where, imp.Origin, []ValType{imp.Global.Type})
+			v.globals = append(v.globals, imp.Global)
+		default:
+			v.errorf(where, imp.Origin, "invalid import kind %#v", imp.Kind)
+		}
+	}
+
+	numImportedFuncs := uint32(len(v.funcs))
+	for i, fn := range module.Funcs {
+		where := fmt.Sprintf("func %d", numImportedFuncs+uint32(i))
+		if uint64(fn.Type) >= uint64(len(module.Types)) {
+			v.errorf(where, fn.Origin, "unknown type %d", fn.Type)
+		}
+		var numLocals uint64
+		for _, local := range fn.Locals {
+			v.valTypes(where, fn.Origin, []ValType{local.Type})
+			numLocals += uint64(local.Count)
+		}
+		if numLocals > 0xffffffff {
+			v.errorf(where, fn.Origin, "too many locals")
+		}
+		v.funcs = append(v.funcs, fn.Type)
+	}
+	for _, table := range module.Tables {
+		v.tableType(fmt.Sprintf("table %d", len(v.tables)), table.Origin, table.Type)
+		v.tables = append(v.tables, table.Type)
+	}
+	for _, memory := range module.Memories {
+		v.memoryType(fmt.Sprintf("memory %d", len(v.memories)), memory.Origin, memory.Type)
+		v.memories = append(v.memories, memory.Type)
+	}
+	if len(v.memories) > 1 {
+		v.errorf("module", module.Origin, "multiple memories")
+	}
+
+	v.collectRefs()
+
+	// A global's initializer may read only imported globals.
+	numImportedGlobals := module.NumImported(GlobalExtern)
+	for _, global := range module.Globals {
+		where := fmt.Sprintf("global %d", len(v.globals))
+		v.valTypes(where, global.Origin, []ValType{global.Type.Type})
+		v.constExpr(where, global.Origin, global.Init, global.Type.Type, numImportedGlobals)
+		v.globals = append(v.globals, global.Type)
+	}
+
+	names := make(map[string]bool, len(module.Exports))
+	for _, export := range module.Exports {
+		where := fmt.Sprintf("export %q", export.Name)
+		if names[export.Name] {
+			v.errorf(where, export.Origin, "duplicate export name")
+		}
+		names[export.Name] = true
+		v.externIndex(where, export.Origin, export.Kind, export.Index)
+	}
+
+	if start := module.Start; start != nil {
+		if v.externIndex("start", start.Origin, FuncExtern, start.Func) {
+			if ft := v.funcType(start.Func); ft != nil && (len(ft.Params) != 0 || len(ft.Results) != 0) {
+				v.errorf("start", start.Origin, "start function must have type [] -> [], not %v", ft)
+			}
+		}
+	}
+
+	for i, elem := range module.Elems {
+		v.elem(fmt.Sprintf("elem %d", i), elem)
+	}
+
+	if module.DataCount != nil && uint64(*module.DataCount) != uint64(len(module.Datas)) {
+		v.errorf("module", module.Origin, "data count %d does not match %d data segments", *module.DataCount, len(module.Datas))
+	}
+	for i, data := range module.Datas {
+		where := fmt.Sprintf("data %d", i)
+		if data.Mode != ActiveSegment {
+			continue
+		}
+		if uint64(data.Memory) >= uint64(len(v.memories)) {
+			v.errorf(where, data.Origin, "unknown memory %d", data.Memory)
+		}
+		v.constExpr(where, data.Origin, data.Offset, I32, uint32(len(v.globals)))
+	}
+
+	for i, fn := range module.Funcs {
+		v.funcBody(numImportedFuncs+uint32(i), fn)
+	}
+}
+
+func (v *validator) valTypes(where string, origin Origin, list []ValType) {
+	for _, vt := range list {
+		if !vt.IsValid() {
+			v.errorf(where, origin, "invalid value type %v", vt)
+			return
+		}
+	}
+}
+
+func (v *validator) tableType(where string, origin Origin, tt TableType) {
+	if !tt.Elem.IsRef() {
+		v.errorf(where, origin, "invalid element type %v", tt.Elem)
+	}
+	if tt.Limits.Shared {
+		v.errorf(where, origin, "tables cannot be shared")
+	}
+	v.limits(where, origin, tt.Limits)
+}
+
+func (v *validator) memoryType(where string, origin Origin, mt MemoryType) {
+	limits := mt.Limits
+	if limits.Min > maxPages || (limits.HasMax && limits.Max > maxPages) {
+		v.errorf(where, origin, "memory size must be at most %d pages (4GiB)", maxPages)
+	}
+	if limits.Shared && !limits.HasMax {
+		v.errorf(where, origin, "shared memory must have maximum")
+	}
+	v.limits(where, origin, limits)
+}
+
+func (v *validator) limits(where string, origin Origin, limits Limits) {
+	if limits.HasMax && limits.Min > limits.Max {
+		v.errorf(where, origin, "size minimum must not be greater than maximum")
+	}
+}
+
+// externIndex checks the index of an export or of the start function.
+func (v *validator) externIndex(where string, origin Origin, kind ExternKind, index uint32) bool {
+	var count int
+	switch kind {
+	case FuncExtern:
+		count = len(v.funcs)
+	case TableExtern:
+		count = len(v.tables)
+	case MemoryExtern:
+		count = len(v.memories)
+	case GlobalExtern:
+		count = len(v.globals)
+	default:
+		v.errorf(where, origin, "invalid export kind %#v", kind)
+		return false
+	}
+	if uint64(index) >= uint64(count) {
+		v.errorf(where, origin, "unknown %s %d", kind, index)
+		return false
+	}
+	return true
+}
+
+// funcType returns the type of a function, or nil if either the function
+// or its type does not exist.
+func (v *validator) funcType(funcIndex uint32) *FuncType {
+	if uint64(funcIndex) >= uint64(len(v.funcs)) {
+		return nil
+	}
+	if typeIndex := v.funcs[funcIndex]; uint64(typeIndex) < uint64(len(v.module.Types)) {
+		return v.module.Types[typeIndex]
+	}
+	return nil
+}
+
+// collectRefs finds the functions that are referred to outside of function
+// bodies, by element segments, exports and the initializers of globals.
+func (v *validator) collectRefs() {
+	module := v.module
+	v.refs = make(map[uint32]bool)
+	addExpr := func(expr Expr) {
+		// Malformed expressions are reported later.
+		list, _ := DecodeExpr(expr)
+		for _, instr := range list {
+			if instr.Opcode == OpRefFunc {
+				v.refs[instr.Index] = true
+			}
+		}
+	}
+	for _, global := range module.Globals {
+		addExpr(global.Init)
+	}
+	for _, export := range module.Exports {
+		if export.Kind == FuncExtern {
+			v.refs[export.Index] = true
+		}
+	}
+	for _, elem := range module.Elems {
+		for _, funcIndex := range elem.Funcs {
+			v.refs[funcIndex] = true
+		}
+		for _, expr := range elem.Exprs {
+			addExpr(expr)
+		}
+	}
+}
+
+func (v *validator) elem(where string, elem *Elem) {
+	if !elem.Type.IsRef() {
+		v.errorf(where, elem.Origin, "invalid element type %v", elem.Type)
+		return
+	}
+	if elem.Mode == ActiveSegment {
+		if uint64(elem.Table) >= uint64(len(v.tables)) {
+			v.errorf(where, elem.Origin, "unknown table %d", elem.Table)
+		} else if tt := v.tables[elem.Table]; tt.Elem != elem.Type {
+			v.errorf(where, elem.Origin, "type mismatch: segment of type %v for table of type %v", elem.Type, tt.Elem)
+		}
+		v.constExpr(where, elem.Origin, elem.Offset, I32, uint32(len(v.globals)))
+	}
+
+	if elem.Exprs == nil {
+		if elem.Type != FuncRef {
+			v.errorf(where, elem.Origin, "type mismatch: function indices in segment of type %v", elem.Type)
+		}
+		for _, funcIndex := range elem.Funcs {
+			if uint64(funcIndex) >= uint64(len(v.funcs)) {
+				v.errorf(where, elem.Origin, "unknown function %d", funcIndex)
+				break
+			}
+		}
+		return
+	}
+	for _, expr := range elem.Exprs {
+		v.constExpr(where, elem.Origin, expr, elem.Type, uint32(len(v.globals)))
+	}
+}
+
+// constExpr checks a constant expression that must produce a value of type
+// vt.  Only the first numGlobals globals may be read, and only if they are
+// immutable.
+func (v *validator) constExpr(where string, origin Origin, expr Expr, vt ValType, numGlobals uint32) {
+	ev := &exprValidator{v: v, returns: []ValType{vt}, isConst: true, numGlobals: numGlobals}
+	ev.run(where, origin, expr, nil)
+}
+
+func (v *validator) funcBody(funcIndex uint32, fn *Func) {
+	where := fmt.Sprintf("func %d", funcIndex)
+	ft := v.funcType(funcIndex)
+	if ft == nil {
+		// The missing type has already been reported.
+		return
+	}
+	ev := &exprValidator{v: v, params: ft.Params, locals: fn.Locals, returns: ft.Results}
+	ev.run(where, fn.Origin, fn.Body, fn.InstrOrigins)
+}
+
+// ctrlFrame is an entry of the control stack.
+type ctrlFrame struct {
+	opcode      Opcode
+	params      []ValType
+	results     []ValType
+	height      int
+	unreachable bool
+}
+
+// labelTypes returns the types that a branch to the frame must provide.
+func (frame *ctrlFrame) labelTypes() []ValType {
+	if frame.opcode == OpLoop {
+		return frame.params
+	}
+	return frame.results
+}
+
+// unknownType is the type of an operand in unreachable code, which
+// matches any other.
+const unknownType ValType = 0
+
+// exprValidator type-checks a function body or constant expression with
+// the operand and control stacks of the algorithm in the appendix of the
+// specification.
+type exprValidator struct {
+	v       *validator
+	params  []ValType
+	locals  []Local
+	returns []ValType
+
+	isConst    bool
+	numGlobals uint32
+
+	vals  []ValType
+	ctrls []ctrlFrame
+
+	// detail describes the first problem found.
+	detail string
+}
+
+func (ev *exprValidator) failf(format string, args ...any) {
+	if ev.detail == "" {
+		ev.detail = fmt.Sprintf(format, args...)
+	}
+}
+
+// run checks every instruction of expr, reporting the first problem
+// against where.  origins, if it matches the instructions, gives the
+// Origin of each; otherwise instructions are located by offset within
+// origin.
+func (ev *exprValidator) run(where string, origin Origin, expr Expr, origins []Origin) {
+	list, err := DecodeExpr(expr)
+	if err != nil {
+		ev.v.errorf(where, origin, "%s", strings.TrimPrefix(err.Error(), "wasm: "))
+		return
+	}
+
+	ev.pushCtrl(OpBlock, nil, ev.returns)
+	for i := range list {
+		instr := &list[i]
+		if len(ev.ctrls) == 0 {
+			ev.failf("instructions after the final end")
+		} else {
+			ev.instr(instr)
+		}
+		if ev.detail != "" {
+			at := Origin{Offset: instr.Origin.Offset, Node: origin.Node}
+			if len(origins) == len(list) {
+				at = origins[i]
+			}
+			ev.v.errorf(where, at, "offset %#x: %v: %s", instr.Origin.Offset, instr.Opcode, ev.detail)
+			return
+		}
+	}
+	if len(ev.ctrls) != 0 {
+		ev.v.errorf(where, origin, "missing end")
+	}
+}
+
+func (ev *exprValidator) push(vt ValType) {
+	ev.vals = append(ev.vals, vt)
+}
+
+func (ev *exprValidator) pushVals(list []ValType) {
+	ev.vals = append(ev.vals, list...)
+}
+
+// pop pops an operand of type expect, or of any type if expect is
+// unknownType, and returns its type.
+func (ev *exprValidator) pop(expect ValType) ValType {
+	frame := &ev.ctrls[len(ev.ctrls)-1]
+	if len(ev.vals) == frame.height {
+		if !frame.unreachable {
+			ev.failf("type mismatch: expected %s but nothing on stack", typeName(expect))
+		}
+		return expect
+	}
+	actual := ev.vals[len(ev.vals)-1]
+	ev.vals = ev.vals[:len(ev.vals)-1]
+	if actual == unknownType {
+		return expect
+	}
+	if expect != unknownType && actual != expect {
+		ev.failf("type mismatch: expected %s but got %s", typeName(expect), typeName(actual))
+	}
+	return actual
+}
+
+func (ev *exprValidator) popVals(list []ValType) {
+	for i := len(list) - 1; i >= 0; i-- {
+		ev.pop(list[i])
+	}
+}
+
+func (ev *exprValidator) pushCtrl(op Opcode, params []ValType, results []ValType) {
+	ev.ctrls = append(ev.ctrls, ctrlFrame{opcode: op, params: params, results: results, height: len(ev.vals)})
+	ev.pushVals(params)
+}
+
+func (ev *exprValidator) popCtrl() ctrlFrame {
+	frame := ev.ctrls[len(ev.ctrls)-1]
+	ev.popVals(frame.results)
+	if len(ev.vals) != frame.height {
+		ev.failf("type mismatch: %d extra values at end of block", len(ev.vals)-frame.height)
+	}
+	ev.vals = ev.vals[:frame.height]
+	ev.ctrls = ev.ctrls[:len(ev.ctrls)-1]
+	return frame
+}
+
+func (ev *exprValidator) unreachable() {
+	frame := &ev.ctrls[len(ev.ctrls)-1]
+	ev.vals = ev.vals[:frame.height]
+	frame.unreachable = true
+}
+
+// label returns the frame that a branch to the given depth targets.
+func (ev *exprValidator) label(depth uint32) *ctrlFrame {
+	return &ev.ctrls[len(ev.ctrls)-1-int(depth)]
+}
+
+func (ev *exprValidator) local(index uint32) (ValType, bool) {
+	if uint64(index) < uint64(len(ev.params)) {
+		return ev.params[index], true
+	}
+	n := uint64(index) - uint64(len(ev.params))
+	for _, local := range ev.locals {
+		if n < uint64(local.Count) {
+			return local.Type, true
+		}
+		n -= uint64(local.Count)
+	}
+	return 0, false
+}
+
+func (ev *exprValidator) blockType(bt BlockType) ([]ValType, []ValType, bool) {
+	if bt == EmptyBlock {
+		return nil, nil, true
+	}
+	if vt, ok := bt.ValType(); ok {
+		if !vt.IsValid() {
+			ev.failf("invalid block type %v", vt)
+			return nil, nil, false
+		}
+		return nil, []ValType{vt}, true
+	}
+	index, _ := bt.TypeIndex()
+	if uint64(index) >= uint64(len(ev.v.module.Types)) {
+		ev.failf("unknown type %d", index)
+		return nil, nil, false
+	}
+	ft := ev.v.module.Types[index]
+	return ft.Params, ft.Results, true
+}
+
+func (ev *exprValidator) instr(instr *Instr) {
+	v := ev.v
+	info := instr.Opcode.Info()
+	if ev.isConst && !instr.Opcode.IsConstant() && instr.Opcode != OpEnd {
+		ev.failf("constant expression required")
+		return
+	}
+	if !ev.immediates(instr, info) {
+		return
+	}
+
+	switch instr.Opcode {
+	case OpTableInit:
+		if table, elem := v.tables[instr.Index2], v.module.Elems[instr.Index]; table.Elem != elem.Type {
+			ev.failf("type mismatch: segment of type %v for table of type %v", elem.Type, table.Elem)
+		}
+	case OpTableCopy:
+		if dst, src := v.tables[instr.Index], v.tables[instr.Index2]; dst.Elem != src.Elem {
+			ev.failf("type mismatch: copying %v to table of type %v", src.Elem, dst.Elem)
+		}
+	case OpRefFunc:
+		if !ev.isConst && !v.refs[instr.Index] {
+			ev.failf("undeclared function reference")
+		}
+	}
+	if !info.Dynamic {
+		ev.popVals(info.Params)
+		ev.pushVals(info.Results)
+		return
+	}
+
+	switch instr.Opcode {
+	case OpUnreachable:
+		ev.unreachable()
+
+	case OpBlock, OpLoop:
+		params, results, ok := ev.blockType(instr.BlockType)
+		if ok {
+			ev.popVals(params)
+			ev.pushCtrl(instr.Opcode, params, results)
+		}
+
+	case OpIf:
+		params, results, ok := ev.blockType(instr.BlockType)
+		if ok {
+			ev.pop(I32)
+			ev.popVals(params)
+			ev.pushCtrl(OpIf, params, results)
+		}
+
+	case OpElse:
+		if ev.ctrls[len(ev.ctrls)-1].opcode != OpIf {
+			ev.failf("else without if")
+			return
+		}
+		frame := ev.popCtrl()
+		ev.pushCtrl(OpElse, frame.params, frame.results)
+
+	case OpEnd:
+		frame := ev.popCtrl()
+		if frame.opcode == OpIf && !equalValTypes(frame.params, frame.results) {
+			ev.failf("type mismatch: if without else must not change the stack")
+		}
+		if len(ev.ctrls) != 0 {
+			ev.pushVals(frame.results)
+		}
+
+	case OpBr:
+		ev.popVals(ev.label(instr.Index).labelTypes())
+		ev.unreachable()
+
+	case OpBrIf:
+		ev.pop(I32)
+		types := ev.label(instr.Index).labelTypes()
+		ev.popVals(types)
+		ev.pushVals(types)
+
+	case OpBrTable:
+		ev.pop(I32)
+		labels := instr.Labels
+		if len(labels) == 0 {
+			labels = []uint32{0}
+		}
+		arity := len(ev.label(labels[len(labels)-1]).labelTypes())
+		for _, depth := range labels {
+			types := ev.label(depth).labelTypes()
+			if len(types) != arity {
+				ev.failf("type mismatch: br_table targets have different arities")
+				return
+			}
+			// Check each target against the same operands, which
+			// may be of unknown type in unreachable code.
+			height := len(ev.vals)
+			ev.popVals(types)
+			ev.vals = ev.vals[:height]
+		}
+		ev.unreachable()
+
+	case OpReturn:
+		ev.popVals(ev.returns)
+		ev.unreachable()
+
+	case OpCall:
+		ft := v.funcType(instr.Index)
+		if ft == nil {
+			ev.failf("unknown type of function %d", instr.Index)
+			return
+		}
+		ev.popVals(ft.Params)
+		ev.pushVals(ft.Results)
+
+	case OpCallIndirect:
+		if v.tables[instr.Index2].Elem != FuncRef {
+			ev.failf("type mismatch: call_indirect on table of type %v", v.tables[instr.Index2].Elem)
+			return
+		}
+		ft := v.module.Types[instr.Index]
+		ev.pop(I32)
+		ev.popVals(ft.Params)
+		ev.pushVals(ft.Results)
+
+	case OpDrop:
+		ev.pop(unknownType)
+
+	case OpSelect:
+		ev.pop(I32)
+		t1 := ev.pop(unknownType)
+		t2 := ev.pop(unknownType)
+		if t1.IsRef() || t2.IsRef() {
+			ev.failf("type mismatch: select without a type requires numeric or vector operands")
+			return
+		}
+		if t1 != t2 && t1 != unknownType && t2 != unknownType {
+			ev.failf("type mismatch: select operands %v and %v", t2, t1)
+			return
+		}
+		if t1 == unknownType {
+			t1 = t2
+		}
+		ev.push(t1)
+
+	case OpSelectT:
+		if len(instr.Types) != 1 {
+			ev.failf("invalid result arity %d", len(instr.Types))
+			return
+		}
+		vt := instr.Types[0]
+		ev.pop(I32)
+		ev.pop(vt)
+		ev.pop(vt)
+		ev.push(vt)
+
+	case OpLocalGet:
+		vt, _ := ev.local(instr.Index)
+		ev.push(vt)
+
+	case OpLocalSet:
+		vt, _ := ev.local(instr.Index)
+		ev.pop(vt)
+
+	case OpLocalTee:
+		vt, _ := ev.local(instr.Index)
+		ev.pop(vt)
+		ev.push(vt)
+
+	case OpGlobalGet:
+		ev.push(v.globals[instr.Index].Type)
+
+	case OpGlobalSet:
+		gt := v.globals[instr.Index]
+		if !gt.Mutable {
+			ev.failf("global is immutable")
+			return
+		}
+		ev.pop(gt.Type)
+
+	case OpTableGet:
+		ev.pop(I32)
+		ev.push(v.tables[instr.Index].Elem)
+
+	case OpTableSet:
+		ev.pop(v.tables[instr.Index].Elem)
+		ev.pop(I32)
+
+	case OpTableGrow:
+		ev.pop(I32)
+		ev.pop(v.tables[instr.Index].Elem)
+		ev.push(I32)
+
+	case OpTableFill:
+		ev.pop(I32)
+		ev.pop(v.tables[instr.Index].Elem)
+		ev.pop(I32)
+
+	case OpRefNull:
+		ev.push(instr.HeapType)
+
+	case OpRefIsNull:
+		if vt := ev.pop(unknownType); vt != unknownType && !vt.IsRef() {
+			ev.failf("type mismatch: expected a reference but got %v", vt)
+			return
+		}
+		ev.push(I32)
+
+	default:
+		ev.failf("no typing rule")
+	}
+}
+
+// immediates checks the indices, alignment and lanes of an instruction.
+func (ev *exprValidator) immediates(instr *Instr, info *OpcodeInfo) bool {
+	v := ev.v
+	module := v.module
+	numIndices := 0
+	for _, imm := range info.Immediates {
+		index := instr.Index
+		switch imm {
+		case LabelImmediate, FuncImmediate, TypeImmediate, TableImmediate, LocalImmediate, GlobalImmediate, ElemImmediate, DataImmediate:
+			if numIndices > 0 {
+				index = instr.Index2
+			}
+			numIndices++
+		}
+
+		switch imm {
+		case LabelImmediate:
+			if uint64(index) >= uint64(len(ev.ctrls)) {
+				ev.failf("unknown label %d", index)
+			}
+		case LabelVecImmediate:
+			for _, depth := range instr.Labels {
+				if uint64(depth) >= uint64(len(ev.ctrls)) {
+					ev.failf("unknown label %d", depth)
+					break
+				}
+			}
+		case FuncImmediate:
+			if uint64(index) >= uint64(len(v.funcs)) {
+				ev.failf("unknown function %d", index)
+			}
+		case TypeImmediate:
+			if uint64(index) >= uint64(len(module.Types)) {
+				ev.failf("unknown type %d", index)
+			}
+		case TableImmediate:
+			if uint64(index) >= uint64(len(v.tables)) {
+				ev.failf("unknown table %d", index)
+			}
+		case LocalImmediate:
+			if _, ok := ev.local(index); !ok {
+				ev.failf("unknown local %d", index)
+			}
+		case GlobalImmediate:
+			switch {
+			case uint64(index) >= uint64(len(v.globals)):
+				ev.failf("unknown global %d", index)
+			case ev.isConst && index >= ev.numGlobals:
+				ev.failf("unknown global %d", index)
+			case ev.isConst && v.globals[index].Mutable:
+				ev.failf("constant expression required")
+			}
+		case ElemImmediate:
+			if uint64(index) >= uint64(len(module.Elems)) {
+				ev.failf("unknown elem segment %d", index)
+			}
+		case DataImmediate:
+			switch {
+			case module.DataCount == nil:
+				ev.failf("data count section required")
+			case uint64(index) >= uint64(len(module.Datas)):
+				ev.failf("unknown data segment %d", index)
+			}
+		case MemArgImmediate:
+			switch {
+			case len(v.memories) == 0:
+				ev.failf("unknown memory 0")
+			case info.Features.Has(ThreadsFeature) && instr.MemArg.Align != info.NaturalAlign:
+				ev.failf("alignment must be exactly natural")
+			case instr.MemArg.Align > info.NaturalAlign:
+				ev.failf("alignment must not be larger than natural")
+			}
+		case ZeroByteImmediate:
+			if instr.Opcode != OpAtomicFence && len(v.memories) == 0 {
+				ev.failf("unknown memory 0")
+			}
+		case LaneImmediate:
+			if int(instr.Lane) >= numLanes(info) {
+				ev.failf("invalid lane index %d", instr.Lane)
+			}
+		case ShuffleImmediate:
+			for _, lane := range instr.V128 {
+				if lane >= 32 {
+					ev.failf("invalid lane index %d", lane)
+					break
+				}
+			}
+		case HeapTypeImmediate:
+			if !instr.HeapType.IsRef() {
+				ev.failf("invalid heap type %v", instr.HeapType)
+			}
+		case ValTypeVecImmediate:
+			for _, vt := range instr.Types {
+				if !vt.IsValid() {
+					ev.failf("invalid value type %v", vt)
+					break
+				}
+			}
+		}
+	}
+	return ev.detail == ""
+}
+
+// numLanes returns the number of lanes in the vector shape of an
+// instruction with a lane index.
+func numLanes(info *OpcodeInfo) int {
+	shape, _, _ := strings.Cut(info.Name, ".")
+	switch shape {
+	case "i8x16":
+		return 16
+	case "i16x8":
+		return 8
+	case "i32x4", "f32x4":
+		return 4
+	case "i64x2", "f64x2":
+		return 2
+	default:
+		// v128.load8_lane and the like.
+		return 16 >> info.NaturalAlign
+	}
+}
+
+func typeName(vt ValType) string {
+	if vt == unknownType {
+		return "a value"
+	}
+	return vt.String()
+}
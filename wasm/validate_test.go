@@ -0,0 +1,142 @@
+package wasm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// funcModule returns a module with one memory, one funcref table and a
+// single function of type [i32] -> [i32] with an i64 local.
+func funcModule(body ...byte) *Module {
+	return &Module{
+		Types:    []*FuncType{{Params: []ValType{I32}, Results: []ValType{I32}}, {}},
+		Funcs:    []*Func{{Type: 0, Locals: []Local{{Count: 1, Type: I64}}, Body: Expr(body)}},
+		Tables:   []*Table{{Type: TableType{Elem: FuncRef, Limits: Limits{Min: 1}}}},
+		Memories: []*Memory{{Type: MemoryType{Limits: Limits{Min: 1}}}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Module *Module
+	}
+
+	withGlobal := funcModule(0x23, 0x00, 0x0b)
+	withGlobal.Imports = []*Import{{Module: "env", Name: "g", Kind: GlobalExtern, Global: GlobalType{Type: I32}}}
+	withGlobal.Globals = []*Global{{Type: GlobalType{Type: I32, Mutable: true}, Init: Expr{0x23, 0x00, 0x41, 0x01, 0x6a, 0x0b}}}
+	withGlobal.Exports = []*Export{{Name: "f", Kind: FuncExtern, Index: 0}, {Name: "g", Kind: GlobalExtern, Index: 1}}
+
+	withSegments := funcModule(0xd2, 0x00, 0x1a, 0xfc, 0x09, 0x00, 0x20, 0x00, 0x0b)
+	withSegments.Elems = []*Elem{{Mode: ActiveSegment, Offset: Expr{0x41, 0x00, 0x0b}, Type: FuncRef, Funcs: []uint32{0}}}
+	withSegments.Datas = []*Data{{Mode: PassiveSegment, Init: []byte("hi")}}
+	count := uint32(1)
+	withSegments.DataCount = &count
+
+	testData := [...]testCase{
+		{"Identity", funcModule(0x20, 0x00, 0x0b)},
+		{"Arithmetic", funcModule(0x20, 0x00, 0x41, 0x01, 0x6a, 0x0b)},
+		{"Blocks", funcModule(
+			0x02, 0x7f, 0x20, 0x00, 0x04, 0x7f, 0x41, 0x01, 0x05, 0x41, 0x02, 0x0b, 0x0b,
+			0x03, 0x40, 0x20, 0x00, 0x0d, 0x00, 0x0b,
+			0x0b)},
+		{"BranchOut", funcModule(0x41, 0x07, 0x0c, 0x00, 0x0b)},
+		{"BrTable", funcModule(0x02, 0x40, 0x03, 0x40, 0x20, 0x00, 0x0e, 0x01, 0x00, 0x01, 0x0b, 0x0b, 0x20, 0x00, 0x0b)},
+		{"Unreachable", funcModule(0x00, 0x6a, 0x0b)},
+		{"Return", funcModule(0x20, 0x00, 0x0f, 0x0b)},
+		{"Memory", funcModule(0x20, 0x00, 0x20, 0x00, 0x28, 0x02, 0x00, 0x36, 0x00, 0x04, 0x3f, 0x00, 0x0b)},
+		{"Select", funcModule(0x20, 0x00, 0x41, 0x00, 0x20, 0x00, 0x1b, 0x0b)},
+		{"Locals", funcModule(0x42, 0x01, 0x21, 0x01, 0x20, 0x00, 0x0b)},
+		{"CallIndirect", funcModule(0x20, 0x00, 0x41, 0x00, 0x11, 0x00, 0x00, 0x0b)},
+		{"Globals", withGlobal},
+		{"Segments", withSegments},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if err := row.Module.Validate(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate_Errors(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Module *Module
+		Expect string
+	}
+
+	dupExports := funcModule(0x20, 0x00, 0x0b)
+	dupExports.Exports = []*Export{{Name: "f", Kind: FuncExtern}, {Name: "f", Kind: MemoryExtern}}
+
+	badStart := funcModule(0x20, 0x00, 0x0b)
+	badStart.Start = &Start{Func: 0}
+
+	badLimits := funcModule(0x20, 0x00, 0x0b)
+	badLimits.Memories[0].Type.Limits = Limits{Min: 2, Max: 1, HasMax: true}
+
+	mutableGlobal := funcModule(0x20, 0x00, 0x0b)
+	mutableGlobal.Imports = []*Import{{Module: "env", Name: "g", Kind: GlobalExtern, Global: GlobalType{Type: I32, Mutable: true}}}
+	mutableGlobal.Globals = []*Global{{Type: GlobalType{Type: I32}, Init: Expr{0x23, 0x00, 0x0b}}}
+
+	definedGlobal := funcModule(0x20, 0x00, 0x0b)
+	definedGlobal.Globals = []*Global{
+		{Type: GlobalType{Type: I32}, Init: Expr{0x41, 0x00, 0x0b}},
+		{Type: GlobalType{Type: I32}, Init: Expr{0x23, 0x00, 0x0b}},
+	}
+
+	noDataCount := funcModule(0xfc, 0x09, 0x00, 0x20, 0x00, 0x0b)
+	noDataCount.Datas = []*Data{{Mode: PassiveSegment}}
+
+	undeclared := funcModule(0xd2, 0x00, 0x1a, 0x20, 0x00, 0x0b)
+
+	testData := [...]testCase{
+		{"Empty", funcModule(0x0b), "wasm: func 0: offset 0x0: end: type mismatch: expected i32 but nothing on stack"},
+		{"WrongType", funcModule(0x42, 0x00, 0x0b), "wasm: func 0: offset 0x2: end: type mismatch: expected i32 but got i64"},
+		{"ExtraValue", funcModule(0x20, 0x00, 0x20, 0x00, 0x0b), "wasm: func 0: offset 0x4: end: type mismatch: 1 extra values at end of block"},
+		{"UnknownLocal", funcModule(0x20, 0x05, 0x0b), "wasm: func 0: offset 0x0: local.get: unknown local 5"},
+		{"UnknownLabel", funcModule(0x0c, 0x01, 0x0b), "wasm: func 0: offset 0x0: br: unknown label 1"},
+		{"BadAlign", funcModule(0x20, 0x00, 0x28, 0x03, 0x00, 0x0b), "wasm: func 0: offset 0x2: i32.load: alignment must not be larger than natural"},
+		{"IfWithoutElse", funcModule(0x20, 0x00, 0x04, 0x7f, 0x41, 0x01, 0x0b, 0x0b), "wasm: func 0: offset 0x6: end: type mismatch: if without else must not change the stack"},
+		{"MissingEnd", funcModule(0x20, 0x00), "wasm: func 0: missing end"},
+		{"TrailingInstr", funcModule(0x20, 0x00, 0x0b, 0x01), "wasm: func 0: offset 0x3: nop: instructions after the final end"},
+		{"SelectRefs", funcModule(0xd0, 0x70, 0xd0, 0x70, 0x20, 0x00, 0x1b, 0x0b), "wasm: func 0: offset 0x6: select: type mismatch: select without a type requires numeric or vector operands"},
+		{"Undeclared", undeclared, "wasm: func 0: offset 0x0: ref.func: undeclared function reference"},
+		{"NoDataCount", noDataCount, "wasm: func 0: offset 0x0: data.drop: data count section required"},
+		{"DuplicateExport", dupExports, `wasm: export "f": duplicate export name`},
+		{"Start", badStart, "wasm: start: start function must have type [] -> [], not (func (param i32) (result i32))"},
+		{"Limits", badLimits, "wasm: memory 0: size minimum must not be greater than maximum"},
+		{"DefinedGlobal", definedGlobal, "wasm: global 1: offset 0x0: global.get: unknown global 0"},
+		{"MutableGlobal", mutableGlobal, "wasm: global 1: offset 0x0: global.get: constant expression required"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			err := row.Module.Validate()
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expect ValidationErrors, got %#v", err)
+			}
+			if actual := errs[0].Error(); actual != row.Expect {
+				t.Errorf("wrong error:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestValidate_InstrOrigins(t *testing.T) {
+	node := &wat.Node{Type: wat.KeywordNode, Value: "i64.const"}
+	node.Span.Begin = wat.Position{Line: 2, Column: 4, ByteOffset: 20}
+	module := funcModule(0x42, 0x00, 0x0b)
+	module.Funcs[0].InstrOrigins = []Origin{{Offset: 0, Node: node}, {Offset: 2, Node: node}}
+
+	err := module.Validate()
+	expect := "wasm: L:3 C:5 @ 20: func 0: offset 0x2: end: type mismatch: expected i32 but got i64"
+	if err == nil || err.Error() != expect {
+		t.Errorf("wrong error:\n\texpect: %s\n\tactual: %v", expect, err)
+	}
+}
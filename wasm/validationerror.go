@@ -0,0 +1,67 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+// ValidationError describes a module that breaks one of the validation
+// rules.  Where names the offending item, as in "func 3" or "export
+// \"main\"", and Origin locates it.  For a problem with an instruction,
+// Origin is that of the instruction, whose Offset is relative to the start
+// of the function body or constant expression.
+type ValidationError struct {
+	Where  string
+	Origin Origin
+	Detail string
+}
+
+func (err *ValidationError) Error() string {
+	var scratch [128]byte
+	out := append(scratch[:0], "wasm: "...)
+	if err.Origin.Node != nil {
+		out = err.Origin.Span().Begin.AppendTo(out, false)
+		out = append(out, ": "...)
+	}
+	return string(err.appendMessage(out))
+}
+
+// Message is like Error, but without the prefix and position.
+func (err *ValidationError) Message() string {
+	var scratch [128]byte
+	return string(err.appendMessage(scratch[:0]))
+}
+
+func (err *ValidationError) appendMessage(out []byte) []byte {
+	if err.Where != "" {
+		out = append(out, err.Where...)
+		out = append(out, ": "...)
+	}
+	return append(out, err.Detail...)
+}
+
+type ValidationErrors []*ValidationError
+
+func (list ValidationErrors) Error() string {
+	switch len(list) {
+	case 0:
+		return "no errors"
+	case 1:
+		return list[0].Error()
+	case 2:
+		return fmt.Sprintf("%s (and 1 more error)", list[0].Error())
+	default:
+		return fmt.Sprintf("%s (and %d more errors)", list[0].Error(), len(list)-1)
+	}
+}
+
+func (list ValidationErrors) Err() error {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+var (
+	_ error = (*ValidationError)(nil)
+	_ error = ValidationErrors(nil)
+)
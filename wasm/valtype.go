@@ -0,0 +1,86 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+type ValType byte
+
+const (
+	I32       ValType = 0x7f
+	I64       ValType = 0x7e
+	F32       ValType = 0x7d
+	F64       ValType = 0x7c
+	V128      ValType = 0x7b
+	FuncRef   ValType = 0x70
+	ExternRef ValType = 0x6f
+)
+
+type valTypeInfo struct {
+	enum   ValType
+	name   string
+	goName string
+}
+
+var valTypeInfos = [...]valTypeInfo{
+	{I32, "i32", "wasm.I32"},
+	{I64, "i64", "wasm.I64"},
+	{F32, "f32", "wasm.F32"},
+	{F64, "f64", "wasm.F64"},
+	{V128, "v128", "wasm.V128"},
+	{FuncRef, "funcref", "wasm.FuncRef"},
+	{ExternRef, "externref", "wasm.ExternRef"},
+}
+
+func ValTypeByName(name string) (ValType, bool) {
+	for _, info := range valTypeInfos {
+		if info.name == name {
+			return info.enum, true
+		}
+	}
+	return 0, false
+}
+
+func (enum ValType) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum ValType) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum ValType) AppendTo(out []byte, verbose bool) []byte {
+	for _, info := range valTypeInfos {
+		if info.enum == enum {
+			if verbose {
+				return append(out, info.goName...)
+			}
+			return append(out, info.name...)
+		}
+	}
+	return fmt.Appendf(out, "wasm.ValType(%#02x)", byte(enum))
+}
+
+func (enum ValType) IsValid() bool {
+	for _, info := range valTypeInfos {
+		if info.enum == enum {
+			return true
+		}
+	}
+	return false
+}
+
+func (enum ValType) IsNum() bool {
+	return enum == I32 || enum == I64 || enum == F32 || enum == F64
+}
+
+func (enum ValType) IsRef() bool {
+	return enum == FuncRef || enum == ExternRef
+}
+
+var (
+	_ fmt.GoStringer = ValType(0)
+	_ fmt.Stringer   = ValType(0)
+)
@@ -0,0 +1,52 @@
+package wast
+
+import (
+	"fmt"
+)
+
+// ActionType identifies the kind of an Action.
+type ActionType byte
+
+const (
+	InvokeAction ActionType = iota
+	GetAction
+)
+
+var actionTypeGoNames = [...]string{
+	"wast.InvokeAction",
+	"wast.GetAction",
+}
+
+var actionTypeNames = [...]string{
+	"invoke",
+	"get",
+}
+
+func (enum ActionType) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum ActionType) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum ActionType) AppendTo(out []byte, verbose bool) []byte {
+	names := actionTypeNames
+	if verbose {
+		names = actionTypeGoNames
+	}
+	var str string
+	if enum < ActionType(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wast.ActionType(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = ActionType(0)
+	_ fmt.Stringer   = ActionType(0)
+)
@@ -0,0 +1,76 @@
+package wast
+
+import (
+	"fmt"
+)
+
+// CommandType identifies the kind of a script Command.
+type CommandType byte
+
+const (
+	ModuleCommand CommandType = iota
+	RegisterCommand
+	ActionCommand
+	AssertReturnCommand
+	AssertTrapCommand
+	AssertExhaustionCommand
+	AssertInvalidCommand
+	AssertMalformedCommand
+	AssertUnlinkableCommand
+	AssertUninstantiableCommand
+)
+
+var commandTypeGoNames = [...]string{
+	"wast.ModuleCommand",
+	"wast.RegisterCommand",
+	"wast.ActionCommand",
+	"wast.AssertReturnCommand",
+	"wast.AssertTrapCommand",
+	"wast.AssertExhaustionCommand",
+	"wast.AssertInvalidCommand",
+	"wast.AssertMalformedCommand",
+	"wast.AssertUnlinkableCommand",
+	"wast.AssertUninstantiableCommand",
+}
+
+var commandTypeNames = [...]string{
+	"module",
+	"register",
+	"action",
+	"assert_return",
+	"assert_trap",
+	"assert_exhaustion",
+	"assert_invalid",
+	"assert_malformed",
+	"assert_unlinkable",
+	"assert_uninstantiable",
+}
+
+func (enum CommandType) GoString() string {
+	var scratch [32]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum CommandType) String() string {
+	var scratch [32]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum CommandType) AppendTo(out []byte, verbose bool) []byte {
+	names := commandTypeNames
+	if verbose {
+		names = commandTypeGoNames
+	}
+	var str string
+	if enum < CommandType(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wast.CommandType(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = CommandType(0)
+	_ fmt.Stringer   = CommandType(0)
+)
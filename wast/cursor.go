@@ -0,0 +1,186 @@
+package wast
+
+import (
+	"strconv"
+
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// cursor walks the significant children of an ExprNode, skipping over
+// spaces, comments and annotations.
+type cursor struct {
+	p    *parser
+	node *wat.Node
+	list []*wat.Node
+}
+
+// open returns a cursor positioned just after the keyword that begins
+// the ExprNode, or nil if the node is not such an ExprNode.
+func (p *parser) open(node *wat.Node) *cursor {
+	list := items(node)
+	if head(node) == "" {
+		return nil
+	}
+	return &cursor{p: p, node: node, list: list[1:]}
+}
+
+func (c *cursor) done() bool {
+	return len(c.list) == 0
+}
+
+func (c *cursor) peek() *wat.Node {
+	if len(c.list) == 0 {
+		return nil
+	}
+	return c.list[0]
+}
+
+func (c *cursor) next() *wat.Node {
+	if len(c.list) == 0 {
+		return nil
+	}
+	node := c.list[0]
+	c.list = c.list[1:]
+	return node
+}
+
+func (c *cursor) peekHead() string {
+	return head(c.peek())
+}
+
+func (c *cursor) peekKeyword() string {
+	node := c.peek()
+	if node == nil || node.Type != wat.KeywordNode {
+		return ""
+	}
+	return node.Value.(string)
+}
+
+func (c *cursor) optID() (string, *wat.Node) {
+	node := c.peek()
+	if node == nil || node.Type != wat.IdentifierNode {
+		return "", nil
+	}
+	c.next()
+	return node.Value.(string)[1:], node
+}
+
+// expectExpr returns the next node if it is an expression that begins
+// with a keyword.
+func (c *cursor) expectExpr(expect string) *wat.Node {
+	if c.peekHead() != "" {
+		return c.next()
+	}
+	c.missing(expect)
+	return nil
+}
+
+func (c *cursor) expectString() (string, bool) {
+	node := c.peek()
+	if node == nil || node.Type != wat.StringNode {
+		c.missing("string")
+		return "", false
+	}
+	c.next()
+	return node.Value.(string), true
+}
+
+func (c *cursor) expectNumber() *wat.Node {
+	node := c.peek()
+	if node == nil || node.Type != wat.NumberNode {
+		c.missing("number")
+		return nil
+	}
+	return c.next()
+}
+
+// expectShape reads the shape of a v128 constant, and returns it with the
+// width of its lanes in bytes, or 0 if there is no valid shape.
+func (c *cursor) expectShape() (string, int) {
+	shape := c.peekKeyword()
+	numBytes := laneBytes[shape]
+	if numBytes == 0 {
+		c.missing("vector shape")
+		return "", 0
+	}
+	c.next()
+	return shape, numBytes
+}
+
+func (c *cursor) expectEnd() {
+	if node := c.peek(); node != nil {
+		c.p.unexpected(node)
+		c.list = nil
+	}
+}
+
+// missing reports that the cursor's next node, or the end of the
+// enclosing expression, is not what was expected.
+func (c *cursor) missing(expect string) {
+	if node := c.peek(); node != nil {
+		c.p.unexpected(node, expect)
+		c.list = nil
+		return
+	}
+	c.p.errorf(c.node, "expect %s before ')'", expect)
+}
+
+func (p *parser) unexpected(node *wat.Node, expect ...string) {
+	if node.Type == wat.ErrorNode {
+		err, _ := node.Value.(error)
+		p.wrapf(node, err, "syntax error")
+		return
+	}
+	if len(expect) > 0 {
+		p.errorf(node, "unexpected %s: expect %s", describe(node), expect[0])
+		return
+	}
+	p.errorf(node, "unexpected %s", describe(node))
+}
+
+func items(node *wat.Node) []*wat.Node {
+	if node == nil || node.Type != wat.ExprNode {
+		return nil
+	}
+	all := node.Value.([]*wat.Node)
+	list := make([]*wat.Node, 0, len(all))
+	for _, child := range all {
+		switch child.Type {
+		case wat.SpaceNode:
+		case wat.LineCommentNode:
+		case wat.BlockCommentNode:
+		case wat.AnnotationNode:
+		default:
+			list = append(list, child)
+		}
+	}
+	return list
+}
+
+func head(node *wat.Node) string {
+	list := items(node)
+	if len(list) == 0 || list[0].Type != wat.KeywordNode {
+		return ""
+	}
+	return list[0].Value.(string)
+}
+
+func describe(node *wat.Node) string {
+	switch node.Type {
+	case wat.ExprNode:
+		if kw := head(node); kw != "" {
+			return "(" + kw + " ...)"
+		}
+		return "expression"
+	case wat.KeywordNode:
+		return "keyword " + strconv.Quote(node.Value.(string))
+	case wat.IdentifierNode:
+		return "identifier " + node.Value.(string)
+	case wat.StringNode:
+		return "string " + strconv.Quote(node.Value.(string))
+	case wat.NumberNode:
+		return "number " + node.Value.(wat.Num).String()
+	default:
+		return node.Type.String()
+	}
+}
@@ -0,0 +1,78 @@
+package wast
+
+import (
+	"encoding/binary"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+)
+
+// Matches reports whether a value returned by an action matches the
+// result.
+func (r *Result) Matches(v Value) bool {
+	if r.Either != nil {
+		for i := range r.Either {
+			if r.Either[i].Matches(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if v.Type != r.Value.Type {
+		return false
+	}
+	switch {
+	case r.AnyRef:
+		return !v.Null
+	case r.Value.Null || v.Null:
+		return r.Value.Null == v.Null
+	case r.NaN != NoNaN:
+		return matchNaN(r.NaN, v.Bits, v.Type == wasm.F32)
+	case v.Type == wasm.V128:
+		return r.matchV128(v.V128)
+	default:
+		return r.Value.Bits == v.Bits
+	}
+}
+
+func (r *Result) matchV128(v128 [16]byte) bool {
+	if r.Lanes == nil {
+		return r.Value.V128 == v128
+	}
+	numBytes := 16 / len(r.Lanes)
+	for i, pattern := range r.Lanes {
+		expect := r.Value.V128[i*numBytes : (i+1)*numBytes]
+		actual := v128[i*numBytes : (i+1)*numBytes]
+		var ok bool
+		switch {
+		case pattern == NoNaN:
+			ok = string(expect) == string(actual)
+		case numBytes == 4:
+			ok = matchNaN(pattern, uint64(binary.LittleEndian.Uint32(actual)), true)
+		default:
+			ok = matchNaN(pattern, binary.LittleEndian.Uint64(actual), false)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNaN reports whether the bits of an f32 or f64 are a NaN of the
+// given kind.  A canonical NaN has only the quiet bit set in its payload;
+// an arithmetic NaN has the quiet bit set and any other payload.  Either
+// may have any sign.
+func matchNaN(pattern NaNPattern, bits uint64, is32 bool) bool {
+	signBit := uint64(1) << 63
+	expMask := uint64(0x7ff0000000000000)
+	quietBit := uint64(0x0008000000000000)
+	if is32 {
+		signBit, expMask, quietBit = 1<<31, 0x7f800000, 0x00400000
+	}
+	bits &^= signBit
+	if pattern == CanonicalNaN {
+		return bits == expMask|quietBit
+	}
+	return bits&expMask == expMask && bits&quietBit != 0
+}
@@ -0,0 +1,30 @@
+package wast
+
+import (
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wasm/text"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// Decode returns the module that the script defines: lowered from its
+// fields, decoded from its bytes, or parsed and lowered from its quoted
+// source.  Positions in the errors for a QuoteModule are relative to the
+// start of the quoted source.
+func (m *Module) Decode() (*wasm.Module, error) {
+	switch m.Type {
+	case BinaryModule:
+		return wasm.Decode(m.Data)
+
+	case QuoteModule:
+		var parser wat.Parser
+		parser.DisableCaching(true)
+		root, err := parser.Parse(wat.NewLexer(m.Data))
+		if err != nil {
+			return nil, err
+		}
+		return text.Lower(root)
+
+	default:
+		return text.Lower(m.Node)
+	}
+}
@@ -0,0 +1,55 @@
+package wast
+
+import (
+	"fmt"
+)
+
+// ModuleType identifies how a script Module is written.
+type ModuleType byte
+
+const (
+	TextModule ModuleType = iota
+	BinaryModule
+	QuoteModule
+)
+
+var moduleTypeGoNames = [...]string{
+	"wast.TextModule",
+	"wast.BinaryModule",
+	"wast.QuoteModule",
+}
+
+var moduleTypeNames = [...]string{
+	"text",
+	"binary",
+	"quote",
+}
+
+func (enum ModuleType) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum ModuleType) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum ModuleType) AppendTo(out []byte, verbose bool) []byte {
+	names := moduleTypeNames
+	if verbose {
+		names = moduleTypeGoNames
+	}
+	var str string
+	if enum < ModuleType(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wast.ModuleType(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = ModuleType(0)
+	_ fmt.Stringer   = ModuleType(0)
+)
@@ -0,0 +1,55 @@
+package wast
+
+import (
+	"fmt"
+)
+
+// NaNPattern is the kind of NaN that a Result expects, if any.
+type NaNPattern byte
+
+const (
+	NoNaN NaNPattern = iota
+	CanonicalNaN
+	ArithmeticNaN
+)
+
+var nanPatternGoNames = [...]string{
+	"wast.NoNaN",
+	"wast.CanonicalNaN",
+	"wast.ArithmeticNaN",
+}
+
+var nanPatternNames = [...]string{
+	"none",
+	"nan:canonical",
+	"nan:arithmetic",
+}
+
+func (enum NaNPattern) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum NaNPattern) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum NaNPattern) AppendTo(out []byte, verbose bool) []byte {
+	names := nanPatternNames
+	if verbose {
+		names = nanPatternGoNames
+	}
+	var str string
+	if enum < NaNPattern(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wast.NaNPattern(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = NaNPattern(0)
+	_ fmt.Stringer   = NaNPattern(0)
+)
@@ -0,0 +1,364 @@
+package wast
+
+import (
+	"fmt"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// Parse parses the source of a script.
+func Parse(src []byte) (*Script, error) {
+	var parser wat.Parser
+	parser.DisableCaching(true)
+	root, err := parser.Parse(wat.NewLexer(src))
+	if err != nil {
+		return nil, err
+	}
+	return ParseNode(root)
+}
+
+// ParseNode interprets a tree produced by wat.Parser as a script.  A tree
+// that holds module fields rather than commands, as a .wat file does, is a
+// script with a single module.
+//
+// Modules are not lowered or decoded until Module.Decode is called, so
+// that a script can hold modules that are meant to be invalid.  If there
+// are problems with the commands themselves, the error is a wat.SyntaxErrors.
+func ParseNode(root *wat.Node) (*Script, error) {
+	p := &parser{}
+	script := &Script{}
+	list := items(root)
+	if len(list) != 0 && !isCommand(head(list[0])) {
+		script.Commands = []*Command{{
+			Type:   ModuleCommand,
+			Module: &Module{Type: TextModule, Node: root},
+			Span:   root.Span,
+		}}
+		return script, nil
+	}
+	for _, node := range list {
+		if cmd := p.command(node); cmd != nil {
+			script.Commands = append(script.Commands, cmd)
+		}
+	}
+	if err := p.errs.Err(); err != nil {
+		return nil, err
+	}
+	return script, nil
+}
+
+var commandsByKeyword = map[string]CommandType{
+	"module":                ModuleCommand,
+	"register":              RegisterCommand,
+	"invoke":                ActionCommand,
+	"get":                   ActionCommand,
+	"assert_return":         AssertReturnCommand,
+	"assert_trap":           AssertTrapCommand,
+	"assert_exhaustion":     AssertExhaustionCommand,
+	"assert_invalid":        AssertInvalidCommand,
+	"assert_malformed":      AssertMalformedCommand,
+	"assert_unlinkable":     AssertUnlinkableCommand,
+	"assert_uninstantiable": AssertUninstantiableCommand,
+}
+
+func isCommand(kw string) bool {
+	_, found := commandsByKeyword[kw]
+	return found
+}
+
+type parser struct {
+	errs wat.SyntaxErrors
+}
+
+func (p *parser) errorf(node *wat.Node, format string, args ...any) {
+	p.errs = append(p.errs, &wat.SyntaxError{Code: wat.SemanticError, Span: node.Span, Detail: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) wrapf(node *wat.Node, err error, format string, args ...any) {
+	p.errs = append(p.errs, &wat.SyntaxError{Code: wat.SemanticError, Span: node.Span, Detail: fmt.Sprintf(format, args...), Err: err})
+}
+
+func (p *parser) command(node *wat.Node) *Command {
+	kw := head(node)
+	cmdType, found := commandsByKeyword[kw]
+	if !found {
+		p.errorf(node, "unexpected %s: expect command", describe(node))
+		return nil
+	}
+
+	cmd := &Command{Type: cmdType, Span: node.Span}
+	c := p.open(node)
+	switch cmdType {
+	case ModuleCommand:
+		cmd.Module = p.module(node)
+		return cmd
+
+	case RegisterCommand:
+		cmd.Name, _ = c.expectString()
+		cmd.ID, _ = c.optID()
+
+	case ActionCommand:
+		cmd.Action = p.action(node)
+		return cmd
+
+	case AssertReturnCommand:
+		cmd.Action = p.action(c.expectExpr("action"))
+		for !c.done() {
+			cmd.Results = append(cmd.Results, p.result(c.next()))
+		}
+
+	case AssertTrapCommand:
+		if c.peekHead() == "module" {
+			cmd.Module = p.module(c.next())
+		} else {
+			cmd.Action = p.action(c.expectExpr("action"))
+		}
+		cmd.Message, _ = c.expectString()
+
+	case AssertExhaustionCommand:
+		cmd.Action = p.action(c.expectExpr("action"))
+		cmd.Message, _ = c.expectString()
+
+	default:
+		if c.peekHead() == "module" {
+			cmd.Module = p.module(c.next())
+		} else {
+			c.missing("(module ...)")
+		}
+		cmd.Message, _ = c.expectString()
+	}
+	c.expectEnd()
+	return cmd
+}
+
+func (p *parser) module(node *wat.Node) *Module {
+	c := p.open(node)
+	m := &Module{Type: TextModule, Node: node}
+	m.ID, _ = c.optID()
+	switch c.peekKeyword() {
+	case "binary":
+		m.Type = BinaryModule
+	case "quote":
+		m.Type = QuoteModule
+	default:
+		// The fields are left for text.Lower.
+		return m
+	}
+	c.next()
+	m.Data = []byte{}
+	for !c.done() {
+		str, ok := c.expectString()
+		if !ok {
+			break
+		}
+		m.Data = append(m.Data, str...)
+	}
+	return m
+}
+
+func (p *parser) action(node *wat.Node) *Action {
+	if node == nil {
+		return nil
+	}
+	action := &Action{Span: node.Span}
+	switch kw := head(node); kw {
+	case "invoke":
+		action.Type = InvokeAction
+	case "get":
+		action.Type = GetAction
+	default:
+		p.errorf(node, "unexpected %s: expect action", describe(node))
+		return nil
+	}
+
+	c := p.open(node)
+	action.Module, _ = c.optID()
+	action.Name, _ = c.expectString()
+	if action.Type == InvokeAction {
+		for !c.done() {
+			value, _ := p.value(c.next())
+			action.Args = append(action.Args, value)
+		}
+	}
+	c.expectEnd()
+	return action
+}
+
+// value parses a constant: a number, a vector, or a null or host
+// reference.
+func (p *parser) value(node *wat.Node) (Value, bool) {
+	var value Value
+	kw := head(node)
+	c := p.open(node)
+	if c == nil {
+		p.errorf(node, "unexpected %s: expect constant", describe(node))
+		return value, false
+	}
+
+	switch kw {
+	case "i32.const", "i64.const", "f32.const", "f64.const":
+		value.Type, _ = wasm.ValTypeByName(kw[:3])
+		if numNode := c.expectNumber(); numNode != nil {
+			value.Bits = p.scalar(numNode, kw[:3])
+		}
+
+	case "v128.const":
+		value.Type = wasm.V128
+		shape, numBytes := c.expectShape()
+		for i := 0; numBytes != 0 && i < 16; i += numBytes {
+			numNode := c.expectNumber()
+			if numNode == nil {
+				break
+			}
+			putLane(&value.V128, i, numBytes, p.scalar(numNode, shape))
+		}
+
+	case "ref.null":
+		value.Null = true
+		switch c.peekKeyword() {
+		case "func":
+			value.Type = wasm.FuncRef
+		case "extern":
+			value.Type = wasm.ExternRef
+		default:
+			c.missing("heap type")
+			return value, false
+		}
+		c.next()
+
+	case "ref.extern":
+		value.Type = wasm.ExternRef
+		if numNode := c.expectNumber(); numNode != nil {
+			value.Bits = p.scalar(numNode, "i32")
+		}
+
+	default:
+		p.errorf(node, "unexpected %s: expect constant", describe(node))
+		return value, false
+	}
+	c.expectEnd()
+	return value, true
+}
+
+// result parses an expected result, which is a constant or a pattern.
+func (p *parser) result(node *wat.Node) Result {
+	var result Result
+	kw := head(node)
+	c := p.open(node)
+	switch {
+	case c == nil:
+		break
+
+	case kw == "either":
+		result.Either = []Result{}
+		for !c.done() {
+			result.Either = append(result.Either, p.result(c.next()))
+		}
+		return result
+
+	case (kw == "f32.const" || kw == "f64.const") && isNaNPattern(c.peekKeyword()):
+		result.Value.Type, _ = wasm.ValTypeByName(kw[:3])
+		result.NaN = nanPattern(c.next())
+		c.expectEnd()
+		return result
+
+	case kw == "v128.const":
+		return p.vectorResult(c)
+
+	case (kw == "ref.extern" || kw == "ref.func") && c.done():
+		result.Value.Type = wasm.ExternRef
+		if kw == "ref.func" {
+			result.Value.Type = wasm.FuncRef
+		}
+		result.AnyRef = true
+		return result
+	}
+
+	result.Value, _ = p.value(node)
+	return result
+}
+
+// vectorResult parses a v128 result, whose float lanes may be NaN
+// patterns.
+func (p *parser) vectorResult(c *cursor) Result {
+	result := Result{Value: Value{Type: wasm.V128}}
+	shape, numBytes := c.expectShape()
+	if numBytes == 0 {
+		return result
+	}
+	result.Shape = shape
+	var lanes []NaNPattern
+	for i := 0; i < 16; i += numBytes {
+		if isNaNPattern(c.peekKeyword()) && (shape == "f32x4" || shape == "f64x2") {
+			if lanes == nil {
+				lanes = make([]NaNPattern, 16/numBytes)
+			}
+			lanes[i/numBytes] = nanPattern(c.next())
+			continue
+		}
+		numNode := c.expectNumber()
+		if numNode == nil {
+			break
+		}
+		putLane(&result.Value.V128, i, numBytes, p.scalar(numNode, shape))
+	}
+	result.Lanes = lanes
+	c.expectEnd()
+	return result
+}
+
+// scalar converts the number of a constant or a vector lane of the given
+// type or shape to its bits.
+func (p *parser) scalar(node *wat.Node, typ string) uint64 {
+	num := node.Value.(wat.Num)
+	var bits uint64
+	var err error
+	switch typ {
+	case "i8x16":
+		bits, err = num.ToInteger(8, false)
+	case "i16x8":
+		bits, err = num.ToInteger(16, false)
+	case "i32", "i32x4":
+		bits, err = num.ToInteger(32, false)
+	case "i64", "i64x2":
+		bits, err = num.ToInteger(64, false)
+	case "f32", "f32x4":
+		var u32 uint32
+		u32, err = num.Float32Bits()
+		bits = uint64(u32)
+	default:
+		bits, err = num.Float64Bits()
+	}
+	if err != nil {
+		p.wrapf(node, err, "invalid %s", typ)
+	}
+	return bits
+}
+
+// laneBytes gives the width of a lane of each vector shape.
+var laneBytes = map[string]int{
+	"i8x16": 1,
+	"i16x8": 2,
+	"i32x4": 4,
+	"f32x4": 4,
+	"i64x2": 8,
+	"f64x2": 8,
+}
+
+func putLane(v128 *[16]byte, offset int, numBytes int, bits uint64) {
+	for j := 0; j < numBytes; j++ {
+		v128[offset+j] = byte(bits >> (8 * j))
+	}
+}
+
+func isNaNPattern(kw string) bool {
+	return kw == "nan:canonical" || kw == "nan:arithmetic"
+}
+
+func nanPattern(node *wat.Node) NaNPattern {
+	if node.Value.(string) == "nan:canonical" {
+		return CanonicalNaN
+	}
+	return ArithmeticNaN
+}
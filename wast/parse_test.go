@@ -0,0 +1,201 @@
+package wast
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+const testScript = `
+(module $M (func (export "f") (param i32) (result i32) (local.get 0)))
+(module binary "\00asm" "\01\00\00\00")
+(module quote "(func)")
+(register "m" $M)
+(invoke $M "f" (i32.const -1))
+(get "g")
+(assert_return (invoke "f" (i32.const 1)) (i32.const 1))
+(assert_return (invoke "f" (f32.const 0)) (f32.const nan:canonical) (f64.const nan:arithmetic))
+(assert_return (invoke "f") (v128.const f32x4 1 nan:canonical 0 -1) (ref.extern) (ref.null func))
+(assert_return (invoke "f") (either (i64.const 1) (ref.extern 2)))
+(assert_trap (invoke "f") "unreachable")
+(assert_trap (module (start 0)) "out of bounds")
+(assert_exhaustion (invoke "f") "call stack exhausted")
+(assert_invalid (module (func (result i32))) "type mismatch")
+(assert_malformed (module quote "(func") "unexpected end")
+(assert_unlinkable (module (import "a" "b" (func))) "unknown import")
+`
+
+func TestParse(t *testing.T) {
+	script, err := Parse([]byte(testScript))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var types []CommandType
+	for _, cmd := range script.Commands {
+		types = append(types, cmd.Type)
+	}
+	expectTypes := []CommandType{
+		ModuleCommand, ModuleCommand, ModuleCommand, RegisterCommand,
+		ActionCommand, ActionCommand,
+		AssertReturnCommand, AssertReturnCommand, AssertReturnCommand, AssertReturnCommand,
+		AssertTrapCommand, AssertTrapCommand, AssertExhaustionCommand,
+		AssertInvalidCommand, AssertMalformedCommand, AssertUnlinkableCommand,
+	}
+	if !reflect.DeepEqual(types, expectTypes) {
+		t.Fatalf("wrong commands:\n\texpect: %v\n\tactual: %v", expectTypes, types)
+	}
+
+	cmds := script.Commands
+	if m := cmds[0].Module; m.Type != TextModule || m.ID != "M" {
+		t.Errorf("wrong text module: %+v", m)
+	}
+	if m := cmds[1].Module; m.Type != BinaryModule || string(m.Data) != "\x00asm\x01\x00\x00\x00" {
+		t.Errorf("wrong binary module: %+v", m)
+	}
+	if m := cmds[2].Module; m.Type != QuoteModule || string(m.Data) != "(func)" {
+		t.Errorf("wrong quote module: %+v", m)
+	}
+	if cmd := cmds[3]; cmd.Name != "m" || cmd.ID != "M" {
+		t.Errorf("wrong register: %+v", cmd)
+	}
+
+	expectAction := &Action{
+		Type:   InvokeAction,
+		Module: "M",
+		Name:   "f",
+		Args:   []Value{{Type: wasm.I32, Bits: 0xffffffff}},
+		Span:   cmds[4].Action.Span,
+	}
+	if !reflect.DeepEqual(cmds[4].Action, expectAction) {
+		t.Errorf("wrong invoke:\n\texpect: %+v\n\tactual: %+v", expectAction, cmds[4].Action)
+	}
+	if action := cmds[5].Action; action.Type != GetAction || action.Name != "g" {
+		t.Errorf("wrong get: %+v", action)
+	}
+
+	results := cmds[7].Results
+	if len(results) != 2 || results[0].NaN != CanonicalNaN || results[1].NaN != ArithmeticNaN || results[1].Value.Type != wasm.F64 {
+		t.Errorf("wrong NaN results: %+v", results)
+	}
+	results = cmds[8].Results
+	expectLanes := []NaNPattern{NoNaN, CanonicalNaN, NoNaN, NoNaN}
+	if len(results) != 3 || results[0].Shape != "f32x4" || !reflect.DeepEqual(results[0].Lanes, expectLanes) {
+		t.Errorf("wrong v128 result: %+v", results)
+	} else if !results[1].AnyRef || results[1].Value.Type != wasm.ExternRef || !results[2].Value.Null {
+		t.Errorf("wrong ref results: %+v", results[1:])
+	}
+	if either := cmds[9].Results[0].Either; len(either) != 2 || either[1].Value.Bits != 2 {
+		t.Errorf("wrong either result: %+v", either)
+	}
+
+	if cmd := cmds[11]; cmd.Module == nil || cmd.Action != nil || cmd.Message != "out of bounds" {
+		t.Errorf("wrong assert_trap with module: %+v", cmd)
+	}
+	if m := cmds[14].Module; m.Type != QuoteModule || cmds[14].Message != "unexpected end" {
+		t.Errorf("wrong assert_malformed: %+v", cmds[14])
+	}
+
+	for _, i := range []int{0, 1, 2} {
+		if _, err := cmds[i].Module.Decode(); err != nil {
+			t.Errorf("command %d: unexpected error: %v", i, err)
+		}
+	}
+	if _, err := cmds[14].Module.Decode(); err == nil {
+		t.Errorf("expected an error from malformed module")
+	}
+}
+
+func TestParse_Module(t *testing.T) {
+	script, err := Parse([]byte(`(func) (memory 1)`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(script.Commands) != 1 || script.Commands[0].Type != ModuleCommand {
+		t.Fatalf("expect a single module, got %+v", script.Commands)
+	}
+	module, err := script.Commands[0].Module.Decode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(module.Funcs) != 1 || len(module.Memories) != 1 {
+		t.Errorf("wrong module: %+v", module)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{"UnknownCommand", `(module) (assert_bogus)`, `L:1 C:10 @ 9: unexpected (assert_bogus ...): expect command`},
+		{"MissingName", `(invoke)`, `L:1 C:1 @ 0: expect string before ')'`},
+		{"MissingMessage", `(assert_invalid (module))`, `L:1 C:1 @ 0: expect string before ')'`},
+		{"NotAnAction", `(assert_return (module))`, `L:1 C:16 @ 15: unexpected (module ...): expect action`},
+		{"UnknownConst", `(invoke "f" (i32.add))`, `L:1 C:13 @ 12: unexpected (i32.add ...): expect constant`},
+		{"BadNumber", `(invoke "f" (i32.const 0x1_0000_0000))`, `L:1 C:24 @ 23: invalid i32: wat.Num.ToInteger: converting "0x100000000": value out of range`},
+		{"BadShape", `(invoke "f" (v128.const i128 0))`, `L:1 C:25 @ 24: unexpected keyword "i128": expect vector shape`},
+		{"BadHeapType", `(invoke "f" (ref.null any))`, `L:1 C:23 @ 22: unexpected keyword "any": expect heap type`},
+		{"Trailing", `(register "m" $M "x")`, `L:1 C:18 @ 17: unexpected string "x"`},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := Parse([]byte(row.Input))
+			var errs wat.SyntaxErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expect wat.SyntaxErrors, got %#v", err)
+			}
+			if actual := errs[0].Error(); actual != row.Expect {
+				t.Errorf("wrong error:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestResult_Matches(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Result Result
+		Value  Value
+		Expect bool
+	}
+
+	f32 := func(bits uint64) Value { return Value{Type: wasm.F32, Bits: bits} }
+	f64 := func(bits uint64) Value { return Value{Type: wasm.F64, Bits: bits} }
+	canonical32 := Result{Value: Value{Type: wasm.F32}, NaN: CanonicalNaN}
+	arithmetic64 := Result{Value: Value{Type: wasm.F64}, NaN: ArithmeticNaN}
+	lanes := Result{Value: Value{Type: wasm.V128, V128: [16]byte{4: 1}}, Shape: "f32x4", Lanes: []NaNPattern{CanonicalNaN, NoNaN, NoNaN, NoNaN}}
+
+	testData := [...]testCase{
+		{"Equal", Result{Value: Value{Type: wasm.I32, Bits: 7}}, Value{Type: wasm.I32, Bits: 7}, true},
+		{"NotEqual", Result{Value: Value{Type: wasm.I32, Bits: 7}}, Value{Type: wasm.I32, Bits: 8}, false},
+		{"WrongType", Result{Value: Value{Type: wasm.I32, Bits: 7}}, Value{Type: wasm.I64, Bits: 7}, false},
+		{"Canonical", canonical32, f32(0x7fc00000), true},
+		{"CanonicalNegative", canonical32, f32(0xffc00000), true},
+		{"CanonicalPayload", canonical32, f32(0x7fc00001), false},
+		{"CanonicalInfinity", canonical32, f32(0x7f800000), false},
+		{"Arithmetic", arithmetic64, f64(0x7ff8000000000001), true},
+		{"ArithmeticSignaling", arithmetic64, f64(0x7ff0000000000001), false},
+		{"Lanes", lanes, Value{Type: wasm.V128, V128: [16]byte{2: 0xc0, 3: 0x7f, 4: 1}}, true},
+		{"LanesMismatch", lanes, Value{Type: wasm.V128, V128: [16]byte{2: 0xc0, 3: 0x7f}}, false},
+		{"AnyRef", Result{Value: Value{Type: wasm.ExternRef}, AnyRef: true}, Value{Type: wasm.ExternRef, Bits: 3}, true},
+		{"AnyRefNull", Result{Value: Value{Type: wasm.ExternRef}, AnyRef: true}, Value{Type: wasm.ExternRef, Null: true}, false},
+		{"Null", Result{Value: Value{Type: wasm.FuncRef, Null: true}}, Value{Type: wasm.FuncRef, Null: true}, true},
+		{"Either", Result{Either: []Result{{Value: Value{Type: wasm.I32, Bits: 1}}, {Value: Value{Type: wasm.I32, Bits: 2}}}}, Value{Type: wasm.I32, Bits: 2}, true},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if actual := row.Result.Matches(row.Value); actual != row.Expect {
+				t.Errorf("wrong match: expect %v, got %v", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package wast reads the scripts of the WebAssembly specification's test
+// suite, which wrap modules in the text format with commands that invoke
+// them and assertions about the outcome.
+package wast
+
+import (
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// Script is a parsed .wast file: its commands, in order.
+type Script struct {
+	Commands []*Command
+}
+
+// Command is one top-level command of a script.  Which of the fields are
+// meaningful depends on the Type:
+//
+//   - ModuleCommand: Module.
+//   - RegisterCommand: Name, and ID if the command names a module.
+//   - ActionCommand: Action.
+//   - AssertReturnCommand: Action and Results.
+//   - AssertTrapCommand: Action or Module, and Message.
+//   - AssertExhaustionCommand: Action and Message.
+//   - AssertInvalidCommand, AssertMalformedCommand,
+//     AssertUnlinkableCommand, AssertUninstantiableCommand: Module and
+//     Message.
+type Command struct {
+	Type    CommandType
+	Module  *Module
+	Action  *Action
+	Results []Result
+	Name    string
+	ID      string
+	Message string
+	Span    wat.Span
+}
+
+// Module is a module defined by a script.  ID is its identifier, without
+// the '$', or "" if it has none.
+type Module struct {
+	Type ModuleType
+	ID   string
+
+	// Node is the (module ...) expression.  For a TextModule, it is what
+	// text.Lower reads.
+	Node *wat.Node
+
+	// Data holds the bytes of a BinaryModule, or the source of a
+	// QuoteModule.
+	Data []byte
+}
+
+// Action invokes an exported function, or reads an exported global, of
+// the module with the given ID, or of the most recent module if Module is
+// "".
+type Action struct {
+	Type   ActionType
+	Module string
+	Name   string
+	Args   []Value
+	Span   wat.Span
+}
+
+// Value is a constant argument of an action.
+type Value struct {
+	Type wasm.ValType
+
+	// Bits holds the bits of an i32, i64, f32 or f64, zero extended, or
+	// the host reference of a non-null externref.  V128 holds a v128.
+	Bits uint64
+	V128 [16]byte
+
+	// Null is set for a null reference.
+	Null bool
+}
+
+// Result is a pattern for a value that an action returns.
+type Result struct {
+	Value Value
+
+	// NaN, if set, matches any f32 or f64 NaN of the given kind,
+	// rather than Value.Bits.
+	NaN NaNPattern
+
+	// Shape is the shape of a v128 result, as in "f32x4".  Lanes, if not
+	// nil, holds the NaN pattern of each lane, for lanes that must
+	// match a kind of NaN rather than the bits of Value.V128.
+	Shape string
+	Lanes []NaNPattern
+
+	// AnyRef, for (ref.extern) and (ref.func) without a value, matches
+	// any non-null reference of Value.Type.
+	AnyRef bool
+
+	// Either, if not nil, holds alternatives, of which any one may
+	// match.
+	Either []Result
+}
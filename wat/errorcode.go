@@ -0,0 +1,85 @@
+package wat
+
+import (
+	"fmt"
+)
+
+type ErrorCode byte
+
+const (
+	UnknownError ErrorCode = iota
+	UnexpectedCharError
+	UnexpectedEOFError
+	InvalidUTF8Error
+	InvalidCharError
+	ControlCharError
+	EscapeRangeError
+	ReadError
+	InvalidTokenError
+	UnexpectedTokenError
+	UnmatchedOpenParenError
+	UnmatchedCloseParenError
+	SemanticError
+)
+
+var errorCodeGoNames = [...]string{
+	"wat.UnknownError",
+	"wat.UnexpectedCharError",
+	"wat.UnexpectedEOFError",
+	"wat.InvalidUTF8Error",
+	"wat.InvalidCharError",
+	"wat.ControlCharError",
+	"wat.EscapeRangeError",
+	"wat.ReadError",
+	"wat.InvalidTokenError",
+	"wat.UnexpectedTokenError",
+	"wat.UnmatchedOpenParenError",
+	"wat.UnmatchedCloseParenError",
+	"wat.SemanticError",
+}
+
+var errorCodeNames = [...]string{
+	"UnknownError",
+	"UnexpectedChar",
+	"UnexpectedEOF",
+	"InvalidUTF8",
+	"InvalidChar",
+	"ControlChar",
+	"EscapeRange",
+	"Read",
+	"InvalidToken",
+	"UnexpectedToken",
+	"UnmatchedOpenParen",
+	"UnmatchedCloseParen",
+	"Semantic",
+}
+
+func (enum ErrorCode) GoString() string {
+	var scratch [32]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum ErrorCode) String() string {
+	var scratch [32]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum ErrorCode) AppendTo(out []byte, verbose bool) []byte {
+	names := errorCodeNames
+	if verbose {
+		names = errorCodeGoNames
+	}
+	var str string
+	if enum < ErrorCode(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wat.ErrorCode(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = ErrorCode(0)
+	_ fmt.Stringer   = ErrorCode(0)
+	_ appenderTo     = ErrorCode(0)
+)
@@ -0,0 +1,55 @@
+package wat
+
+import (
+	"fmt"
+)
+
+type FloatStyle byte
+
+const (
+	ShortestFloat FloatStyle = iota
+	DecimalFloat
+	HexFloat
+)
+
+var floatStyleGoNames = [...]string{
+	"wat.ShortestFloat",
+	"wat.DecimalFloat",
+	"wat.HexFloat",
+}
+
+var floatStyleNames = [...]string{
+	"ShortestFloat",
+	"DecimalFloat",
+	"HexFloat",
+}
+
+func (enum FloatStyle) GoString() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], true))
+}
+
+func (enum FloatStyle) String() string {
+	var scratch [24]byte
+	return string(enum.AppendTo(scratch[:0], false))
+}
+
+func (enum FloatStyle) AppendTo(out []byte, verbose bool) []byte {
+	names := floatStyleNames
+	if verbose {
+		names = floatStyleGoNames
+	}
+	var str string
+	if enum < FloatStyle(len(names)) {
+		str = names[enum]
+	} else {
+		str = fmt.Sprintf("wat.FloatStyle(%d)", byte(enum))
+	}
+	return append(out, str...)
+}
+
+var (
+	_ fmt.GoStringer = FloatStyle(0)
+	_ fmt.Stringer   = FloatStyle(0)
+	_ appenderTo     = FloatStyle(0)
+)
@@ -0,0 +1,368 @@
+package wat
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultIndentWidth = 2
+	defaultLineWidth   = 80
+)
+
+type Formatter struct {
+	indentWidth uint
+	lineWidth   uint
+	hasIndent   bool
+	hasWidth    bool
+}
+
+func (formatter *Formatter) IndentWidth(value uint) *Formatter {
+	formatter.indentWidth = value
+	formatter.hasIndent = true
+	return formatter
+}
+
+func (formatter *Formatter) LineWidth(value uint) *Formatter {
+	formatter.lineWidth = value
+	formatter.hasWidth = true
+	return formatter
+}
+
+func (formatter *Formatter) Source(src []byte) ([]byte, error) {
+	var p Parser
+	p.KeepSpaces(true).KeepComments(true).KeepAnnotations(true)
+	root, err := p.Parse(NewLexer(src))
+	if err != nil {
+		return nil, err
+	}
+	return formatter.Append(make([]byte, 0, len(src)), root), nil
+}
+
+func (formatter *Formatter) Append(out []byte, root *Node) []byte {
+	if formatter == nil {
+		formatter = new(Formatter)
+	}
+
+	fs := formatState{
+		out:         out,
+		start:       uint(len(out)),
+		indentWidth: defaultIndentWidth,
+		lineWidth:   defaultLineWidth,
+	}
+	if formatter.hasIndent {
+		fs.indentWidth = formatter.indentWidth
+	}
+	if formatter.hasWidth {
+		fs.lineWidth = formatter.lineWidth
+	}
+
+	if root == nil {
+		return out
+	}
+	if root.Type != ExprNode {
+		fs.item(root, 0)
+		fs.newline(0)
+		return fs.out
+	}
+
+	items := collectItems(root.Value.([]*Node))
+	for i, it := range items {
+		if i > 0 {
+			switch {
+			case it.newlines == 0 && isCommentNode(it.node) && items[i-1].node.Type != LineCommentNode:
+				fs.space()
+			case it.newlines >= 2:
+				fs.newline(0)
+				fs.newline(0)
+			default:
+				fs.newline(0)
+			}
+		}
+		fs.item(it.node, 0)
+	}
+	if len(items) > 0 {
+		fs.newline(0)
+	}
+	return fs.out
+}
+
+type formatItem struct {
+	node     *Node
+	newlines uint
+}
+
+func collectItems(list []*Node) []formatItem {
+	items := make([]formatItem, 0, len(list))
+	var newlines uint
+	for _, child := range list {
+		switch child.Type {
+		case SpaceNode:
+			if isNewlineNode(child) {
+				newlines += child.Value.(Space).Count
+			}
+		case ErrorNode:
+			// pass
+		default:
+			items = append(items, formatItem{node: child, newlines: newlines})
+			newlines = 0
+		}
+	}
+	return items
+}
+
+type formatState struct {
+	out         []byte
+	start       uint
+	indentWidth uint
+	lineWidth   uint
+}
+
+func (fs *formatState) column() uint {
+	line := fs.out[fs.start:]
+	if i := bytes.LastIndexByte(line, '\n'); i >= 0 {
+		line = line[i+1:]
+	}
+	return uint(utf8.RuneCount(line))
+}
+
+func (fs *formatState) space() {
+	fs.out = append(fs.out, ' ')
+}
+
+func (fs *formatState) newline(indent uint) {
+	// Never leave trailing whitespace behind.
+	fs.out = bytes.TrimRight(fs.out, " ")
+	if uint(len(fs.out)) < fs.start {
+		fs.out = fs.out[:fs.start]
+	}
+	fs.out = append(fs.out, '\n')
+	for i := uint(0); i < indent; i++ {
+		fs.out = append(fs.out, ' ')
+	}
+}
+
+func (fs *formatState) fits(node *Node) bool {
+	width, ok := flatWidth(node)
+	return ok && fs.column()+width <= fs.lineWidth
+}
+
+func (fs *formatState) item(node *Node, indent uint) {
+	if !isListNode(node) {
+		fs.out = appendFormattedAtom(fs.out, node)
+		return
+	}
+
+	if fs.fits(node) {
+		fs.out = appendFlat(fs.out, node)
+		return
+	}
+
+	items := collectItems(node.Value.([]*Node))
+	fs.out = append(fs.out, '(')
+
+	var prev *Node
+	prevBroken := false
+	unitEnd := 0
+	depth := uint(0)
+	for i, it := range items {
+		child := it.node
+		glued := i < unitEnd
+		if !glued {
+			unitEnd = immediatesEnd(items, i)
+		}
+		if i > 0 && closesBlock(child) && depth > 0 {
+			depth--
+		}
+		childIndent := indent + (1+depth)*fs.indentWidth
+		if i > 0 {
+			switch {
+			case glued:
+				fs.space()
+			case prev.Type == LineCommentNode:
+				fs.newline(childIndent)
+			case it.newlines >= 2:
+				fs.newline(0)
+				fs.newline(childIndent)
+			case it.newlines > 0:
+				fs.newline(childIndent)
+			case isCommentNode(child):
+				fs.space()
+			case prevBroken:
+				fs.newline(childIndent)
+			case fs.column() < fs.lineWidth && fs.fitsAfterSpace(items[i:unitEnd]):
+				fs.space()
+			default:
+				fs.newline(childIndent)
+			}
+		}
+
+		before := len(fs.out)
+		fs.item(child, childIndent)
+		prevBroken = bytes.IndexByte(fs.out[before:], '\n') >= 0
+		prev = child
+		if i > 0 && opensBlock(child) {
+			depth++
+		}
+	}
+
+	if prev != nil && prev.Type == LineCommentNode {
+		fs.newline(indent)
+	}
+	fs.out = append(fs.out, ')')
+}
+
+func (fs *formatState) fitsAfterSpace(unit []formatItem) bool {
+	var total uint
+	for _, it := range unit {
+		width, ok := flatWidth(it.node)
+		if !ok {
+			// Doesn't fit on any line; keep it next to its predecessor
+			// unless it's an expression that will be broken up anyway.
+			return !isListNode(it.node)
+		}
+		total += 1 + width
+	}
+	return fs.column()+total <= fs.lineWidth
+}
+
+// immediatesEnd returns the end of the unit that starts at items[i]: a
+// keyword together with the immediates that follow it on the same line,
+// such as "f32.const nan:0x7fffff" or "i32.load offset=4", which are never
+// split across lines.  Any other item is a unit by itself.
+func immediatesEnd(items []formatItem, i int) int {
+	j := i + 1
+	if items[i].node.Type != KeywordNode {
+		return j
+	}
+	for j < len(items) && items[j].newlines == 0 && isImmediate(items[j].node) {
+		j++
+	}
+	return j
+}
+
+// opensBlock and closesBlock find the structured instructions of a flat
+// instruction sequence, whose bodies are indented one more level.  "else"
+// does both.
+func opensBlock(node *Node) bool {
+	if node.Type != KeywordNode {
+		return false
+	}
+	switch node.Value.(string) {
+	case "block", "loop", "if", "else":
+		return true
+	default:
+		return false
+	}
+}
+
+func closesBlock(node *Node) bool {
+	if node.Type != KeywordNode {
+		return false
+	}
+	switch node.Value.(string) {
+	case "else", "end":
+		return true
+	default:
+		return false
+	}
+}
+
+func isImmediate(node *Node) bool {
+	switch node.Type {
+	case NumberNode, IdentifierNode:
+		return true
+	case KeywordNode:
+		return strings.IndexByte(node.Value.(string), '=') >= 0
+	default:
+		return false
+	}
+}
+
+func flatWidth(node *Node) (uint, bool) {
+	if !isFlat(node) {
+		return 0, false
+	}
+	var scratch [256]byte
+	return uint(utf8.RuneCount(appendFlat(scratch[:0], node))), true
+}
+
+func isFlat(node *Node) bool {
+	switch node.Type {
+	case LineCommentNode:
+		return false
+	case BlockCommentNode:
+		return len(node.Value.([]string)) <= 1
+	case ExprNode:
+		fallthrough
+	case AnnotationNode:
+		for _, child := range node.Value.([]*Node) {
+			if child.Type != SpaceNode && !isFlat(child) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+func appendFlat(out []byte, node *Node) []byte {
+	if !isListNode(node) {
+		return appendFormattedAtom(out, node)
+	}
+	out = append(out, '(')
+	first := true
+	for _, child := range node.Value.([]*Node) {
+		if child.Type == SpaceNode || child.Type == ErrorNode {
+			continue
+		}
+		if !first {
+			out = append(out, ' ')
+		}
+		out = appendFlat(out, child)
+		first = false
+	}
+	out = append(out, ')')
+	return out
+}
+
+func appendFormattedAtom(out []byte, node *Node) []byte {
+	switch node.Type {
+	case LineCommentNode:
+		out = append(out, ';', ';')
+		out = append(out, strings.TrimRight(node.Value.(string), " \t")...)
+		return out
+
+	case BlockCommentNode:
+		lines := node.Value.([]string)
+		out = append(out, '(', ';')
+		for i, line := range lines {
+			if i > 0 {
+				out = bytes.TrimRight(out, " \t")
+				out = append(out, '\n')
+			}
+			out = append(out, line...)
+		}
+		out = append(out, ';', ')')
+		return out
+
+	case StringNode:
+		return appendQuotedString(out, node.Value.(string))
+
+	case NumberNode:
+		return node.Value.(Num).appendNormalized(out)
+
+	default:
+		return append(out, node.Value.(string)...)
+	}
+}
+
+func isListNode(node *Node) bool {
+	return node.Type == ExprNode || node.Type == AnnotationNode
+}
+
+func isCommentNode(node *Node) bool {
+	return node.Type == LineCommentNode || node.Type == BlockCommentNode
+}
@@ -0,0 +1,151 @@
+package wat
+
+import (
+	"io/fs"
+	"path"
+	"testing"
+)
+
+func TestFormatter(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Indent uint
+		Width  uint
+		Input  string
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Flat",
+			Width:  80,
+			Input:  "(module\n  (func $f\n    (param i32)    (result i32)\n local.get 0))",
+			Expect: "(module (func $f (param i32) (result i32) local.get 0))\n",
+		},
+		{
+			Name:   "Fold",
+			Width:  30,
+			Input:  "(module (func $f (param i32) (result i32) local.get 0))",
+			Expect: "(module\n  (func $f (param i32)\n    (result i32) local.get 0))\n",
+		},
+		{
+			Name:   "Indent",
+			Indent: 4,
+			Width:  20,
+			Input:  "(module (memory 1) (func $f))",
+			Expect: "(module (memory 1)\n    (func $f))\n",
+		},
+		{
+			Name:   "Immediates",
+			Width:  24,
+			Input:  "(func (result f32) f32.const nan:0x7f_ffff i32.load offset=4 align=2)\n(f32.const nan:0x7f_ffff)",
+			Expect: "(func (result f32)\n  f32.const nan:0x7fffff\n  i32.load offset=4 align=2)\n(f32.const nan:0x7fffff)\n",
+		},
+		{
+			Name:   "Blocks",
+			Width:  40,
+			Input:  "(func\nblock\nloop $l\nbr $l\nend\nend\nif\nnop\nelse\n(block nop)\nend)",
+			Expect: "(func\n  block\n    loop $l\n      br $l\n    end\n  end\n  if\n    nop\n  else\n    (block nop)\n  end)\n",
+		},
+		{
+			Name:   "Comments",
+			Width:  80,
+			Input:  ";; header   \n\n\n\n(module ;; trailing\n(func) (; inline ;) (; multi\n   line ;)\n)",
+			Expect: ";; header\n\n(module ;; trailing\n  (func) (; inline ;) (; multi\n   line ;))\n",
+		},
+		{
+			Name:   "Normalize",
+			Width:  80,
+			Input:  `(data "\41\u{42}\0a\FF") (i32.const 0xA_B) (f32.const 0x1.8p+1) (f64.const 1_000.5e1_0) (f32.const nan:0x7F_FFFF)`,
+			Expect: `(data "AB\n\ff")` + "\n" + `(i32.const 0xab)` + "\n" + `(f32.const 0x1.8p+1)` + "\n" + `(f64.const 1000.5e10)` + "\n" + `(f32.const nan:0x7fffff)` + "\n",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var f Formatter
+			if row.Indent != 0 {
+				f.IndentWidth(row.Indent)
+			}
+			f.LineWidth(row.Width)
+
+			out, err := f.Source([]byte(row.Input))
+			if err != nil {
+				t.Fatalf("Source failed: %v", err)
+			}
+			if str := string(out); str != row.Expect {
+				t.Errorf("wrong output\n\texpect: %q\n\tactual: %q", row.Expect, str)
+			}
+
+			again, err := f.Source(out)
+			if err != nil {
+				t.Fatalf("Source failed on formatted output: %v", err)
+			}
+			if string(again) != string(out) {
+				t.Errorf("formatting is not idempotent\n\tfirst:  %q\n\tsecond: %q", out, again)
+			}
+		})
+	}
+}
+
+func TestFormatter_Idempotent(t *testing.T) {
+	entries, err := fs.ReadDir(testDataFS, "testdata")
+	if err != nil {
+		t.Fatalf("failed to list testdata: %v", err)
+	}
+
+	var p Parser
+	p.KeepSpaces(true).KeepComments(true)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		t.Run(name, func(t *testing.T) {
+			raw, err := fs.ReadFile(testDataFS, path.Join("testdata", name))
+			if err != nil {
+				t.Fatalf("failed to read %q: %v", name, err)
+			}
+
+			var f Formatter
+			first, err := f.Source(raw)
+			if err != nil {
+				t.Fatalf("Source failed: %v", err)
+			}
+			second, err := f.Source(first)
+			if err != nil {
+				t.Fatalf("Source failed on formatted output: %v", err)
+			}
+			if string(first) != string(second) {
+				t.Errorf("formatting is not idempotent\n\tfirst:  %q\n\tsecond: %q", first, second)
+			}
+
+			before, _ := p.Parse(NewLexer(raw))
+			after, _ := p.Parse(NewLexer(first))
+			if !stripped(before).Equals(stripped(after)) {
+				t.Errorf("formatting changed the tree\n\tbefore: %v\n\tafter:  %v", before, after)
+			}
+		})
+	}
+}
+
+func stripped(node *Node) *Node {
+	if node == nil || node.Type != ExprNode {
+		return node
+	}
+	var list []*Node
+	for _, child := range node.Value.([]*Node) {
+		switch child.Type {
+		case SpaceNode:
+		case LineCommentNode:
+		case BlockCommentNode:
+		case NumberNode:
+			var scratch [64]byte
+			str := string(child.Value.(Num).appendNormalized(scratch[:0]))
+			list = append(list, &Node{Type: NumberNode, Value: str})
+		default:
+			list = append(list, stripped(child))
+		}
+	}
+	return &Node{Type: ExprNode, Value: list}
+}
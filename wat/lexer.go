@@ -1,13 +1,15 @@
 package wat
 
 import (
-	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/chronos-tachyon/wasmfile/internal/hexfmt"
 )
 
 type TokenStream interface {
@@ -15,17 +17,30 @@ type TokenStream interface {
 	Next() Token
 }
 
+type Resyncer interface {
+	Resync() bool
+}
+
 type Lexer struct {
-	input []byte
-	pos   Position
-	next  Token
+	reader io.Reader
+	buffer []byte
+	base   uint64
+	eof    bool
+	err    error
+	pos    Position
+	last   Span
+	next   Token
 
 	scratchBytes   [1024]byte
 	scratchStrings [64]string
 }
 
 func NewLexer(input []byte) *Lexer {
-	return &Lexer{input: input}
+	return &Lexer{buffer: input, eof: true}
+}
+
+func NewReaderLexer(r io.Reader) *Lexer {
+	return &Lexer{reader: r, buffer: make([]byte, 0, readerLexerBufferSize)}
 }
 
 func (lexer *Lexer) HasNext() bool {
@@ -37,7 +52,7 @@ func (lexer *Lexer) HasNext() bool {
 	lexer.next.Type = InvalidToken
 	lexer.next.Span = Span{Begin: lexer.pos, End: lexer.pos}
 
-	if len(lexer.input) <= 0 {
+	if len(lexer.available()) <= 0 && lexer.err == nil {
 		lexer.next.Type = AcceptToken
 		return true
 	}
@@ -50,6 +65,37 @@ func (lexer *Lexer) Next() Token {
 	return lexer.next
 }
 
+func (lexer *Lexer) Resync() bool {
+	if lexer.next.Type != RejectToken || lexer.err != nil {
+		return false
+	}
+
+	for {
+		input := lexer.available()
+		if len(input) <= 0 {
+			break
+		}
+
+		ch, size := utf8.DecodeRune(input)
+		if ch == '(' || ch == ')' || isSpace(ch) {
+			break
+		}
+
+		if size == 1 && ch == utf8.RuneError {
+			lexer.pos.ByteOffset++
+			lexer.pos.RuneOffset++
+			lexer.pos.Column++
+			lexer.pos.SkipLF = false
+			continue
+		}
+
+		lexer.pos.Advance(ch, size)
+	}
+
+	lexer.next.Type = InvalidToken
+	return true
+}
+
 func (lexer *Lexer) lex() {
 	ch, ok := lexer.peekRune()
 	switch {
@@ -189,6 +235,10 @@ func (lexer *Lexer) lexOther() {
 			lexer.lexBlockComment()
 			return
 		}
+		if lexer.match('@') {
+			lexer.lexAnnotation()
+			return
+		}
 		lexer.done(OpenParenToken, nil)
 
 	case ')':
@@ -213,6 +263,20 @@ func (lexer *Lexer) lexOther() {
 	}
 }
 
+func (lexer *Lexer) lexAnnotation() {
+	partial := lexer.scratchBytes[:0]
+	partial = append(partial, '@')
+	name := lexer.takeWhile(partial, isIdentifier)
+	if len(name) <= 1 {
+		ch, ok := lexer.peekRune()
+		if ok {
+			lexer.rejectUnexpected(ch, `annotation name after '(@'`)
+		}
+		return
+	}
+	lexer.done(AnnotationToken, name)
+}
+
 func (lexer *Lexer) lexBlockComment() {
 	const (
 		stateReady = iota
@@ -294,6 +358,7 @@ func (lexer *Lexer) lexBlockComment() {
 func (lexer *Lexer) lexString() {
 	partial := lexer.scratchBytes[:0]
 	for {
+		begin := lexer.pos
 		ch, ok := lexer.readRune()
 		if !ok {
 			return
@@ -310,7 +375,7 @@ func (lexer *Lexer) lexString() {
 
 		if ch == '\\' {
 			var ok bool
-			partial, ok = lexer.appendEscape(partial)
+			partial, ok = lexer.appendEscape(partial, begin)
 			if !ok {
 				return
 			}
@@ -321,7 +386,7 @@ func (lexer *Lexer) lexString() {
 	}
 }
 
-func (lexer *Lexer) appendEscape(partial []byte) ([]byte, bool) {
+func (lexer *Lexer) appendEscape(partial []byte, begin Position) ([]byte, bool) {
 	ch, ok := lexer.readRune()
 	if !ok {
 		return nil, false
@@ -352,8 +417,8 @@ func (lexer *Lexer) appendEscape(partial []byte) ([]byte, bool) {
 			panic(err)
 		}
 
-		ch = rune(u64)
-		partial = utf8.AppendRune(partial, ch)
+		// WAT strings are byte strings: \HH is a raw byte, not a rune.
+		partial = append(partial, byte(u64))
 		return partial, true
 	}
 
@@ -387,13 +452,13 @@ func (lexer *Lexer) appendEscape(partial []byte) ([]byte, bool) {
 
 		u64, err := strconv.ParseUint(hex, 16, 32)
 		if err != nil {
-			lexer.rejectf("hex escape \\u{%s} is out of range for a 32-bit unsigned integer", hex)
+			lexer.rejectEscape(begin, fmt.Sprintf("hex escape \\u{%s} is out of range for a 32-bit unsigned integer", hex))
 			return nil, false
 		}
 
 		ch = rune(u64)
 		if ch < 0 || !utf8.ValidRune(ch) {
-			lexer.rejectf("hex escape \\u{%s} encodes an invalid Unicode rune, U+%04x", hex, u64)
+			lexer.rejectEscape(begin, fmt.Sprintf("hex escape \\u{%s} encodes an invalid Unicode rune, U+%04x", hex, u64))
 			return nil, false
 		}
 
@@ -430,9 +495,10 @@ func (lexer *Lexer) takeDigits(partial []byte, hex bool) string {
 			break
 		}
 		if ch == '_' {
-			continue
+			// An underscore must separate two digits.
+			ch, ok = lexer.readRune()
 		}
-		if !isDigit(ch) {
+		if !ok || !isDigit(ch) {
 			break
 		}
 		partial = utf8.AppendRune(partial, ch)
@@ -494,57 +560,122 @@ func (lexer *Lexer) peekRune() (ch rune, ok bool) {
 }
 
 func (lexer *Lexer) readRune() (rune, bool) {
-	if len(lexer.input) <= 0 {
+	input := lexer.available()
+	lexer.last = Span{Begin: lexer.pos, End: lexer.pos}
+	if len(input) <= 0 {
+		if lexer.err != nil {
+			lexer.reject(&SyntaxError{Code: ReadError, Span: lexer.last, Rune: -1, Err: lexer.err})
+			return -1, false
+		}
 		return -1, true
 	}
 
-	ch, size := utf8.DecodeRune(lexer.input)
+	ch, size := utf8.DecodeRune(input)
 	if size < 1 || (size == 1 && ch == utf8.RuneError) {
-		tmp := hexBytes(lexer.input)
+		lexer.last.End.ByteOffset++
+		lexer.last.End.RuneOffset++
+		lexer.last.End.Column++
+		tmp := hexfmt.Bytes(input)
 		suffix := ""
 		if len(tmp) > 8 {
 			tmp = tmp[:8]
 			suffix = "..."
 		}
-		lexer.rejectf("UTF-8 decode error: %v%s", tmp, suffix)
+		lexer.reject(&SyntaxError{
+			Code:   InvalidUTF8Error,
+			Span:   lexer.last,
+			Rune:   utf8.RuneError,
+			Detail: tmp.String() + suffix,
+		})
 		return -1, false
 	}
 
+	lexer.last.End.Advance(ch, size)
+
 	if ch < 0 || !utf8.ValidRune(ch) {
-		lexer.rejectf("invalid Unicode character %q U+%04x", ch, ch)
+		lexer.reject(&SyntaxError{Code: InvalidCharError, Span: lexer.last, Rune: ch})
 		return -1, false
 	}
 
 	if unicode.IsControl(ch) && !isSpace(ch) {
-		lexer.rejectf("unexpected Unicode control character %q U+%04x", ch, ch)
+		lexer.reject(&SyntaxError{Code: ControlCharError, Span: lexer.last, Rune: ch})
 		return -1, false
 	}
 
-	lexer.input = lexer.input[size:]
-	lexer.pos.Advance(ch, size)
+	lexer.pos = lexer.last.End
 	return ch, true
 }
 
-func (lexer *Lexer) rejectUnexpected(ch rune, expect string) {
-	partial := lexer.scratchBytes[:0]
-	partial = append(partial, "unexpected "...)
-	str := "end of input"
-	if ch >= 0 {
-		str = fmt.Sprintf("character %q U+%04x", ch, ch)
+func (lexer *Lexer) available() []byte {
+	i := lexer.pos.ByteOffset - lexer.base
+	input := lexer.buffer[i:]
+	if !lexer.eof && !utf8.FullRune(input) {
+		lexer.fill()
+		i = lexer.pos.ByteOffset - lexer.base
+		input = lexer.buffer[i:]
+	}
+	return input
+}
+
+func (lexer *Lexer) fill() {
+	// Marks never reach back past the beginning of the token currently
+	// being lexed, so everything before it can be discarded.
+	if keep := lexer.next.Span.Begin.ByteOffset - lexer.base; keep > 0 {
+		n := copy(lexer.buffer, lexer.buffer[keep:])
+		lexer.buffer = lexer.buffer[:n]
+		lexer.base += keep
+	}
+
+	i := lexer.pos.ByteOffset - lexer.base
+	emptyReads := 0
+	for !lexer.eof && !utf8.FullRune(lexer.buffer[i:]) {
+		bufLen := len(lexer.buffer)
+		bufCap := cap(lexer.buffer)
+		if bufCap-bufLen < readerLexerMinRead {
+			newCap := bufCap << 1
+			if newCap < readerLexerBufferSize {
+				newCap = readerLexerBufferSize
+			}
+			tmp := make([]byte, bufLen, newCap)
+			copy(tmp, lexer.buffer)
+			lexer.buffer = tmp
+			bufCap = newCap
+		}
+
+		n, err := lexer.reader.Read(lexer.buffer[bufLen:bufCap])
+		lexer.buffer = lexer.buffer[:bufLen+n]
+		switch {
+		case err == io.EOF:
+			lexer.eof = true
+		case err != nil:
+			lexer.eof = true
+			lexer.err = err
+		case n > 0:
+			emptyReads = 0
+		default:
+			emptyReads++
+			if emptyReads >= 100 {
+				lexer.eof = true
+				lexer.err = io.ErrNoProgress
+			}
+		}
 	}
-	partial = append(partial, str...)
-	if expect != "" {
-		partial = append(partial, ": expect "...)
-		partial = append(partial, expect...)
+}
+
+func (lexer *Lexer) rejectUnexpected(ch rune, expect string) {
+	code := UnexpectedCharError
+	if ch < 0 {
+		code = UnexpectedEOFError
 	}
-	lexer.reject(errors.New(string(partial)))
+	lexer.reject(&SyntaxError{Code: code, Span: lexer.last, Rune: ch, Expect: expect})
 }
 
-func (lexer *Lexer) rejectf(format string, v ...any) {
-	lexer.reject(fmt.Errorf(format, v...))
+func (lexer *Lexer) rejectEscape(begin Position, detail string) {
+	span := Span{Begin: begin, End: lexer.pos}
+	lexer.reject(&SyntaxError{Code: EscapeRangeError, Span: span, Rune: -1, Detail: detail})
 }
 
-func (lexer *Lexer) reject(err error) {
+func (lexer *Lexer) reject(err *SyntaxError) {
 	lexer.done(RejectToken, err)
 }
 
@@ -556,25 +687,43 @@ func (lexer *Lexer) done(tt TokenType, tv any) {
 	lexer.next.Type = tt
 	lexer.next.Value = tv
 	lexer.next.Span.End = lexer.pos
+	switch tt {
+	case NumberToken:
+		fallthrough
+	case StringToken:
+		fallthrough
+	case BlockCommentToken:
+		// The value loses the original spelling (digit separators,
+		// escapes, line terminators), so keep the raw source text.
+		i := lexer.next.Span.Begin.ByteOffset - lexer.base
+		j := lexer.next.Span.End.ByteOffset - lexer.base
+		lexer.next.Text = string(lexer.buffer[i:j])
+	}
 	if err := lexer.next.Validate(); err != nil {
 		panic(err)
 	}
 }
 
-var _ TokenStream = (*Lexer)(nil)
+var (
+	_ TokenStream = (*Lexer)(nil)
+	_ Resyncer    = (*Lexer)(nil)
+)
+
+const (
+	readerLexerBufferSize = 4096
+	readerLexerMinRead    = 512
+)
 
 type mark struct {
-	input []byte
-	pos   Position
-	next  Token
+	pos  Position
+	next Token
 }
 
 func (lexer *Lexer) createMark() mark {
-	return mark{lexer.input, lexer.pos, lexer.next}
+	return mark{lexer.pos, lexer.next}
 }
 
 func (m mark) rewind(lexer *Lexer) {
-	lexer.input = m.input
 	lexer.pos = m.pos
 	lexer.next = m.next
 }
@@ -589,15 +738,36 @@ func keywordToNumber(num *Num, keyword string) bool {
 		return true
 	}
 	if strings.HasPrefix(keyword, "nan:0x") {
+		payload, ok := hexPayload(keyword[6:])
+		if !ok {
+			return false
+		}
 		num.Flags |= FlagFloat | FlagNaN | FlagHex | FlagAcanonical
-		num.Integer = keyword[6:]
+		num.Integer = payload
 		return true
 	}
 	return false
 }
 
+// hexPayload checks the payload of "nan:0x..." and removes its digit
+// separators, as takeDigits does for other numbers.
+func hexPayload(str string) (string, bool) {
+	out := make([]byte, 0, len(str))
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case isHexDigit(rune(ch)):
+			out = append(out, ch)
+		case ch == '_' && len(out) > 0 && i+1 < len(str) && isHexDigit(rune(str[i+1])):
+		default:
+			return "", false
+		}
+	}
+	return string(out), len(out) > 0
+}
+
 func isLineComment(ch rune) bool {
-	return ch != '\r' && ch != '\n'
+	return ch >= 0 && ch != '\r' && ch != '\n'
 }
 
 func isRuneInTable(ch rune, tab [4]uint32) bool {
@@ -657,6 +827,12 @@ func isIdentifier(ch rune) bool {
 	return isRuneInTable(ch, identifierTable)
 }
 
+// IsIdentifierRune reports whether ch may appear in an identifier after
+// the leading '$'.
+func IsIdentifierRune(ch rune) bool {
+	return isIdentifier(ch)
+}
+
 var simpleEscapeTable = [4]uint32{
 	0x00000000, // 00 to 1f
 	0x00000084, // 20 to 3f
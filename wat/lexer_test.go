@@ -1,10 +1,15 @@
 package wat
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"io/fs"
 	"path"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func L(v ...string) []string {
@@ -296,6 +301,8 @@ func TestLexer(t *testing.T) {
 				Token{Type: SpaceToken, Value: S(LF, 1)},
 				Token{Type: StringToken, Value: "smiley: \u263a\ufe0f"},
 				Token{Type: SpaceToken, Value: S(LF, 1)},
+				Token{Type: StringToken, Value: "bytes: \xff\x00\xc3\xa9\x80"},
+				Token{Type: SpaceToken, Value: S(LF, 1)},
 				Token{Type: AcceptToken},
 			},
 		},
@@ -422,3 +429,110 @@ func TestLexer(t *testing.T) {
 		})
 	}
 }
+
+func TestLexer_LineCommentAtEOF(t *testing.T) {
+	input := ";; x"
+	expect := []Token{
+		Token{Type: LineCommentToken, Value: ` x`},
+		Token{Type: AcceptToken},
+	}
+
+	for _, lexer := range [...]*Lexer{NewLexer([]byte(input)), NewReaderLexer(strings.NewReader(input))} {
+		var actual []Token
+		for lexer.HasNext() && len(actual) <= len(expect) {
+			actual = append(actual, lexer.Next())
+		}
+		if len(actual) != len(expect) {
+			t.Fatalf("wrong tokens:\n\texpect: %v\n\tactual: %v", expect, actual)
+		}
+		for i, a := range expect {
+			if b := actual[i]; a.Type != b.Type || !reflect.DeepEqual(a.Value, b.Value) {
+				t.Errorf("token #%d: mismatch\n\texpect: %v\n\tactual: %v", i, a, b)
+			}
+		}
+	}
+}
+
+func TestReaderLexer(t *testing.T) {
+	entries, err := fs.ReadDir(testDataFS, "testdata")
+	if err != nil {
+		t.Fatalf("failed to list testdata: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		t.Run(name, func(t *testing.T) {
+			testDataPath := path.Join("testdata", name)
+
+			raw, err := fs.ReadFile(testDataFS, testDataPath)
+			if err != nil {
+				t.Errorf("failed to read %q: %v", testDataPath, err)
+				return
+			}
+
+			var expect []Token
+			lexer := NewLexer(raw)
+			for lexer.HasNext() {
+				expect = append(expect, lexer.Next())
+			}
+
+			var actual []Token
+			lexer = NewReaderLexer(iotest.OneByteReader(bytes.NewReader(raw)))
+			for lexer.HasNext() {
+				actual = append(actual, lexer.Next())
+			}
+
+			if !reflect.DeepEqual(expect, actual) {
+				t.Errorf("reader lexer gave different tokens:\n\texpect: %#v\n\tactual: %#v", expect, actual)
+			}
+		})
+	}
+}
+
+func TestReaderLexer_Error(t *testing.T) {
+	errFake := errors.New("fake read error")
+	r := io.MultiReader(strings.NewReader("(module "), iotest.ErrReader(errFake))
+
+	var last Token
+	lexer := NewReaderLexer(r)
+	for lexer.HasNext() {
+		last = lexer.Next()
+	}
+
+	if last.Type != RejectToken {
+		t.Fatalf("expected final token to be %v, got %v", RejectToken, last)
+	}
+	if err := last.Value.(error); !errors.Is(err, errFake) {
+		t.Errorf("expected error to wrap %v, got %v", errFake, err)
+	}
+	if last.Span.Begin.ByteOffset != 8 {
+		t.Errorf("expected error at byte offset 8, got %v", last.Span.Begin)
+	}
+}
+
+func TestReaderLexer_Bounded(t *testing.T) {
+	raw := bytes.Repeat([]byte("(module $m (func (i32.const 42)))\n"), 1<<15)
+
+	lexer := NewReaderLexer(bytes.NewReader(raw))
+	count := 0
+	for lexer.HasNext() {
+		token := lexer.Next()
+		if token.Type == RejectToken {
+			t.Fatalf("unexpected reject: %v", token)
+		}
+		count++
+	}
+
+	if count == 0 {
+		t.Error("no tokens")
+	}
+	if c := cap(lexer.buffer); c > readerLexerBufferSize {
+		t.Errorf("buffer grew to %d bytes for %d bytes of input", c, len(raw))
+	}
+	if lexer.pos.ByteOffset != uint64(len(raw)) {
+		t.Errorf("expected final offset %d, got %v", len(raw), lexer.pos)
+	}
+}
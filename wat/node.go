@@ -2,12 +2,15 @@ package wat
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
 type Node struct {
 	Type  NodeType
 	Value any
 	Span  Span
+	Text  string
 }
 
 func (node *Node) GoString() string {
@@ -58,6 +61,8 @@ func (node *Node) Equals(other *Node) bool {
 
 	switch node.Type {
 	case ExprNode:
+		fallthrough
+	case AnnotationNode:
 		a := node.Value.([]*Node)
 		b := other.Value.([]*Node)
 		aLen := uint(len(a))
@@ -87,6 +92,14 @@ func (node *Node) Equals(other *Node) bool {
 		}
 		return true
 
+	case ErrorNode:
+		a, aOK := node.Value.(*SyntaxError)
+		b, bOK := other.Value.(*SyntaxError)
+		if aOK && bOK {
+			return a.Code == b.Code
+		}
+		return (node.Value == other.Value)
+
 	case SpaceNode:
 		a := node.Value.(Space)
 		b := other.Value.(Space)
@@ -102,12 +115,33 @@ func (node *Node) Equals(other *Node) bool {
 	}
 }
 
+func (node *Node) AnnotationName() string {
+	if node == nil || node.Type != AnnotationNode {
+		return ""
+	}
+	list := node.Value.([]*Node)
+	if len(list) == 0 || list[0].Type != KeywordNode {
+		return ""
+	}
+	return strings.TrimPrefix(list[0].Value.(string), "@")
+}
+
+func (node *Node) IsValidUTF8() bool {
+	if node == nil || node.Type != StringNode {
+		return false
+	}
+	str, ok := node.Value.(string)
+	return ok && utf8.ValidString(str)
+}
+
 func (node *Node) Validate(recursive bool) error {
 	if node == nil {
 		return nil
 	}
 	switch node.Type {
 	case ExprNode:
+		fallthrough
+	case AnnotationNode:
 		return node.validateChildren(recursive)
 	case SpaceNode:
 		return node.validateSpace()
@@ -123,6 +157,8 @@ func (node *Node) Validate(recursive bool) error {
 		return node.validateStringList()
 	case NumberNode:
 		return node.validateNumber()
+	case ErrorNode:
+		return node.validateError()
 	default:
 		return fmt.Errorf("unknown node type %v", node.Type)
 	}
@@ -146,6 +182,16 @@ func (node *Node) validateChildren(recursive bool) error {
 	return nil
 }
 
+func (node *Node) validateError() error {
+	if node.Value == nil {
+		return fmt.Errorf("%v node has nil value, not error", node.Type)
+	}
+	if _, ok := node.Value.(error); !ok {
+		return fmt.Errorf("%v node has value of type %T, not error: %#v", node.Type, node.Value, node.Value)
+	}
+	return nil
+}
+
 func (node *Node) validateSpace() error {
 	if node.Value == nil {
 		return fmt.Errorf("%v node has nil value, not wat.Space", node.Type)
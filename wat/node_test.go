@@ -102,3 +102,28 @@ func TestNode_AppendTo(t *testing.T) {
 		})
 	}
 }
+
+func TestNode_IsValidUTF8(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  *Node
+		Expect bool
+	}
+
+	testData := [...]testCase{
+		{"Nil", nil, false},
+		{"Keyword", KN("blah"), false},
+		{"ASCII", SVN("hello"), true},
+		{"Multibyte", SVN("caf\xc3\xa9"), true},
+		{"RawByte", SVN("\xff\x00"), false},
+		{"Truncated", SVN("caf\xc3"), false},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if actual := row.Input.IsValidUTF8(); actual != row.Expect {
+				t.Errorf("expect %v, got %v", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ const (
 	IdentifierNode
 	StringNode
 	NumberNode
+	ErrorNode
+	AnnotationNode
 )
 
 var nodeTypeGoNames = [...]string{
@@ -28,6 +30,8 @@ var nodeTypeGoNames = [...]string{
 	"wat.IdentifierNode",
 	"wat.StringNode",
 	"wat.NumberNode",
+	"wat.ErrorNode",
+	"wat.AnnotationNode",
 }
 
 var nodeTypeNames = [...]string{
@@ -40,6 +44,8 @@ var nodeTypeNames = [...]string{
 	"Identifier",
 	"String",
 	"Number",
+	"Error",
+	"Annotation",
 }
 
 func (enum NodeType) GoString() string {
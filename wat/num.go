@@ -3,6 +3,7 @@ package wat
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type Num struct {
@@ -96,6 +97,14 @@ func (num Num) appendGuts(out []byte, verbose bool) []byte {
 	return out
 }
 
+// appendNormalized writes num with lowercase digits.  The lexer has already
+// removed any digit separators.
+func (num Num) appendNormalized(out []byte) []byte {
+	num.Integer = strings.ToLower(num.Integer)
+	num.Fraction = strings.ToLower(num.Fraction)
+	return num.appendGuts(out, false)
+}
+
 var (
 	_ fmt.GoStringer = Num{}
 	_ fmt.Stringer   = Num{}
@@ -0,0 +1,225 @@
+package wat
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+func (num Num) Uint32() (uint32, error) {
+	u64, err := num.toInteger("Uint32", 32, false)
+	return uint32(u64), err
+}
+
+func (num Num) Uint64() (uint64, error) {
+	return num.toInteger("Uint64", 64, false)
+}
+
+func (num Num) Int32() (int32, error) {
+	u64, err := num.toInteger("Int32", 32, true)
+	return int32(uint32(u64)), err
+}
+
+func (num Num) Int64() (int64, error) {
+	u64, err := num.toInteger("Int64", 64, true)
+	return int64(u64), err
+}
+
+// ToInteger returns the numBits-wide two's-complement bit pattern of an
+// integer literal.  If signed is false, the literal may be anything in
+// the union of the signed and unsigned ranges, as with WebAssembly's iN
+// literals; if signed is true, it must be in the signed range.
+func (num Num) ToInteger(numBits uint, signed bool) (uint64, error) {
+	return num.toInteger("ToInteger", numBits, signed)
+}
+
+func (num Num) toInteger(fn string, numBits uint, signed bool) (uint64, error) {
+	if numBits < 1 || numBits > 64 {
+		panic(fmt.Errorf("numBits %d is out of range 1..64", numBits))
+	}
+
+	if num.Flags.HasAny(FlagFloat|FlagInf|FlagNaN) || num.Fraction != "" || num.Exponent != "" {
+		return 0, &NumError{Func: fn, Num: num, Err: ErrSyntax}
+	}
+
+	magnitude, err := parseDigits(num.Integer, num.Flags.HasAny(FlagHex))
+	if err != nil {
+		return 0, &NumError{Func: fn, Num: num, Err: err}
+	}
+
+	neg := num.Flags.HasAll(FlagSign | FlagNeg)
+	signBit := uint64(1) << (numBits - 1)
+	mask := ^uint64(0) >> (64 - numBits)
+
+	var limit uint64
+	switch {
+	case neg:
+		limit = signBit
+	case signed:
+		limit = signBit - 1
+	default:
+		limit = mask
+	}
+	if magnitude > limit {
+		return 0, &NumError{Func: fn, Num: num, Err: ErrRange}
+	}
+
+	if neg {
+		magnitude = -magnitude
+	}
+	return magnitude & mask, nil
+}
+
+func (num Num) Float32Bits() (uint32, error) {
+	u64, err := num.toFloatBits("Float32Bits", float32Format)
+	return uint32(u64), err
+}
+
+func (num Num) Float64Bits() (uint64, error) {
+	return num.toFloatBits("Float64Bits", float64Format)
+}
+
+type floatFormat struct {
+	bitSize      int
+	mantissaBits uint
+	exponentBits uint
+}
+
+var (
+	float32Format = floatFormat{bitSize: 32, mantissaBits: 23, exponentBits: 8}
+	float64Format = floatFormat{bitSize: 64, mantissaBits: 52, exponentBits: 11}
+)
+
+func (ff floatFormat) signBit() uint64 {
+	return uint64(1) << (ff.mantissaBits + ff.exponentBits)
+}
+
+func (ff floatFormat) exponentMask() uint64 {
+	return ((uint64(1) << ff.exponentBits) - 1) << ff.mantissaBits
+}
+
+func (ff floatFormat) mantissaMask() uint64 {
+	return (uint64(1) << ff.mantissaBits) - 1
+}
+
+func (ff floatFormat) canonicalNaN() uint64 {
+	return ff.exponentMask() | (uint64(1) << (ff.mantissaBits - 1))
+}
+
+func (num Num) toFloatBits(fn string, ff floatFormat) (uint64, error) {
+	var sign uint64
+	if num.Flags.HasAll(FlagSign | FlagNeg) {
+		sign = ff.signBit()
+	}
+
+	if num.Flags.HasAny(FlagNaN) {
+		if num.Flags.HasNone(FlagAcanonical) {
+			return sign | ff.canonicalNaN(), nil
+		}
+		payload, err := parseDigits(num.Integer, true)
+		if err == nil && (payload == 0 || payload > ff.mantissaMask()) {
+			err = ErrRange
+		}
+		if err != nil {
+			return 0, &NumError{Func: fn, Num: num, Err: err}
+		}
+		return sign | ff.exponentMask() | payload, nil
+	}
+
+	if num.Flags.HasAny(FlagInf) {
+		return sign | ff.exponentMask(), nil
+	}
+
+	var scratch [64]byte
+	str := scratch[:0]
+	if sign != 0 {
+		str = append(str, '-')
+	}
+	hex := num.Flags.HasAny(FlagHex)
+	if hex {
+		str = append(str, '0', 'x')
+	}
+	str = appendDigits(str, num.Integer)
+	if num.Fraction != "" {
+		str = append(str, '.')
+		str = appendDigits(str, num.Fraction)
+	}
+	if num.Exponent != "" || hex {
+		if hex {
+			str = append(str, 'p')
+		} else {
+			str = append(str, 'e')
+		}
+		if num.Flags.HasAll(FlagExpSign | FlagExpNeg) {
+			str = append(str, '-')
+		}
+		if num.Exponent == "" {
+			str = append(str, '0')
+		}
+		str = appendDigits(str, num.Exponent)
+	}
+
+	f64, err := strconv.ParseFloat(string(str), ff.bitSize)
+	if err != nil {
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			return 0, &NumError{Func: fn, Num: num, Err: ErrRange}
+		}
+		return 0, &NumError{Func: fn, Num: num, Err: ErrSyntax}
+	}
+
+	if ff.bitSize == 32 {
+		return uint64(math.Float32bits(float32(f64))), nil
+	}
+	return math.Float64bits(f64), nil
+}
+
+func appendDigits(out []byte, digits string) []byte {
+	for i := 0; i < len(digits); i++ {
+		if ch := digits[i]; ch != '_' {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+func parseDigits(digits string, hex bool) (uint64, error) {
+	if strings.Trim(digits, "_") == "" {
+		return 0, ErrSyntax
+	}
+
+	base := uint64(10)
+	if hex {
+		base = 16
+	}
+
+	var value uint64
+	for i := 0; i < len(digits); i++ {
+		ch := digits[i]
+		var digit uint64
+		switch {
+		case ch == '_':
+			continue
+		case ch >= '0' && ch <= '9':
+			digit = uint64(ch - '0')
+		case hex && ch >= 'a' && ch <= 'f':
+			digit = uint64(ch-'a') + 10
+		case hex && ch >= 'A' && ch <= 'F':
+			digit = uint64(ch-'A') + 10
+		default:
+			return 0, ErrSyntax
+		}
+
+		hi, lo := bits.Mul64(value, base)
+		if hi != 0 {
+			return 0, ErrRange
+		}
+		var carry uint64
+		value, carry = bits.Add64(lo, digit, 0)
+		if carry != 0 {
+			return 0, ErrRange
+		}
+	}
+	return value, nil
+}
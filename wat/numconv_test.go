@@ -0,0 +1,114 @@
+package wat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNum_ToInteger(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  Num
+		Bits   uint
+		Signed bool
+		Expect uint64
+		Err    error
+	}
+
+	testData := [...]testCase{
+		{"Zero", N(0, "0"), 32, false, 0, nil},
+		{"Dec", N(0, "123"), 32, false, 123, nil},
+		{"Hex", N(FlagHex, "dead_BEEF"), 32, false, 0xdeadbeef, nil},
+		{"MaxU32", N(0, "4294967295"), 32, false, 0xffffffff, nil},
+		{"OverU32", N(0, "4294967296"), 32, false, 0, ErrRange},
+		{"NegOne", N(FlagSign|FlagNeg, "1"), 32, false, 0xffffffff, nil},
+		{"MinS32", N(FlagSign|FlagNeg, "2147483648"), 32, false, 0x80000000, nil},
+		{"UnderS32", N(FlagSign|FlagNeg, "2147483649"), 32, false, 0, ErrRange},
+		{"MaxS32", N(FlagSign, "2147483647"), 32, true, 0x7fffffff, nil},
+		{"OverS32", N(0, "2147483648"), 32, true, 0, ErrRange},
+		{"MaxU64", N(FlagHex, "ffffffffffffffff"), 64, false, 0xffffffffffffffff, nil},
+		{"OverU64", N(FlagHex, "10000000000000000"), 64, false, 0, ErrRange},
+		{"MinS64", N(FlagSign|FlagNeg, "9223372036854775808"), 64, true, 0x8000000000000000, nil},
+		{"NegZero", N(FlagSign|FlagNeg, "0"), 8, false, 0, nil},
+		{"Float", N(FlagFloat, "1", "5"), 32, false, 0, ErrSyntax},
+		{"NaN", N(FlagFloat | FlagNaN), 32, false, 0, ErrSyntax},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual, err := row.Input.ToInteger(row.Bits, row.Signed)
+			if !errors.Is(err, row.Err) {
+				t.Errorf("expect error %v, got %v", row.Err, err)
+			}
+			if err != nil {
+				var ne *NumError
+				if !errors.As(err, &ne) || ne.Num != row.Input {
+					t.Errorf("expect *wat.NumError for %v, got %#v", row.Input, err)
+				}
+				return
+			}
+			if actual != row.Expect {
+				t.Errorf("expect %#x, got %#x", row.Expect, actual)
+			}
+		})
+	}
+
+	if v, err := N(FlagSign|FlagNeg, "1").Int32(); v != -1 || err != nil {
+		t.Errorf("Int32: expect -1, got %d, %v", v, err)
+	}
+	if v, err := N(FlagSign|FlagNeg, "1").Uint64(); v != 0xffffffffffffffff || err != nil {
+		t.Errorf("Uint64: expect 0xffffffffffffffff, got %#x, %v", v, err)
+	}
+}
+
+func TestNum_FloatBits(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Input    Num
+		Expect32 uint32
+		Err32    error
+		Expect64 uint64
+		Err64    error
+	}
+
+	testData := [...]testCase{
+		{"Zero", N(0, "0"), 0x00000000, nil, 0x0000000000000000, nil},
+		{"NegZero", N(FlagFloat|FlagSign|FlagNeg, "0", "0"), 0x80000000, nil, 0x8000000000000000, nil},
+		{"One", N(0, "1"), 0x3f800000, nil, 0x3ff0000000000000, nil},
+		{"Tenth", N(FlagFloat, "0", "1"), 0x3dcccccd, nil, 0x3fb999999999999a, nil},
+		{"Exp", N(FlagFloat|FlagExpSign|FlagExpNeg, "1", "5", "3"), 0x3ac49ba6, nil, 0x3f589374bc6a7efa, nil},
+		{"Hex", N(FlagFloat|FlagHex, "1", "8", "3"), 0x41400000, nil, 0x4028000000000000, nil},
+		{"HexNoExp", N(FlagFloat|FlagHex, "a", "8"), 0x41280000, nil, 0x4025000000000000, nil},
+		{"HexRoundEven", N(FlagFloat|FlagHex, "1", "000001"), 0x3f800000, nil, 0x3ff0000010000000, nil},
+		{"HexRoundUp", N(FlagFloat|FlagHex, "1", "0000018"), 0x3f800001, nil, 0x3ff0000018000000, nil},
+		{"MaxF32", N(FlagFloat|FlagHex, "1", "fffffe", "127"), 0x7f7fffff, nil, 0x47efffffe0000000, nil},
+		{"OverF32", N(FlagFloat|FlagHex, "1", "ffffff", "127"), 0, ErrRange, 0x47efffffe0000000 | 0x10000000, nil},
+		{"OverF64", N(FlagFloat, "1", "0", "400"), 0, ErrRange, 0, ErrRange},
+		{"Denormal", N(FlagFloat|FlagHex|FlagExpSign|FlagExpNeg, "1", "0", "149"), 0x00000001, nil, 0x36a0000000000000, nil},
+		{"Inf", N(FlagFloat | FlagInf), 0x7f800000, nil, 0x7ff0000000000000, nil},
+		{"NegInf", N(FlagFloat | FlagInf | FlagSign | FlagNeg), 0xff800000, nil, 0xfff0000000000000, nil},
+		{"NaN", N(FlagFloat | FlagNaN), 0x7fc00000, nil, 0x7ff8000000000000, nil},
+		{"NegNaN", N(FlagFloat | FlagNaN | FlagSign | FlagNeg), 0xffc00000, nil, 0xfff8000000000000, nil},
+		{"Payload", N(FlagFloat|FlagNaN|FlagAcanonical|FlagHex, "1"), 0x7f800001, nil, 0x7ff0000000000001, nil},
+		{"BigPayload", N(FlagFloat|FlagNaN|FlagAcanonical|FlagHex, "80_0000"), 0, ErrRange, 0x7ff0000000800000, nil},
+		{"ZeroPayload", N(FlagFloat|FlagNaN|FlagAcanonical|FlagHex, "0"), 0, ErrRange, 0, ErrRange},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			u32, err := row.Input.Float32Bits()
+			if !errors.Is(err, row.Err32) {
+				t.Errorf("Float32Bits: expect error %v, got %v", row.Err32, err)
+			} else if err == nil && u32 != row.Expect32 {
+				t.Errorf("Float32Bits: expect %#08x, got %#08x", row.Expect32, u32)
+			}
+
+			u64, err := row.Input.Float64Bits()
+			if !errors.Is(err, row.Err64) {
+				t.Errorf("Float64Bits: expect error %v, got %v", row.Err64, err)
+			} else if err == nil && u64 != row.Expect64 {
+				t.Errorf("Float64Bits: expect %#016x, got %#016x", row.Expect64, u64)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package wat
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	ErrRange  = errors.New("value out of range")
+	ErrSyntax = errors.New("invalid syntax")
+)
+
+type NumError struct {
+	Func string
+	Num  Num
+	Err  error
+}
+
+func (err *NumError) Error() string {
+	return "wat.Num." + err.Func + ": converting " + strconv.Quote(err.Num.String()) + ": " + err.Err.Error()
+}
+
+func (err *NumError) Unwrap() error {
+	return err.Err
+}
+
+var _ error = (*NumError)(nil)
@@ -0,0 +1,159 @@
+package wat
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+func NumFromInt64(value int64) Num {
+	if value < 0 {
+		num := NumFromUint64(-uint64(value))
+		num.Flags |= FlagSign | FlagNeg
+		return num
+	}
+	return NumFromUint64(uint64(value))
+}
+
+func NumFromUint64(value uint64) Num {
+	return Num{Integer: strconv.FormatUint(value, 10)}
+}
+
+func NumFromFloat32(value float32, style FloatStyle) Num {
+	return NumFromFloat32Bits(math.Float32bits(value), style)
+}
+
+func NumFromFloat64(value float64, style FloatStyle) Num {
+	return NumFromFloat64Bits(math.Float64bits(value), style)
+}
+
+// NumFromFloat32Bits returns a Num which spells the given binary32 bit
+// pattern exactly, such that Float32Bits on it (or on the result of
+// lexing its String) yields the same bits, NaN payloads included.
+func NumFromFloat32Bits(value uint32, style FloatStyle) Num {
+	return float32Format.numFromBits(uint64(value), style)
+}
+
+// NumFromFloat64Bits is the binary64 equivalent of NumFromFloat32Bits.
+func NumFromFloat64Bits(value uint64, style FloatStyle) Num {
+	return float64Format.numFromBits(value, style)
+}
+
+func (ff floatFormat) numFromBits(value uint64, style FloatStyle) Num {
+	var sign NumFlags
+	if (value & ff.signBit()) != 0 {
+		sign = FlagSign | FlagNeg
+	}
+
+	exponent := value & ff.exponentMask()
+	mantissa := value & ff.mantissaMask()
+	if exponent == ff.exponentMask() {
+		if mantissa == 0 {
+			return Num{Flags: FlagFloat | FlagInf | sign}
+		}
+		if (exponent | mantissa) == ff.canonicalNaN() {
+			return Num{Flags: FlagFloat | FlagNaN | sign}
+		}
+		return Num{
+			Flags:   FlagFloat | FlagNaN | FlagHex | FlagAcanonical | sign,
+			Integer: strconv.FormatUint(mantissa, 16),
+		}
+	}
+
+	var num Num
+	switch style {
+	case DecimalFloat:
+		num = ff.decimalNum(value &^ ff.signBit())
+	case HexFloat:
+		num = ff.hexNum(exponent>>ff.mantissaBits, mantissa)
+	default:
+		num = ff.decimalNum(value &^ ff.signBit())
+		if alt := ff.hexNum(exponent>>ff.mantissaBits, mantissa); numLen(alt) < numLen(num) {
+			num = alt
+		}
+	}
+	num.Flags |= sign
+	return num
+}
+
+func (ff floatFormat) decimalNum(value uint64) Num {
+	var f64 float64
+	if ff.bitSize == 32 {
+		f64 = float64(math.Float32frombits(uint32(value)))
+	} else {
+		f64 = math.Float64frombits(value)
+	}
+
+	// strconv gives us the shortest digit string that round-trips, in
+	// the form "d.ddde±XX"; all that remains is to place the point.
+	str := strconv.FormatFloat(f64, 'e', -1, ff.bitSize)
+	mant, exp, _ := strings.Cut(str, "e")
+	exp10, _ := strconv.Atoi(exp)
+	digits := strings.Replace(mant, ".", "", 1)
+
+	scientific := Num{Flags: FlagFloat, Integer: digits[:1], Fraction: digits[1:]}
+	if exp10 < 0 {
+		scientific.Flags |= FlagExpSign | FlagExpNeg
+		scientific.Exponent = strconv.Itoa(-exp10)
+	} else {
+		scientific.Exponent = strconv.Itoa(exp10)
+	}
+
+	var positional Num
+	point := exp10 + 1
+	switch {
+	case point <= 0:
+		positional = Num{Flags: FlagFloat, Integer: "0", Fraction: strings.Repeat("0", -point) + digits}
+	case point >= len(digits):
+		positional = Num{Integer: digits + strings.Repeat("0", point-len(digits))}
+	default:
+		positional = Num{Flags: FlagFloat, Integer: digits[:point], Fraction: digits[point:]}
+	}
+
+	if numLen(scientific) < numLen(positional) {
+		return scientific
+	}
+	return positional
+}
+
+func (ff floatFormat) hexNum(exponent uint64, mantissa uint64) Num {
+	if exponent == 0 && mantissa == 0 {
+		return Num{Flags: FlagHex, Integer: "0"}
+	}
+
+	// Denormals are renormalized, so that every finite non-zero value is
+	// spelled as 0x1.fffp±N.
+	bias := (int64(1) << (ff.exponentBits - 1)) - 1
+	exp2 := int64(exponent) - bias
+	if exponent == 0 {
+		exp2 = 1 - bias
+		shift := uint(bits.LeadingZeros64(mantissa)) - (63 - ff.mantissaBits)
+		mantissa = (mantissa << shift) & ff.mantissaMask()
+		exp2 -= int64(shift)
+	}
+
+	num := Num{Flags: FlagHex, Integer: "1"}
+	if mantissa != 0 {
+		pad := (4 - ff.mantissaBits%4) % 4
+		width := int(ff.mantissaBits+pad) / 4
+		frac := strconv.FormatUint(mantissa<<pad, 16)
+		frac = strings.Repeat("0", width-len(frac)) + frac
+		num.Flags |= FlagFloat
+		num.Fraction = strings.TrimRight(frac, "0")
+	}
+	if exp2 != 0 {
+		num.Flags |= FlagFloat
+		if exp2 < 0 {
+			num.Flags |= FlagExpSign | FlagExpNeg
+			exp2 = -exp2
+		}
+		num.Exponent = strconv.FormatInt(exp2, 10)
+	}
+	return num
+}
+
+func numLen(num Num) int {
+	var scratch [64]byte
+	return len(num.AppendTo(scratch[:0], false))
+}
@@ -0,0 +1,145 @@
+package wat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNumFromInt64(t *testing.T) {
+	testData := [...]struct {
+		Input  int64
+		Expect string
+	}{
+		{0, "0"},
+		{42, "42"},
+		{-1, "-1"},
+		{math.MaxInt64, "9223372036854775807"},
+		{math.MinInt64, "-9223372036854775808"},
+	}
+	for _, row := range testData {
+		num := NumFromInt64(row.Input)
+		if actual := num.String(); actual != row.Expect {
+			t.Errorf("NumFromInt64(%d): expect %q, got %q", row.Input, row.Expect, actual)
+		}
+		if actual, err := num.Int64(); actual != row.Input || err != nil {
+			t.Errorf("NumFromInt64(%d).Int64(): got %d, %v", row.Input, actual, err)
+		}
+	}
+
+	if actual := NumFromUint64(math.MaxUint64).String(); actual != "18446744073709551615" {
+		t.Errorf("NumFromUint64: got %q", actual)
+	}
+}
+
+func TestNumFromFloat(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Bits   uint64
+		Is64   bool
+		Style  FloatStyle
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{"Zero", 0x00000000, false, ShortestFloat, "0"},
+		{"NegZero", 0x80000000, false, ShortestFloat, "-0"},
+		{"One", 0x3f800000, false, ShortestFloat, "1"},
+		{"Hundred", 0x42c80000, false, ShortestFloat, "100"},
+		{"Million", 0x49742400, false, ShortestFloat, "1e6"},
+		{"Tenth", 0x3dcccccd, false, ShortestFloat, "0.1"},
+		{"Small", 0x358637bd, false, ShortestFloat, "1e-6"},
+		{"Denormal", 0x00000001, false, ShortestFloat, "1e-45"},
+		{"HexOne", 0x3f800000, false, HexFloat, "0x1"},
+		{"HexTenth", 0x3dcccccd, false, HexFloat, "0x1.99999ap-4"},
+		{"HexDenormal", 0x00000001, false, HexFloat, "0x1p-149"},
+		{"HexMax", 0x7f7fffff, false, HexFloat, "0x1.fffffep127"},
+		{"ShortestHex", 0x3ff0000000000001, true, ShortestFloat, "0x1.0000000000001"},
+		{"DecPi", 0xc0490fdb, false, DecimalFloat, "-3.1415927"},
+		{"DecNext", 0x3f800001, false, DecimalFloat, "1.0000001"},
+		{"Inf", 0x7f800000, false, ShortestFloat, "inf"},
+		{"NegInf", 0xff800000, false, HexFloat, "-inf"},
+		{"NaN", 0x7fc00000, false, ShortestFloat, "nan"},
+		{"NegNaN", 0xffc00000, false, DecimalFloat, "-nan"},
+		{"Payload", 0x7f800001, false, ShortestFloat, "nan:0x1"},
+		{"Signaling", 0xffa00000, false, ShortestFloat, "-nan:0x200000"},
+		{"Zero64", 0x0000000000000000, true, HexFloat, "0x0"},
+		{"Tenth64", 0x3fb999999999999a, true, ShortestFloat, "0.1"},
+		{"HexTenth64", 0x3fb999999999999a, true, HexFloat, "0x1.999999999999ap-4"},
+		{"Denormal64", 0x0000000000000001, true, ShortestFloat, "5e-324"},
+		{"HexDenormal64", 0x0000000000000001, true, HexFloat, "0x1p-1074"},
+		{"Max64", 0x7fefffffffffffff, true, ShortestFloat, "1.7976931348623157e308"},
+		{"NaN64", 0x7ff8000000000000, true, HexFloat, "nan"},
+		{"Payload64", 0xfff4000000000000, true, ShortestFloat, "-nan:0x4000000000000"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var num Num
+			if row.Is64 {
+				num = NumFromFloat64Bits(row.Bits, row.Style)
+			} else {
+				num = NumFromFloat32Bits(uint32(row.Bits), row.Style)
+			}
+			if actual := num.String(); actual != row.Expect {
+				t.Errorf("expect %q, got %q", row.Expect, actual)
+			}
+		})
+	}
+
+	if actual := NumFromFloat64(-0.5, ShortestFloat).String(); actual != "-0.5" {
+		t.Errorf("NumFromFloat64(-0.5): got %q", actual)
+	}
+	if actual := NumFromFloat32(float32(math.Inf(1)), DecimalFloat).String(); actual != "inf" {
+		t.Errorf("NumFromFloat32(+Inf): got %q", actual)
+	}
+}
+
+func TestNumFromFloat_RoundTrip(t *testing.T) {
+	lex := func(str string) Num {
+		t.Helper()
+		lexer := NewLexer([]byte(str))
+		var token Token
+		if lexer.HasNext() {
+			token = lexer.Next()
+		}
+		if token.Type != NumberToken {
+			t.Fatalf("%q: expect NumberToken, got %v", str, token)
+		}
+		return token.Value.(Num)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	styles := [...]FloatStyle{ShortestFloat, DecimalFloat, HexFloat}
+	for i := 0; i < 10000; i++ {
+		u64 := rng.Uint64()
+		u32 := uint32(u64)
+		switch i % 4 {
+		case 1:
+			// denormals
+			u64 &^= 0x7ff0000000000000
+			u32 &^= 0x7f800000
+		case 2:
+			// NaNs
+			u64 |= 0x7ff0000000000000
+			u32 |= 0x7f800000
+		}
+		for _, style := range styles {
+			num := NumFromFloat32Bits(u32, style)
+			if lexed := lex(num.String()); lexed != num {
+				t.Errorf("%#08x: lexing %q: expect %#v, got %#v", u32, num.String(), num, lexed)
+			}
+			if actual, err := num.Float32Bits(); actual != u32 || err != nil {
+				t.Errorf("%#08x: %q: got %#08x, %v", u32, num.String(), actual, err)
+			}
+
+			num = NumFromFloat64Bits(u64, style)
+			if lexed := lex(num.String()); lexed != num {
+				t.Errorf("%#016x: lexing %q: expect %#v, got %#v", u64, num.String(), num, lexed)
+			}
+			if actual, err := num.Float64Bits(); actual != u64 || err != nil {
+				t.Errorf("%#016x: %q: got %#016x, %v", u64, num.String(), actual, err)
+			}
+		}
+	}
+}
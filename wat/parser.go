@@ -1,9 +1,5 @@
 package wat
 
-import (
-	"fmt"
-)
-
 type Parser struct {
 	slabs           []*nodeSlab
 	spaceCache      map[Space]*Node
@@ -13,7 +9,9 @@ type Parser struct {
 	strCache        map[string]*Node
 	keepSpaces      bool
 	keepComments    bool
+	keepAnnotations bool
 	disableCaching  bool
+	recoverErrors   bool
 }
 
 func (parser *Parser) KeepSpaces(value bool) *Parser {
@@ -26,11 +24,21 @@ func (parser *Parser) KeepComments(value bool) *Parser {
 	return parser
 }
 
+func (parser *Parser) KeepAnnotations(value bool) *Parser {
+	parser.keepAnnotations = value
+	return parser
+}
+
 func (parser *Parser) DisableCaching(value bool) *Parser {
 	parser.disableCaching = value
 	return parser
 }
 
+func (parser *Parser) RecoverErrors(value bool) *Parser {
+	parser.recoverErrors = value
+	return parser
+}
+
 func (parser *Parser) Parse(lexer TokenStream) (*Node, error) {
 	if parser == nil {
 		parser = new(Parser)
@@ -50,41 +58,120 @@ func (parser *Parser) Parse(lexer TokenStream) (*Node, error) {
 		return child
 	}
 
+	pop := func(end Position) {
+		stackLen := len(stack)
+		top.Span.End = end
+		stackLen--
+		stack[stackLen] = nil
+		stack = stack[:stackLen]
+		top = root
+		if stackLen > 0 {
+			top = stack[stackLen-1]
+		}
+		list = top.Value.([]*Node)
+	}
+
+	var errs SyntaxErrors
+	recovered := func(err *SyntaxError) bool {
+		if !parser.recoverErrors {
+			return false
+		}
+		errs = append(errs, err)
+		add(parser.Node(ErrorNode, err, err.Span))
+		return true
+	}
+
+	finish := func(end Position) (*Node, error) {
+		for len(stack) > 0 {
+			recovered(&SyntaxError{Code: UnmatchedOpenParenError, Span: top.Span, Rune: '('})
+			pop(end)
+		}
+		root.Span.End = end
+		return root, errs.Err()
+	}
+
+	var skipDepth uint
+	var skipSpan Span
+
 	for lexer.HasNext() {
 		token := lexer.Next()
 		if err := token.Validate(); err != nil {
-			return nil, err
+			err := &SyntaxError{Code: InvalidTokenError, Span: token.Span, Rune: -1, Err: err}
+			if !recovered(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		if skipDepth > 0 {
+			switch token.Type {
+			case OpenParenToken:
+				fallthrough
+			case AnnotationToken:
+				skipDepth++
+				continue
+			case CloseParenToken:
+				skipDepth--
+				continue
+			case AcceptToken:
+				err := &SyntaxError{Code: UnmatchedOpenParenError, Span: skipSpan, Rune: '('}
+				if !recovered(err) {
+					return nil, err
+				}
+				skipDepth = 0
+			case RejectToken:
+				// handled below
+			default:
+				continue
+			}
 		}
 
 		switch token.Type {
 		case AcceptToken:
-			if len(stack) > 0 {
-				return nil, fmt.Errorf("unmatched '(' at %v", token.Span.Begin)
+			if len(stack) > 0 && !parser.recoverErrors {
+				return nil, &SyntaxError{Code: UnmatchedOpenParenError, Span: top.Span, Rune: '('}
 			}
-			root.Span.End = token.Span.End
-			return root, nil
+			return finish(token.Span.End)
 		case RejectToken:
-			return nil, token.Value.(error)
+			err := asSyntaxError(token)
+			if !recovered(err) {
+				return nil, err
+			}
+			if resyncer, ok := lexer.(Resyncer); ok && resyncer.Resync() {
+				continue
+			}
+			return finish(token.Span.End)
 		case OpenParenToken:
 			exprList := make([]*Node, 0, 16)
 			exprNode := add(parser.Node(ExprNode, exprList, token.Span))
 			stack = append(stack, exprNode)
 			list = exprList
 			top = exprNode
+		case AnnotationToken:
+			if !parser.keepAnnotations {
+				skipDepth = 1
+				skipSpan = token.Span
+				continue
+			}
+			nameSpan := token.Span
+			nameSpan.Begin.ByteOffset++
+			nameSpan.Begin.RuneOffset++
+			nameSpan.Begin.Column++
+			exprList := make([]*Node, 0, 16)
+			exprList = append(exprList, parser.Node(KeywordNode, token.Value, nameSpan))
+			exprNode := add(parser.Node(AnnotationNode, exprList, token.Span))
+			stack = append(stack, exprNode)
+			list = exprList
+			top = exprNode
 		case CloseParenToken:
-			stackLen := len(stack)
-			if stackLen < 1 {
-				return nil, fmt.Errorf("unmatched ')' at %v", token.Span.Begin)
-			}
-			top.Span.End = token.Span.End
-			stackLen--
-			stack[stackLen] = nil
-			stack = stack[:stackLen]
-			top = root
-			if stackLen > 0 {
-				top = stack[stackLen-1]
-			}
-			list = top.Value.([]*Node)
+			if len(stack) < 1 {
+				err := &SyntaxError{Code: UnmatchedCloseParenError, Span: token.Span, Rune: ')'}
+				if !recovered(err) {
+					return nil, err
+				}
+				continue
+			}
+			pop(token.Span.End)
 		case SpaceToken:
 			if parser.keepSpaces {
 				add(parser.Node(SpaceNode, token.Value, token.Span))
@@ -95,36 +182,54 @@ func (parser *Parser) Parse(lexer TokenStream) (*Node, error) {
 			}
 		case BlockCommentToken:
 			if parser.keepComments {
-				add(parser.Node(BlockCommentNode, token.Value, token.Span))
+				add(parser.node(BlockCommentNode, token.Value, token.Span, token.Text))
 			}
 		case KeywordToken:
 			add(parser.Node(KeywordNode, token.Value, token.Span))
 		case IdentifierToken:
 			add(parser.Node(IdentifierNode, token.Value, token.Span))
 		case StringToken:
-			add(parser.Node(StringNode, token.Value, token.Span))
+			add(parser.node(StringNode, token.Value, token.Span, token.Text))
 		case NumberToken:
-			add(parser.Node(NumberNode, token.Value, token.Span))
+			add(parser.node(NumberNode, token.Value, token.Span, token.Text))
 		default:
-			return nil, fmt.Errorf("unexpected token %v", token)
+			err := &SyntaxError{Code: UnexpectedTokenError, Span: token.Span, Rune: -1, Detail: token.String()}
+			if !recovered(err) {
+				return nil, err
+			}
 		}
 	}
+	if parser.recoverErrors {
+		return finish(root.Span.End)
+	}
 	panic("unreachable")
 }
 
+func asSyntaxError(token Token) *SyntaxError {
+	err := token.Value.(error)
+	if se, ok := err.(*SyntaxError); ok {
+		return se
+	}
+	return &SyntaxError{Code: UnknownError, Span: token.Span, Rune: -1, Err: err}
+}
+
 func (parser *Parser) Node(tt NodeType, tv any, ts Span) *Node {
+	return parser.node(tt, tv, ts, "")
+}
+
+func (parser *Parser) node(tt NodeType, tv any, ts Span, text string) *Node {
 	if parser == nil {
-		return &Node{Type: tt, Value: tv, Span: ts}
+		return &Node{Type: tt, Value: tv, Span: ts, Text: text}
 	}
 
 	if !parser.disableCaching {
 		switch tt {
 		case SpaceNode:
 			sp := tv.(Space)
-			if node := parser.spaceCache[sp]; node != nil {
+			if node := parser.spaceCache[sp]; node != nil && node.Text == text {
 				return node
 			}
-			node := parser.createNode(tt, tv, ts)
+			node := parser.createNode(tt, tv, ts, text)
 			if parser.spaceCache == nil {
 				parser.spaceCache = make(map[Space]*Node, 16)
 			}
@@ -133,10 +238,10 @@ func (parser *Parser) Node(tt NodeType, tv any, ts Span) *Node {
 
 		case NumberNode:
 			num := tv.(Num)
-			if node := parser.numCache[num]; node != nil {
+			if node := parser.numCache[num]; node != nil && node.Text == text {
 				return node
 			}
-			node := parser.createNode(tt, tv, ts)
+			node := parser.createNode(tt, tv, ts, text)
 			if parser.numCache == nil {
 				parser.numCache = make(map[Num]*Node, 16)
 			}
@@ -145,10 +250,10 @@ func (parser *Parser) Node(tt NodeType, tv any, ts Span) *Node {
 
 		case KeywordNode:
 			str := tv.(string)
-			if node := parser.keywordCache[str]; node != nil {
+			if node := parser.keywordCache[str]; node != nil && node.Text == text {
 				return node
 			}
-			node := parser.createNode(tt, tv, ts)
+			node := parser.createNode(tt, tv, ts, text)
 			if parser.keywordCache == nil {
 				parser.keywordCache = make(map[string]*Node, 16)
 			}
@@ -157,10 +262,10 @@ func (parser *Parser) Node(tt NodeType, tv any, ts Span) *Node {
 
 		case IdentifierNode:
 			str := tv.(string)
-			if node := parser.identifierCache[str]; node != nil {
+			if node := parser.identifierCache[str]; node != nil && node.Text == text {
 				return node
 			}
-			node := parser.createNode(tt, tv, ts)
+			node := parser.createNode(tt, tv, ts, text)
 			if parser.identifierCache == nil {
 				parser.identifierCache = make(map[string]*Node, 16)
 			}
@@ -169,10 +274,10 @@ func (parser *Parser) Node(tt NodeType, tv any, ts Span) *Node {
 
 		case StringNode:
 			str := tv.(string)
-			if node := parser.strCache[str]; node != nil {
+			if node := parser.strCache[str]; node != nil && node.Text == text {
 				return node
 			}
-			node := parser.createNode(tt, tv, ts)
+			node := parser.createNode(tt, tv, ts, text)
 			if parser.strCache == nil {
 				parser.strCache = make(map[string]*Node, 16)
 			}
@@ -181,14 +286,14 @@ func (parser *Parser) Node(tt NodeType, tv any, ts Span) *Node {
 		}
 	}
 
-	return parser.createNode(tt, tv, ts)
+	return parser.createNode(tt, tv, ts, text)
 }
 
-func (parser *Parser) createNode(tt NodeType, tv any, ts Span) *Node {
+func (parser *Parser) createNode(tt NodeType, tv any, ts Span, text string) *Node {
 	i := uint(len(parser.slabs))
 	if i > 0 {
 		slab := parser.slabs[i-1]
-		node := slab.alloc(tt, tv, ts)
+		node := slab.alloc(tt, tv, ts, text)
 		if node != nil {
 			return node
 		}
@@ -196,7 +301,7 @@ func (parser *Parser) createNode(tt NodeType, tv any, ts Span) *Node {
 
 	slab := &nodeSlab{}
 	parser.slabs = append(parser.slabs, slab)
-	return slab.alloc(tt, tv, ts)
+	return slab.alloc(tt, tv, ts, text)
 }
 
 type nodeSlab struct {
@@ -204,13 +309,13 @@ type nodeSlab struct {
 	used uint
 }
 
-func (slab *nodeSlab) alloc(tt NodeType, tv any, ts Span) *Node {
+func (slab *nodeSlab) alloc(tt NodeType, tv any, ts Span, text string) *Node {
 	i := slab.used
 	if i >= uint(len(slab.list)) {
 		return nil
 	}
 	node := &slab.list[i]
-	*node = Node{Type: tt, Value: tv, Span: ts}
+	*node = Node{Type: tt, Value: tv, Span: ts, Text: text}
 	i++
 	slab.used = i
 	return node
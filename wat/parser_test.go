@@ -1,6 +1,7 @@
 package wat
 
 import (
+	"errors"
 	"io/fs"
 	"path"
 	"testing"
@@ -26,6 +27,17 @@ func NVN(bits NumFlags, v ...string) *Node {
 	return &Node{Type: NumberNode, Value: N(bits, v...)}
 }
 
+func AN(name string, v ...*Node) *Node {
+	list := make([]*Node, 0, len(v)+1)
+	list = append(list, KN(name))
+	list = append(list, v...)
+	return &Node{Type: AnnotationNode, Value: list}
+}
+
+func EN(code ErrorCode) *Node {
+	return &Node{Type: ErrorNode, Value: &SyntaxError{Code: code}}
+}
+
 func TestParse(t *testing.T) {
 	type TestCase struct {
 		Name   string
@@ -115,6 +127,7 @@ func TestParse(t *testing.T) {
 				SVN("ESC[0m: \x1b[0m"),
 				SVN("smiley: \u263a\ufe0f"),
 				SVN("smiley: \u263a\ufe0f"),
+				SVN("bytes: \xff\x00\xc3\xa9\x80"),
 			),
 		},
 		{
@@ -183,3 +196,116 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_RecoverErrors(t *testing.T) {
+	input := "(module\n  (func $a #bad)\n  (func $b \xff))\n  (func $c)))\n  (memory 1\n"
+
+	expect := XN(
+		XN(
+			KN("module"),
+			XN(KN("func"), IN("$a"), EN(UnexpectedCharError)),
+			XN(KN("func"), IN("$b"), EN(InvalidUTF8Error)),
+		),
+		XN(KN("func"), IN("$c")),
+		EN(UnmatchedCloseParenError),
+		EN(UnmatchedCloseParenError),
+		XN(KN("memory"), NVN(0, "1"), EN(UnmatchedOpenParenError)),
+	)
+
+	expectErrors := []ErrorCode{
+		UnexpectedCharError,
+		InvalidUTF8Error,
+		UnmatchedCloseParenError,
+		UnmatchedCloseParenError,
+		UnmatchedOpenParenError,
+	}
+
+	var p Parser
+	p.RecoverErrors(true)
+	node, err := p.Parse(NewLexer([]byte(input)))
+	if !expect.Equals(node) {
+		t.Errorf("parse gave wrong result:\n\texpect: %v\n\tactual: %v", expect, node)
+	}
+
+	var list SyntaxErrors
+	if !errors.As(err, &list) {
+		t.Fatalf("expected wat.SyntaxErrors, got %T: %v", err, err)
+	}
+	if len(list) != len(expectErrors) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expectErrors), len(list), list)
+	}
+	for i, code := range expectErrors {
+		if list[i].Code != code {
+			t.Errorf("error #%d: expect %v, got %v", i, code, list[i])
+		}
+	}
+
+	if str := list[len(list)-1].Span.Begin.String(); str != "L:5 C:3 @ 56" {
+		t.Errorf("unmatched '(' reported at wrong position %s", str)
+	}
+}
+
+func TestParse_Annotations(t *testing.T) {
+	type TestCase struct {
+		Name   string
+		Keep   bool
+		Expect *Node
+	}
+
+	testCases := [...]TestCase{
+		{
+			Name: "Keep",
+			Keep: true,
+			Expect: XN(
+				XN(
+					KN("module"),
+					AN("@custom", SVN("name"), XN(KN("after"), KN("function")), SVN("\x00\x01")),
+					XN(KN("func"), IN("$f"), AN("@metadata.code.branch_hint", SVN("\x01")), KN("nop")),
+					AN("@producers", XN(KN("language"), SVN("wat"), SVN("1.0"))),
+				),
+			),
+		},
+		{
+			Name: "Drop",
+			Keep: false,
+			Expect: XN(
+				XN(
+					KN("module"),
+					XN(KN("func"), IN("$f"), KN("nop")),
+				),
+			),
+		},
+	}
+
+	raw, err := fs.ReadFile(testDataFS, "testdata/annotations.wat")
+	if err != nil {
+		t.Fatalf("failed to read annotations.wat: %v", err)
+	}
+
+	for _, row := range testCases {
+		t.Run(row.Name, func(t *testing.T) {
+			var p Parser
+			p.KeepAnnotations(row.Keep)
+			node, err := p.Parse(NewLexer(raw))
+			if err != nil {
+				t.Errorf("parse failed: %v", err)
+			}
+			if !row.Expect.Equals(node) {
+				t.Errorf("parse gave wrong result:\n\texpect: %v\n\tactual: %v", row.Expect, node)
+			}
+			if row.Keep {
+				annotation := node.Value.([]*Node)[0].Value.([]*Node)[1]
+				if name := annotation.AnnotationName(); name != "custom" {
+					t.Errorf("AnnotationName: expect %q, got %q", "custom", name)
+				}
+			}
+		})
+	}
+
+	var p Parser
+	_, err = p.Parse(NewLexer([]byte("(module (@custom (a b)")))
+	var se *SyntaxError
+	if !errors.As(err, &se) || se.Code != UnmatchedOpenParenError || se.Span.Begin.ByteOffset != 8 {
+		t.Errorf("expected unmatched '(' at offset 8 for dropped annotation, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package wat
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func (node *Node) AppendSource(out []byte) []byte {
+	if node == nil {
+		return out
+	}
+
+	switch node.Type {
+	case ExprNode:
+		fallthrough
+	case AnnotationNode:
+		out = append(out, '(')
+		out = appendSourceList(out, node.Value.([]*Node), true)
+		out = append(out, ')')
+		return out
+
+	case SpaceNode:
+		sp := node.Value.(Space)
+		text := sp.Type.Text()
+		for i := uint(0); i < sp.Count; i++ {
+			out = append(out, text...)
+		}
+		return out
+
+	case LineCommentNode:
+		out = append(out, ';', ';')
+		out = append(out, node.Value.(string)...)
+		return out
+
+	case BlockCommentNode:
+		if node.Text != "" {
+			return append(out, node.Text...)
+		}
+		out = append(out, '(', ';')
+		out = append(out, strings.Join(node.Value.([]string), "\n")...)
+		out = append(out, ';', ')')
+		return out
+
+	case KeywordNode:
+		fallthrough
+	case IdentifierNode:
+		return append(out, node.Value.(string)...)
+
+	case StringNode:
+		if node.Text != "" {
+			return append(out, node.Text...)
+		}
+		return appendQuotedString(out, node.Value.(string))
+
+	case NumberNode:
+		if node.Text != "" {
+			return append(out, node.Text...)
+		}
+		return node.Value.(Num).AppendTo(out, false)
+
+	default:
+		return out
+	}
+}
+
+func (node *Node) WriteTo(w io.Writer) (int64, error) {
+	var scratch [1024]byte
+	n, err := w.Write(node.AppendSource(scratch[:0]))
+	return int64(n), err
+}
+
+func AppendDocument(out []byte, root *Node) []byte {
+	if root == nil {
+		return out
+	}
+	if root.Type != ExprNode {
+		return root.AppendSource(out)
+	}
+	return appendSourceList(out, root.Value.([]*Node), false)
+}
+
+func WriteDocument(w io.Writer, root *Node) (int64, error) {
+	var scratch [1024]byte
+	n, err := w.Write(AppendDocument(scratch[:0], root))
+	return int64(n), err
+}
+
+// appendSourceList writes the children of an expression, or of the whole
+// document if closed is false.  A trailing line comment needs a newline
+// only to keep it from swallowing the closing ')'.
+func appendSourceList(out []byte, list []*Node, closed bool) []byte {
+	var prev *Node
+	for _, child := range list {
+		out = appendSeparator(out, prev, child)
+		out = child.AppendSource(out)
+		prev = child
+	}
+	if closed && prev != nil && prev.Type == LineCommentNode {
+		out = append(out, '\n')
+	}
+	return out
+}
+
+func appendSeparator(out []byte, prev *Node, next *Node) []byte {
+	// Only insert whitespace where the tree lacks it and the output would
+	// otherwise lex differently; a tree parsed with KeepSpaces and
+	// KeepComments always has it already.
+	switch {
+	case prev == nil:
+		return out
+	case prev.Type == LineCommentNode && !isNewlineNode(next):
+		return append(out, '\n')
+	case isAtomNode(prev) && isAtomNode(next):
+		return append(out, ' ')
+	default:
+		return out
+	}
+}
+
+func isAtomNode(node *Node) bool {
+	switch node.Type {
+	case KeywordNode:
+		return true
+	case IdentifierNode:
+		return true
+	case StringNode:
+		return true
+	case NumberNode:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNewlineNode(node *Node) bool {
+	if node.Type != SpaceNode {
+		return false
+	}
+	switch node.Value.(Space).Type {
+	case LF:
+		return true
+	case CR:
+		return true
+	case CRLF:
+		return true
+	default:
+		return false
+	}
+}
+
+func appendQuotedString(out []byte, str string) []byte {
+	const hexDigits = "0123456789abcdef"
+	out = append(out, '"')
+	for len(str) > 0 {
+		ch, size := utf8.DecodeRuneInString(str)
+		switch {
+		case ch == '\t':
+			out = append(out, '\\', 't')
+		case ch == '\n':
+			out = append(out, '\\', 'n')
+		case ch == '\r':
+			out = append(out, '\\', 'r')
+		case ch == '"':
+			out = append(out, '\\', '"')
+		case ch == '\\':
+			out = append(out, '\\', '\\')
+		case size == 1 && ch == utf8.RuneError:
+			fallthrough
+		case !unicode.IsPrint(ch):
+			for i := 0; i < size; i++ {
+				b := str[i]
+				out = append(out, '\\', hexDigits[b>>4], hexDigits[b&0xf])
+			}
+		default:
+			out = append(out, str[:size]...)
+		}
+		str = str[size:]
+	}
+	out = append(out, '"')
+	return out
+}
+
+var _ io.WriterTo = (*Node)(nil)
@@ -0,0 +1,131 @@
+package wat
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"testing"
+)
+
+func TestAppendDocument_Lossless(t *testing.T) {
+	entries, err := fs.ReadDir(testDataFS, "testdata")
+	if err != nil {
+		t.Fatalf("failed to list testdata: %v", err)
+	}
+
+	var p Parser
+	p.KeepSpaces(true).KeepComments(true).KeepAnnotations(true)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		t.Run(name, func(t *testing.T) {
+			testDataPath := path.Join("testdata", name)
+
+			raw, err := fs.ReadFile(testDataFS, testDataPath)
+			if err != nil {
+				t.Errorf("failed to read %q: %v", testDataPath, err)
+				return
+			}
+
+			node, err := p.Parse(NewLexer(raw))
+			if err != nil {
+				t.Errorf("parse failed: %v", err)
+				return
+			}
+
+			var buf bytes.Buffer
+			if _, err := WriteDocument(&buf, node); err != nil {
+				t.Errorf("WriteDocument failed: %v", err)
+			}
+			if actual := buf.Bytes(); !bytes.Equal(raw, actual) {
+				t.Errorf("round trip gave wrong result:\n\texpect: %q\n\tactual: %q", raw, actual)
+			}
+		})
+	}
+}
+
+func TestAppendDocument_RoundTrip(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Input string
+	}
+
+	testData := [...]testCase{
+		{"TrailingComment", "(module) ;; c"},
+		{"TrailingCommentLF", "(module) ;; c\n"},
+		{"NestedComment", "(module ;; c\n)"},
+	}
+
+	var p Parser
+	p.KeepSpaces(true).KeepComments(true).KeepAnnotations(true)
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			node, err := p.Parse(NewLexer([]byte(row.Input)))
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			if actual := string(AppendDocument(nil, node)); actual != row.Input {
+				t.Errorf("round trip gave wrong result:\n\texpect: %q\n\tactual: %q", row.Input, actual)
+			}
+		})
+	}
+}
+
+func TestNode_AppendSource(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  *Node
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Nil",
+			Input:  nil,
+			Expect: ``,
+		},
+		{
+			Name:   "Space",
+			Input:  &Node{Type: SpaceNode, Value: Space{CRLF, 2}},
+			Expect: "\r\n\r\n",
+		},
+		{
+			Name:   "BlockComment",
+			Input:  &Node{Type: BlockCommentNode, Value: L("a", " b")},
+			Expect: "(;a\n b;)",
+		},
+		{
+			Name:   "String",
+			Input:  SVN("tab\t quote\" smiley☺ nul\x00 byte\xff"),
+			Expect: `"tab\t quote\" smiley` + "☺" + ` nul\00 byte\ff"`,
+		},
+		{
+			Name:   "Number",
+			Input:  NVN(FlagFloat|FlagHex|FlagExpSign|FlagExpNeg, "1f", "8", "10"),
+			Expect: `0x1f.8p-10`,
+		},
+		{
+			Name: "Expr",
+			Input: XN(
+				KN("func"),
+				IN("$f"),
+				XN(KN("param"), KN("i32"), KN("i64")),
+				&Node{Type: LineCommentNode, Value: " comment"},
+				XN(KN("result"), KN("i32")),
+				&Node{Type: LineCommentNode, Value: " last"},
+			),
+			Expect: "(func $f(param i32 i64);; comment\n(result i32);; last\n)",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			str := string(row.Input.AppendSource(nil))
+			if str != row.Expect {
+				t.Errorf("AppendSource: wrong output\n\texpect: %q\n\tactual: %q", row.Expect, str)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package wat_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/chronos-tachyon/wasmfile/wasm"
+	"github.com/chronos-tachyon/wasmfile/wast"
+	"github.com/chronos-tachyon/wasmfile/wat"
+)
+
+// errSkipped marks a command that needs an interpreter to check, such as
+// an action or an assertion about the results of one.
+var errSkipped = errors.New("skipped")
+
+var flagSpecSummary = flag.Bool("spec.summary", false, "print the per-file summary of TestSpec even without -v")
+
+// specExpectedFailures lists the commands of the spec scripts that are
+// known to fail, keyed by file and line as in "i32.wast:123", with the
+// reason.  A listed command that passes is reported, so that the list is
+// kept up to date.
+var specExpectedFailures = map[string]string{}
+
+// TestSpec runs .wast scripts from the WebAssembly specification's test
+// suite through the lexer, parser, text lowering, binary decoder and
+// validator.  Modules must decode, validate and survive a round trip
+// through the binary format, except that assert_malformed modules must fail
+// to decode and assert_invalid modules must fail to decode or to validate.
+//
+// The scripts in testdata/spec are unmodified upstream files at the
+// revision recorded in testdata/spec/REVISION; those directly in testdata
+// are abridged excerpts, each of which names the upstream file it comes
+// from.
+func TestSpec(t *testing.T) {
+	var summary []string
+	for _, dir := range [...]string{"testdata", "testdata/spec"} {
+		entries, err := fs.ReadDir(wat.TestDataFS, dir)
+		if err != nil {
+			t.Fatalf("failed to list %s: %v", dir, err)
+		}
+
+		n := len(summary)
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || path.Ext(name) != ".wast" {
+				continue
+			}
+			testDataPath := path.Join(dir, name)
+			t.Run(testDataPath, func(t *testing.T) {
+				summary = append(summary, testDataPath+": "+runSpecScript(t, testDataPath))
+			})
+		}
+		if len(summary) == n {
+			summary = append(summary, dir+": no scripts")
+		}
+	}
+
+	text := "spec summary:\n\t" + strings.Join(summary, "\n\t")
+	if *flagSpecSummary {
+		fmt.Println(text)
+	}
+	t.Log(text)
+}
+
+// runSpecScript checks every command of one script and returns its line of
+// the summary.
+func runSpecScript(t *testing.T, testDataPath string) string {
+	raw, err := fs.ReadFile(wat.TestDataFS, testDataPath)
+	if err != nil {
+		t.Fatalf("failed to read %q: %v", testDataPath, err)
+	}
+
+	script, err := wast.Parse(raw)
+	if err != nil {
+		t.Errorf("parse failed: %v", err)
+		return "parse failed"
+	}
+
+	var passed, failed, expected, skipped int
+	for _, cmd := range script.Commands {
+		key := fmt.Sprintf("%s:%d", path.Base(testDataPath), cmd.Span.Begin.Line+1)
+		reason, listed := specExpectedFailures[key]
+		err := checkSpecCommand(cmd)
+		switch {
+		case err == errSkipped:
+			skipped++
+		case err != nil && listed:
+			expected++
+			t.Logf("%v: %v: expected failure (%s): %v", cmd.Span.Begin, cmd.Type, reason, err)
+		case err != nil:
+			failed++
+			t.Errorf("%v: %v: %v", cmd.Span.Begin, cmd.Type, err)
+		case listed:
+			failed++
+			t.Errorf("%v: %v: passed, but listed as an expected failure (%s)", cmd.Span.Begin, cmd.Type, reason)
+		default:
+			passed++
+		}
+	}
+	return fmt.Sprintf("%d passed, %d failed, %d expected failures, %d skipped", passed, failed, expected, skipped)
+}
+
+func checkSpecCommand(cmd *wast.Command) error {
+	switch cmd.Type {
+	case wast.ModuleCommand:
+		return checkSpecModule(cmd.Module)
+
+	case wast.AssertTrapCommand, wast.AssertUnlinkableCommand, wast.AssertUninstantiableCommand:
+		// These fail at link or run time, so the module must be valid.
+		if cmd.Module == nil {
+			return errSkipped
+		}
+		return checkSpecModule(cmd.Module)
+
+	case wast.AssertMalformedCommand:
+		if _, err := cmd.Module.Decode(); err == nil {
+			return fmt.Errorf("expect malformed module (%q), but it decoded", cmd.Message)
+		}
+		return nil
+
+	case wast.AssertInvalidCommand:
+		module, err := cmd.Module.Decode()
+		if err == nil {
+			err = module.Validate()
+		}
+		if err == nil {
+			return fmt.Errorf("expect invalid module (%q), but it validated", cmd.Message)
+		}
+		return nil
+
+	default:
+		return errSkipped
+	}
+}
+
+// checkSpecModule checks that a module decodes and validates, and that
+// encoding it and decoding the result gives the same encoding.
+func checkSpecModule(m *wast.Module) error {
+	module, err := m.Decode()
+	if err != nil {
+		return err
+	}
+	if err := module.Validate(); err != nil {
+		return err
+	}
+
+	first, err := wasm.Encode(module)
+	if err != nil {
+		return fmt.Errorf("encode failed: %w", err)
+	}
+	decoded, err := wasm.Decode(first)
+	if err != nil {
+		return fmt.Errorf("decode of encoding failed: %w", err)
+	}
+	if err := decoded.Validate(); err != nil {
+		return fmt.Errorf("validate of encoding failed: %w", err)
+	}
+	second, err := wasm.Encode(decoded)
+	if err != nil {
+		return fmt.Errorf("encode of encoding failed: %w", err)
+	}
+	if !bytes.Equal(first, second) {
+		return fmt.Errorf("encoding does not round trip:\n\tfirst:  %x\n\tsecond: %x", first, second)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package wat
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type SyntaxError struct {
+	Code   ErrorCode
+	Span   Span
+	Rune   rune
+	Expect string
+	Detail string
+	Err    error
+}
+
+func (err *SyntaxError) Error() string {
+	var scratch [128]byte
+	return string(err.AppendTo(scratch[:0], false))
+}
+
+func (err *SyntaxError) GoString() string {
+	var scratch [256]byte
+	return string(err.AppendTo(scratch[:0], true))
+}
+
+func (err *SyntaxError) Unwrap() error {
+	return err.Err
+}
+
+func (err *SyntaxError) AppendTo(out []byte, verbose bool) []byte {
+	if verbose {
+		out = append(out, "&wat.SyntaxError{"...)
+		out = err.Code.AppendTo(out, verbose)
+		out = append(out, ", "...)
+		out = err.Span.AppendTo(out, verbose)
+		out = append(out, ", "...)
+		out = strconv.AppendQuoteRune(out, err.Rune)
+		out = append(out, ", "...)
+		out = strconv.AppendQuote(out, err.Expect)
+		out = append(out, ", "...)
+		out = strconv.AppendQuote(out, err.Detail)
+		out = append(out, ", "...)
+		out = appendPretty(out, verbose, err.Err)
+		out = append(out, "}"...)
+		return out
+	}
+
+	out = err.Span.Begin.AppendTo(out, verbose)
+	out = append(out, ": "...)
+	return err.appendMessage(out)
+}
+
+func (err *SyntaxError) Message() string {
+	var scratch [128]byte
+	return string(err.appendMessage(scratch[:0]))
+}
+
+func (err *SyntaxError) appendMessage(out []byte) []byte {
+	out = err.appendCause(out)
+	if err.Expect != "" {
+		out = append(out, ": expect "...)
+		out = append(out, err.Expect...)
+	}
+	return out
+}
+
+func (err *SyntaxError) appendCause(out []byte) []byte {
+	switch err.Code {
+	case UnexpectedCharError:
+		out = append(out, "unexpected character "...)
+		return appendRuneDetail(out, err.Rune)
+
+	case UnexpectedEOFError:
+		return append(out, "unexpected end of input"...)
+
+	case InvalidUTF8Error:
+		out = append(out, "UTF-8 decode error: "...)
+		return append(out, err.Detail...)
+
+	case InvalidCharError:
+		out = append(out, "invalid Unicode character "...)
+		return appendRuneDetail(out, err.Rune)
+
+	case ControlCharError:
+		out = append(out, "unexpected Unicode control character "...)
+		return appendRuneDetail(out, err.Rune)
+
+	case EscapeRangeError:
+		return append(out, err.Detail...)
+
+	case ReadError:
+		out = append(out, "read error"...)
+
+	case InvalidTokenError:
+		out = append(out, "invalid token"...)
+
+	case UnexpectedTokenError:
+		out = append(out, "unexpected token "...)
+		return append(out, err.Detail...)
+
+	case UnmatchedOpenParenError:
+		return append(out, "unmatched '('"...)
+
+	case UnmatchedCloseParenError:
+		return append(out, "unmatched ')'"...)
+
+	case SemanticError:
+		out = append(out, err.Detail...)
+
+	default:
+		out = append(out, "syntax error"...)
+		if err.Detail != "" {
+			out = append(out, ": "...)
+			out = append(out, err.Detail...)
+		}
+	}
+
+	if err.Err != nil {
+		out = append(out, ": "...)
+		out = append(out, err.Err.Error()...)
+	}
+	return out
+}
+
+func appendRuneDetail(out []byte, ch rune) []byte {
+	return fmt.Appendf(out, "%q U+%04x", ch, ch)
+}
+
+var (
+	_ error          = (*SyntaxError)(nil)
+	_ fmt.GoStringer = (*SyntaxError)(nil)
+	_ appenderTo     = (*SyntaxError)(nil)
+)
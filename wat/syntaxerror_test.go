@@ -0,0 +1,118 @@
+package wat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSyntaxError(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Code   ErrorCode
+		Offset uint64
+		Rune   rune
+		Expect string
+		Error  string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "UnexpectedChar",
+			Input:  "(module #)",
+			Code:   UnexpectedCharError,
+			Offset: 8,
+			Rune:   '#',
+			Expect: "start of token",
+			Error:  `L:1 C:9 @ 8: unexpected character '#' U+0023: expect start of token`,
+		},
+		{
+			Name:   "UnterminatedString",
+			Input:  `"abc`,
+			Code:   UnexpectedEOFError,
+			Offset: 4,
+			Rune:   -1,
+			Expect: `string terminator '"'`,
+			Error:  `L:1 C:5 @ 4: unexpected end of input: expect string terminator '"'`,
+		},
+		{
+			Name:   "MissingExponent",
+			Input:  "1.5e)",
+			Code:   UnexpectedCharError,
+			Offset: 4,
+			Rune:   ')',
+			Expect: "at least one exponent digit",
+			Error:  `L:1 C:5 @ 4: unexpected character ')' U+0029: expect at least one exponent digit`,
+		},
+		{
+			Name:   "InvalidUTF8",
+			Input:  "\"\xff\"",
+			Code:   InvalidUTF8Error,
+			Offset: 1,
+			Rune:   0xfffd,
+			Error:  `L:1 C:2 @ 1: UTF-8 decode error: ff 22`,
+		},
+		{
+			Name:   "ControlChar",
+			Input:  "\x00",
+			Code:   ControlCharError,
+			Offset: 0,
+			Rune:   0,
+			Error:  `L:1 C:1 @ 0: unexpected Unicode control character '\x00' U+0000`,
+		},
+		{
+			Name:   "EscapeRange",
+			Input:  `"ab\u{110000}"`,
+			Code:   EscapeRangeError,
+			Offset: 3,
+			Rune:   -1,
+			Error:  `L:1 C:4 @ 3: hex escape \u{110000} encodes an invalid Unicode rune, U+110000`,
+		},
+		{
+			Name:   "UnmatchedOpenParen",
+			Input:  "(module\n  (func)\n  (func\n",
+			Code:   UnmatchedOpenParenError,
+			Offset: 19,
+			Rune:   '(',
+			Error:  `L:3 C:3 @ 19: unmatched '('`,
+		},
+		{
+			Name:   "UnmatchedCloseParen",
+			Input:  "(module))",
+			Code:   UnmatchedCloseParenError,
+			Offset: 8,
+			Rune:   ')',
+			Error:  `L:1 C:9 @ 8: unmatched ')'`,
+		},
+	}
+
+	var p Parser
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			node, err := p.Parse(NewLexer([]byte(row.Input)))
+			if err == nil {
+				t.Fatalf("expected error, got %v", node)
+			}
+
+			var se *SyntaxError
+			if !errors.As(err, &se) {
+				t.Fatalf("expected *wat.SyntaxError, got %T: %v", err, err)
+			}
+			if se.Code != row.Code {
+				t.Errorf("Code: expect %v, got %v", row.Code, se.Code)
+			}
+			if se.Span.Begin.ByteOffset != row.Offset {
+				t.Errorf("Span: expect offset %d, got %v", row.Offset, se.Span)
+			}
+			if se.Rune != row.Rune {
+				t.Errorf("Rune: expect %q, got %q", row.Rune, se.Rune)
+			}
+			if se.Expect != row.Expect {
+				t.Errorf("Expect: expect %q, got %q", row.Expect, se.Expect)
+			}
+			if str := se.Error(); str != row.Error {
+				t.Errorf("Error: wrong output\n\texpect: %s\n\tactual: %s", row.Error, str)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package wat
+
+import (
+	"fmt"
+)
+
+type SyntaxErrors []*SyntaxError
+
+func (list SyntaxErrors) Error() string {
+	switch len(list) {
+	case 0:
+		return "no errors"
+	case 1:
+		return list[0].Error()
+	case 2:
+		return fmt.Sprintf("%s (and 1 more error)", list[0].Error())
+	default:
+		return fmt.Sprintf("%s (and %d more errors)", list[0].Error(), len(list)-1)
+	}
+}
+
+func (list SyntaxErrors) Err() error {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+var _ error = SyntaxErrors(nil)
@@ -0,0 +1,4 @@
+(module
+  (@custom "name" (after function) "\00\01")
+  (func $f (@metadata.code.branch_hint "\01") nop)
+  (@producers (language "wat" "1.0")))
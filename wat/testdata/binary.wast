@@ -0,0 +1,102 @@
+;; Abridged excerpt of test/core/binary.wast from the WebAssembly specification
+;; repository, https://github.com/WebAssembly/spec, which is licensed under
+;; the Apache License, Version 2.0.  It holds a selection of the upstream
+;; commands, some of them adapted, and is not a copy of any one upstream
+;; revision; passing it does not show conformance with the full file.
+
+(module binary "\00asm" "\01\00\00\00")
+(module binary "\00asm\01\00\00\00")
+(module $M1 binary "\00asm" "\01\00\00\00")
+(module $M2 binary "\00asm" "\01\00\00\00")
+
+(assert_malformed (module binary "") "unexpected end")
+(assert_malformed (module binary "\01") "unexpected end")
+(assert_malformed (module binary "\00as") "unexpected end")
+(assert_malformed (module binary "asm\00") "magic header not detected")
+(assert_malformed (module binary "msa\00") "magic header not detected")
+(assert_malformed (module binary "msa\00\01\00\00\00") "magic header not detected")
+(assert_malformed (module binary "\00ASM\01\00\00\00") "magic header not detected")
+
+(assert_malformed (module binary "\00asm") "unexpected end")
+(assert_malformed (module binary "\00asm\01") "unexpected end")
+(assert_malformed (module binary "\00asm\01\00\00") "unexpected end")
+(assert_malformed (module binary "\00asm\00\00\00\00") "unknown binary version")
+(assert_malformed (module binary "\00asm\0d\00\00\00") "unknown binary version")
+(assert_malformed (module binary "\00asm\00\00\01\00") "unknown binary version")
+
+;; Invalid section id.
+(assert_malformed (module binary "\00asm" "\01\00\00\00" "\0e\01\00") "malformed section id")
+(assert_malformed (module binary "\00asm" "\01\00\00\00" "\7f\01\00") "malformed section id")
+(assert_malformed (module binary "\00asm" "\01\00\00\00" "\80\01\00") "malformed section id")
+
+;; Custom sections.
+(module binary
+  "\00asm" "\01\00\00\00"
+  "\00\24\10" "a custom section" "this is the payload"
+)
+(module binary
+  "\00asm" "\01\00\00\00"
+  "\00\01\00"                             ;; empty name, no payload
+)
+(assert_malformed
+  (module binary
+    "\00asm" "\01\00\00\00"
+    "\00\00"                              ;; custom section without a name
+  )
+  "unexpected end"
+)
+
+;; Functions.
+(module binary
+  "\00asm" "\01\00\00\00"
+  "\01\04\01\60\00\00"                    ;; Type section
+  "\03\02\01\00"                          ;; Function section
+  "\0a\04\01\02\00\0b"                    ;; Code section
+)
+
+;; Memory limits with a u32 that is one byte too long.
+(assert_malformed
+  (module binary
+    "\00asm" "\01\00\00\00"
+    "\05\07\01"                           ;; Memory section with 1 entry
+    "\00\82\80\80\80\80\00"               ;; no max, minimum 2 with one byte too many
+  )
+  "integer representation too long"
+)
+
+;; Function and code sections disagree on the number of functions.
+(assert_malformed
+  (module binary
+    "\00asm" "\01\00\00\00"
+    "\01\04\01\60\00\00"                  ;; Type section
+    "\03\02\01\00"                        ;; Function section with 1 function
+  )
+  "function and code section have inconsistent lengths"
+)
+(assert_malformed
+  (module binary
+    "\00asm" "\01\00\00\00"
+    "\0a\04\01\02\00\0b"                  ;; Code section with 1 function
+  )
+  "function and code section have inconsistent lengths"
+)
+
+;; The data count section does not match the data section.
+(assert_malformed
+  (module binary
+    "\00asm" "\01\00\00\00"
+    "\05\03\01\00\01"                     ;; Memory section
+    "\0c\01\01"                           ;; Data count section with value 1
+  )
+  "data count and data section have inconsistent lengths"
+)
+
+;; Sections out of order.
+(assert_malformed
+  (module binary
+    "\00asm" "\01\00\00\00"
+    "\03\01\00"                           ;; Function section
+    "\01\01\00"                           ;; Type section
+  )
+  "unexpected content after last section"
+)
@@ -0,0 +1,147 @@
+;; Abridged excerpt of test/core/bulk.wast from the WebAssembly specification
+;; repository, https://github.com/WebAssembly/spec, which is licensed under
+;; the Apache License, Version 2.0.  It holds a selection of the upstream
+;; commands, some of them adapted, and is not a copy of any one upstream
+;; revision; passing it does not show conformance with the full file.
+
+;; segment syntax
+(module
+  (memory 1)
+  (data "foo"))
+
+(module
+  (table 3 funcref)
+  (elem funcref (ref.func 0) (ref.null func) (ref.func 1))
+  (func)
+  (func))
+
+;; memory.fill
+(module
+  (memory 1)
+
+  (func (export "fill") (param i32 i32 i32)
+    (memory.fill
+      (local.get 0)
+      (local.get 1)
+      (local.get 2)))
+
+  (func (export "load8_u") (param i32) (result i32)
+    (i32.load8_u (local.get 0)))
+)
+
+(invoke "fill" (i32.const 1) (i32.const 0xff) (i32.const 3))
+(assert_return (invoke "load8_u" (i32.const 0)) (i32.const 0))
+(assert_return (invoke "load8_u" (i32.const 1)) (i32.const 0xff))
+(assert_trap (invoke "fill" (i32.const 0x10000) (i32.const 0) (i32.const 1))
+    "out of bounds memory access")
+
+;; memory.copy
+(module
+  (memory (data "\aa\bb\cc\dd"))
+
+  (func (export "copy") (param i32 i32 i32)
+    (memory.copy
+      (local.get 0)
+      (local.get 1)
+      (local.get 2)))
+
+  (func (export "load8_u") (param i32) (result i32)
+    (i32.load8_u (local.get 0)))
+)
+
+(invoke "copy" (i32.const 10) (i32.const 0) (i32.const 4))
+(assert_return (invoke "load8_u" (i32.const 10)) (i32.const 0xaa))
+
+;; memory.init
+(module
+  (memory 1)
+  (data "\aa\bb\cc\dd")
+
+  (func (export "init") (param i32 i32 i32)
+    (memory.init 0
+      (local.get 0)
+      (local.get 1)
+      (local.get 2)))
+
+  (func (export "load8_u") (param i32) (result i32)
+    (i32.load8_u (local.get 0)))
+)
+
+(invoke "init" (i32.const 0) (i32.const 1) (i32.const 2))
+(assert_return (invoke "load8_u" (i32.const 0)) (i32.const 0xbb))
+
+;; data.drop
+(module
+  (memory 1)
+  (data $p "x")
+  (data $a (memory 0) (i32.const 0) "x")
+
+  (func (export "drop_passive") (data.drop $p))
+  (func (export "init_passive") (param $len i32)
+    (memory.init $p (i32.const 0) (i32.const 0) (local.get $len)))
+
+  (func (export "drop_active") (data.drop $a))
+  (func (export "init_active") (param $len i32)
+    (memory.init $a (i32.const 0) (i32.const 0) (local.get $len)))
+)
+
+(invoke "init_passive" (i32.const 1))
+(invoke "drop_passive")
+(assert_trap (invoke "init_passive" (i32.const 1)) "out of bounds memory access")
+
+;; table.init
+(module
+  (table 3 funcref)
+  (elem funcref
+    (ref.func $zero) (ref.func $one) (ref.func $zero) (ref.func $one))
+
+  (func $zero (result i32) (i32.const 0))
+  (func $one (result i32) (i32.const 1))
+
+  (func (export "init") (param i32 i32 i32)
+    (table.init 0
+      (local.get 0)
+      (local.get 1)
+      (local.get 2)))
+
+  (func (export "call") (param i32) (result i32)
+    (call_indirect (result i32)
+      (local.get 0)))
+)
+
+(assert_trap (invoke "init" (i32.const 2) (i32.const 0) (i32.const 2))
+    "out of bounds table access")
+(assert_trap (invoke "call" (i32.const 2))
+    "uninitialized element 2")
+
+;; table.copy
+(module
+  (table 10 funcref)
+  (elem (i32.const 0) $zero $one $two)
+  (func $zero (result i32) (i32.const 0))
+  (func $one (result i32) (i32.const 1))
+  (func $two (result i32) (i32.const 2))
+
+  (func (export "copy") (param i32 i32 i32)
+    (table.copy
+      (local.get 0)
+      (local.get 1)
+      (local.get 2)))
+
+  (func (export "call") (param i32) (result i32)
+    (call_indirect (result i32)
+      (local.get 0)))
+)
+
+(invoke "copy" (i32.const 3) (i32.const 0) (i32.const 3))
+(assert_return (invoke "call" (i32.const 3)) (i32.const 0))
+
+(assert_invalid
+  (module (func (data.drop 0)))
+  "unknown data segment")
+(assert_invalid
+  (module (func (elem.drop 0)))
+  "unknown elem segment 0")
+(assert_invalid
+  (module (memory 1) (func (memory.init 1 (i32.const 0) (i32.const 0) (i32.const 0))))
+  "unknown data segment 1")
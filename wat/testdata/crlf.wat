@@ -0,0 +1,10 @@
+(module $m
+  (; block comment
+     (; nested ;)
+     spanning lines ;)
+  (data (i32.const 1_024) "\t\u{263a}\41" "caf\c3\a9")
+  (global f64 (f64.const 0x1_F.8p-1_0))
+  (global f32 (f32.const -nan:0x7F_FFFF)) ;; trailing
+
+
+)
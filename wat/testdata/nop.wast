@@ -0,0 +1,110 @@
+;; Abridged excerpt of test/core/nop.wast from the WebAssembly specification
+;; repository, https://github.com/WebAssembly/spec, which is licensed under
+;; the Apache License, Version 2.0.  It holds a selection of the upstream
+;; commands, some of them adapted, and is not a copy of any one upstream
+;; revision; passing it does not show conformance with the full file.
+
+;; Test `nop` operator.
+
+(module
+  ;; Auxiliary definitions
+  (func $dummy)
+  (func $3-ary (param i32 i32 i32) (result i32)
+    local.get 0 local.get 1 local.get 2 i32.sub i32.add
+  )
+  (memory 1)
+
+  (func $f32-id (param f32) (result f32) (local.get 0))
+  (func (export "as-func-first") (result i32)
+    (nop) (i32.const 1)
+  )
+  (func (export "as-func-mid") (result i32)
+    (call $dummy) (nop) (i32.const 2)
+  )
+  (func (export "as-func-last") (result i32)
+    (call $dummy) (i32.const 3) (nop)
+  )
+  (func (export "as-func-everywhere") (result i32)
+    (nop) (nop) (call $dummy) (nop) (i32.const 4) (nop) (nop)
+  )
+
+  (func (export "as-drop-first") (param i32)
+    (nop) (local.get 0) (drop)
+  )
+  (func (export "as-drop-last") (param i32)
+    (local.get 0) (nop) (drop)
+  )
+
+  (func (export "as-select-mid1") (param i32) (result i32)
+    (local.get 0) (nop) (local.get 0) (local.get 0) (select)
+  )
+
+  (func (export "as-block-first") (result i32)
+    (block (result i32) (nop) (i32.const 2))
+  )
+  (func (export "as-loop-mid") (result i32)
+    (loop (result i32) (call $dummy) (nop) (i32.const 2))
+  )
+
+  (func (export "as-if-condition") (param i32)
+    (local.get 0) (nop) (if (then (call $dummy)) (else (call $dummy)))
+  )
+  (func (export "as-if-then") (param i32)
+    (if (local.get 0) (then (nop)) (else (call $dummy)))
+  )
+
+  (func (export "as-br_if-last") (param i32) (result i32)
+    (block (result i32) (local.get 0) (local.get 0) (nop) (br_if 0))
+  )
+  (func (export "as-br_table-mid") (param i32) (result i32)
+    (block (result i32) (local.get 0) (nop) (local.get 0) (br_table 0 0))
+  )
+
+  (func (export "as-call-mid1") (param i32 i32 i32) (result i32)
+    (local.get 0) (nop) (local.get 1) (local.get 2) (call $3-ary)
+  )
+
+  (func (export "as-unary-last") (param i32) (result i32)
+    (local.get 0) (nop) (i32.ctz)
+  )
+  (func (export "as-binary-mid") (param i32) (result i32)
+    (local.get 0) (nop) (local.get 0) (i32.add)
+  )
+  (func (export "as-compare-first") (param i32) (result i32)
+    (nop) (local.get 0) (local.get 0) (i32.ne)
+  )
+
+  (func (export "as-memory.grow-last") (param i32) (result i32)
+    (local.get 0) (nop) (memory.grow)
+  )
+)
+
+(assert_return (invoke "as-func-first") (i32.const 1))
+(assert_return (invoke "as-func-mid") (i32.const 2))
+(assert_return (invoke "as-func-last") (i32.const 3))
+(assert_return (invoke "as-func-everywhere") (i32.const 4))
+(assert_return (invoke "as-drop-first" (i32.const 0)))
+(assert_return (invoke "as-select-mid1" (i32.const 3)) (i32.const 3))
+(assert_return (invoke "as-block-first") (i32.const 2))
+(assert_return (invoke "as-loop-mid") (i32.const 2))
+(assert_return (invoke "as-br_if-last" (i32.const 3)) (i32.const 3))
+(assert_return (invoke "as-call-mid1" (i32.const 3) (i32.const 1) (i32.const 2)) (i32.const 2))
+(assert_return (invoke "as-unary-last" (i32.const 10)) (i32.const 1))
+(assert_return (invoke "as-memory.grow-last" (i32.const 2)) (i32.const 1))
+
+(assert_invalid
+  (module (func $type-i32 (result i32) (nop)))
+  "type mismatch"
+)
+(assert_invalid
+  (module (func $type-i64 (result i64) (nop)))
+  "type mismatch"
+)
+(assert_invalid
+  (module (func $type-f32 (result f32) (nop)))
+  "type mismatch"
+)
+(assert_invalid
+  (module (func $type-f64 (result f64) (nop)))
+  "type mismatch"
+)
@@ -0,0 +1,74 @@
+;; Abridged excerpt of test/core/simd/simd_const.wast from the WebAssembly specification
+;; repository, https://github.com/WebAssembly/spec, which is licensed under
+;; the Apache License, Version 2.0.  It holds a selection of the upstream
+;; commands, some of them adapted, and is not a copy of any one upstream
+;; revision; passing it does not show conformance with the full file.
+
+(module (func (v128.const i8x16 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF) drop))
+(module (func (v128.const i8x16 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80) drop))
+(module (func (v128.const i16x8 0xFFFF 0xFFFF 0xFFFF 0xFFFF 0xFFFF 0xFFFF 0xFFFF 0xFFFF) drop))
+(module (func (v128.const i32x4 0xffffffff 0xffffffff 0xffffffff 0xffffffff) drop))
+(module (func (v128.const i32x4 -0x80000000 -0x80000000 -0x80000000 -0x80000000) drop))
+(module (func (v128.const i64x2 0xffffffffffffffff 0xffffffffffffffff) drop))
+(module (func (v128.const f32x4 0x1p127 0x1p127 0x1p127 0x1p127) drop))
+(module (func (v128.const f32x4 nan nan nan nan) drop))
+(module (func (v128.const f32x4 inf -inf nan:0x1 -nan:0x7fffff) drop))
+(module (func (v128.const f64x2 0x1p1023 -0x1p1023) drop))
+(module (func (v128.const f64x2 1e308 -1e308) drop))
+
+;; Non-splat cases
+
+(module (func (v128.const i8x16 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF 0xFF
+                                -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80 -0x80) drop))
+(module (func (v128.const i16x8 0xFF 0xFF 0xFF 0xFF -0x8000 -0x8000 -0x8000 -0x8000) drop))
+(module (func (v128.const i32x4 0xffffffff -0x80000000 0xffffffff -0x80000000) drop))
+
+;; Constant out of range
+
+(assert_malformed
+  (module quote "(func (v128.const i8x16 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100 0x100) drop)")
+  "constant out of range"
+)
+(assert_malformed
+  (module quote "(func (v128.const i16x8 0x10000 0x10000 0x10000 0x10000 0x10000 0x10000 0x10000 0x10000) drop)")
+  "constant out of range"
+)
+(assert_malformed
+  (module quote "(func (v128.const i32x4  0x100000000 0x100000000 0x100000000 0x100000000) drop)")
+  "constant out of range"
+)
+(assert_malformed
+  (module quote "(func (v128.const f32x4 0x1p128 0x1p128 0x1p128 0x1p128) drop)")
+  "constant out of range"
+)
+
+;; More or less than the lane count
+
+(assert_malformed
+  (module quote "(func (v128.const i32x4 0x10000000000000000 0x10000000000000000) drop)")
+  "wrong number of lane literals"
+)
+(assert_malformed
+  (module quote "(func (v128.const i32x4 0 0 0) drop)")
+  "wrong number of lane literals"
+)
+(assert_malformed
+  (module quote "(func (v128.const i64x2 0 0 0) drop)")
+  "wrong number of lane literals"
+)
+(assert_malformed
+  (module quote "(func (v128.const 0 0 0 0) drop)")
+  "unexpected token"
+)
+
+;; Constants as results
+
+(module
+  (func (export "f32x4") (result v128) (v128.const f32x4 nan 1 -0 inf))
+  (func (export "f64x2") (result v128) (v128.const f64x2 nan:0x4000000000000 -nan))
+  (func (export "i8x16") (result v128) (v128.const i8x16 0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15))
+)
+
+(assert_return (invoke "f32x4") (v128.const f32x4 nan:canonical 1 -0 inf))
+(assert_return (invoke "f64x2") (v128.const f64x2 nan:arithmetic nan:canonical))
+(assert_return (invoke "i8x16") (v128.const i8x16 0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15))
@@ -8,3 +8,4 @@
 "ESC[0m: \1b[0m"
 "smiley: ☺️"
 "smiley: \u{263a}\u{fe0f}"
+"bytes: \ff\00\c3\a9\80"
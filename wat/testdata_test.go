@@ -6,3 +6,7 @@ import (
 
 //go:embed testdata/*
 var testDataFS embed.FS
+
+// TestDataFS exposes the test data to package wat_test, whose tests use
+// packages that import this one.
+var TestDataFS = testDataFS
@@ -8,6 +8,7 @@ type Token struct {
 	Type  TokenType
 	Value any
 	Span  Span
+	Text  string
 }
 
 func (token Token) GoString() string {
@@ -62,6 +63,8 @@ func (token Token) Validate() error {
 		return token.validateString()
 	case IdentifierToken:
 		return token.validateString()
+	case AnnotationToken:
+		return token.validateString()
 	case StringToken:
 		return token.validateString()
 	case NumberToken:
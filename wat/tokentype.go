@@ -19,6 +19,7 @@ const (
 	NumberToken
 	OpenParenToken
 	CloseParenToken
+	AnnotationToken
 )
 
 var tokenTypeGoNames = [...]string{
@@ -34,6 +35,7 @@ var tokenTypeGoNames = [...]string{
 	"wat.NumberToken",
 	"wat.OpenParenToken",
 	"wat.CloseParenToken",
+	"wat.AnnotationToken",
 }
 
 var tokenTypeNames = [...]string{
@@ -49,12 +51,13 @@ var tokenTypeNames = [...]string{
 	"Number",
 	"OpenParen",
 	"CloseParen",
+	"Annotation",
 }
 
 var tokenTypeNodeTypes = [...]NodeType{
 	InvalidNode,
 	InvalidNode,
-	InvalidNode,
+	ErrorNode,
 	SpaceNode,
 	LineCommentNode,
 	BlockCommentNode,
@@ -64,6 +67,7 @@ var tokenTypeNodeTypes = [...]NodeType{
 	NumberNode,
 	InvalidNode,
 	InvalidNode,
+	AnnotationNode,
 }
 
 func (enum TokenType) GoString() string {